@@ -1,61 +1,355 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"log"
 	"os"
+	"strconv"
+	"time"
 
+	"devconnectstorage/internal/application/policy"
+	appendresumableupload "devconnectstorage/internal/application/usecase/append_resumable_upload"
+	cleanupstaleuploads "devconnectstorage/internal/application/usecase/cleanup_stale_uploads"
+	completeupload "devconnectstorage/internal/application/usecase/complete_upload"
+	createresumableupload "devconnectstorage/internal/application/usecase/create_resumable_upload"
+	createsharelink "devconnectstorage/internal/application/usecase/create_share_link"
 	deletefile "devconnectstorage/internal/application/usecase/delete_file"
+	downloadsharedfile "devconnectstorage/internal/application/usecase/download_shared_file"
+	getcleanupjob "devconnectstorage/internal/application/usecase/get_cleanup_job"
 	getfile "devconnectstorage/internal/application/usecase/get_file"
+	getfiledownloadurl "devconnectstorage/internal/application/usecase/get_file_download_url"
+	getfilemetadata "devconnectstorage/internal/application/usecase/get_file_metadata"
+	getprojecttree "devconnectstorage/internal/application/usecase/get_project_tree"
+	getresumableupload "devconnectstorage/internal/application/usecase/get_resumable_upload"
+	getstorageusage "devconnectstorage/internal/application/usecase/get_storage_usage"
+	initiateupload "devconnectstorage/internal/application/usecase/initiate_upload"
+	listfileversions "devconnectstorage/internal/application/usecase/list_file_versions"
+	listfiles "devconnectstorage/internal/application/usecase/list_files"
+	listsharelinks "devconnectstorage/internal/application/usecase/list_share_links"
+	listsharedwithme "devconnectstorage/internal/application/usecase/list_shared_with_me"
+	listtrash "devconnectstorage/internal/application/usecase/list_trash"
+	movefolder "devconnectstorage/internal/application/usecase/move_folder"
+	purgedeletedfiles "devconnectstorage/internal/application/usecase/purge_deleted_files"
+	receivedeletionevent "devconnectstorage/internal/application/usecase/receive_deletion_event"
+	resolveprojectpath "devconnectstorage/internal/application/usecase/resolve_project_path"
+	restorefile "devconnectstorage/internal/application/usecase/restore_file"
+	restorefileversion "devconnectstorage/internal/application/usecase/restore_file_version"
+	revokefileshare "devconnectstorage/internal/application/usecase/revoke_file_share"
+	revokesharelink "devconnectstorage/internal/application/usecase/revoke_share_link"
+	runcleanupjobs "devconnectstorage/internal/application/usecase/run_cleanup_jobs"
+	searchfiles "devconnectstorage/internal/application/usecase/search_files"
+	sharefile "devconnectstorage/internal/application/usecase/share_file"
+	terminateresumableupload "devconnectstorage/internal/application/usecase/terminate_resumable_upload"
+	updatefilemetadata "devconnectstorage/internal/application/usecase/update_file_metadata"
 	uploadfile "devconnectstorage/internal/application/usecase/upload_file"
+	uploadfileversion "devconnectstorage/internal/application/usecase/upload_file_version"
 	"devconnectstorage/internal/infraestructure/inbound/rest"
+	"devconnectstorage/internal/infraestructure/inbound/scheduler"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
 	"devconnectstorage/internal/infraestructure/outbound/generator/uuidgen"
+	"devconnectstorage/internal/infraestructure/outbound/hasher/bcrypthasher"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	blobMongodb "devconnectstorage/internal/infraestructure/outbound/repository/blob/mongodb"
+	cleanupJobMongodb "devconnectstorage/internal/infraestructure/outbound/repository/cleanupjob/mongodb"
 	"devconnectstorage/internal/infraestructure/outbound/repository/file/mongodb"
+	shareLinkMongodb "devconnectstorage/internal/infraestructure/outbound/repository/sharelink/mongodb"
+	uploadSessionMongodb "devconnectstorage/internal/infraestructure/outbound/repository/uploadsession/mongodb"
+	usageMongodb "devconnectstorage/internal/infraestructure/outbound/repository/usage/mongodb"
+	"devconnectstorage/internal/infraestructure/outbound/signer/hmacsigner"
 	minioStorage "devconnectstorage/internal/infraestructure/outbound/storage/minio"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Storage rejects multipart parts smaller than this, except for the last one.
+const resumableUploadPartSize = 5 * 1024 * 1024
+
 func main() {
 
 	mongoURI := os.Getenv("MONGO_URI")
 	mongoDB := os.Getenv("MONGO_DB")
 	mongoCollection := os.Getenv("MONGO_COLLECTION")
+	shareLinkCollection := os.Getenv("MONGO_SHARE_LINK_COLLECTION")
+	if shareLinkCollection == "" {
+		shareLinkCollection = "share_links"
+	}
+	uploadSessionCollection := os.Getenv("MONGO_UPLOAD_SESSION_COLLECTION")
+	if uploadSessionCollection == "" {
+		uploadSessionCollection = "upload_sessions"
+	}
+	blobCollection := os.Getenv("MONGO_BLOB_COLLECTION")
+	if blobCollection == "" {
+		blobCollection = "blobs"
+	}
+	storageUsageCollection := os.Getenv("MONGO_STORAGE_USAGE_COLLECTION")
+	if storageUsageCollection == "" {
+		storageUsageCollection = "storage_usage"
+	}
+	cleanupJobCollection := os.Getenv("MONGO_CLEANUP_JOB_COLLECTION")
+	if cleanupJobCollection == "" {
+		cleanupJobCollection = "cleanup_jobs"
+	}
 	authBaseURL := os.Getenv("AUTH_URI")
+	projectBaseURL := os.Getenv("PROJECT_URI")
 
 	minioEndpoint := os.Getenv("MINIO_ENDPOINT")
 	minioUser := os.Getenv("MINIO_USER")
 	minioPassword := os.Getenv("MINIO_PASSWORD")
 	minioBucket := os.Getenv("MINIO_BUCKET")
 	minioSSL := os.Getenv("MINIO_USE_SSL") == "true"
+	minioPublicEndpoint := os.Getenv("MINIO_PUBLIC_ENDPOINT")
+	minioPublicSSL := os.Getenv("MINIO_PUBLIC_USE_SSL") == "true"
+	minioRegion := os.Getenv("MINIO_REGION")
+	if minioRegion == "" {
+		minioRegion = "us-east-1"
+	}
+
+	trashRetention := durationFromEnv("TRASH_RETENTION", 30*24*time.Hour)
+	trashPurgeInterval := durationFromEnv("TRASH_PURGE_INTERVAL", time.Hour)
+	pendingUploadTimeout := durationFromEnv("PENDING_UPLOAD_TIMEOUT", 24*time.Hour)
+	uploadSweepInterval := durationFromEnv("UPLOAD_SWEEP_INTERVAL", 15*time.Minute)
+	cleanupInterval := durationFromEnv("CLEANUP_INTERVAL", time.Minute)
+	cleanupBatchSize := int64FromEnv("CLEANUP_BATCH_SIZE", 100)
+	eventsWebhookSecret := os.Getenv("EVENTS_WEBHOOK_SECRET")
+	presignedUploadExpiry := durationFromEnv("PRESIGNED_UPLOAD_EXPIRY", 15*time.Minute)
+	presignedDownloadExpiry := durationFromEnv("PRESIGNED_DOWNLOAD_EXPIRY", 5*time.Minute)
+	resumableUploadExpiry := durationFromEnv("RESUMABLE_UPLOAD_EXPIRY", pendingUploadTimeout)
+	redirectDownloads := os.Getenv("DOWNLOAD_REDIRECT") == "true"
+	maxUploadSize := int64FromEnv("MAX_UPLOAD_SIZE", 5*1024*1024*1024)
+	contentAddressedStorage := os.Getenv("CONTENT_ADDRESSED_STORAGE") == "true"
+	uploadPolicy := uploadPolicyFromFile(os.Getenv("UPLOAD_POLICY_FILE"))
+	storageQuota := storageQuotaFromFile(os.Getenv("STORAGE_QUOTA_FILE"), int64FromEnv("DEFAULT_STORAGE_QUOTA", 0))
 
 	fileRepo, err := mongodb.NewMongoFileRepository(mongoURI, "", "", mongoDB, mongoCollection)
 	if err != nil {
 		log.Fatalf("failed to initialize Mongo repository: %v", err)
 	}
 
+	shareLinkRepo, err := shareLinkMongodb.NewMongoShareLinkRepository(mongoURI, mongoDB, shareLinkCollection)
+	if err != nil {
+		log.Fatalf("failed to initialize Mongo share link repository: %v", err)
+	}
+
+	uploadSessionRepo, err := uploadSessionMongodb.NewMongoUploadSessionRepository(mongoURI, mongoDB, uploadSessionCollection)
+	if err != nil {
+		log.Fatalf("failed to initialize Mongo upload session repository: %v", err)
+	}
+
+	blobRepo, err := blobMongodb.NewMongoBlobRepository(mongoURI, mongoDB, blobCollection)
+	if err != nil {
+		log.Fatalf("failed to initialize Mongo blob repository: %v", err)
+	}
+
+	storageUsageRepo, err := usageMongodb.NewMongoStorageUsageRepository(mongoURI, mongoDB, storageUsageCollection)
+	if err != nil {
+		log.Fatalf("failed to initialize Mongo storage usage repository: %v", err)
+	}
+
+	cleanupJobRepo, err := cleanupJobMongodb.NewMongoCleanupJobRepository(mongoURI, mongoDB, cleanupJobCollection)
+	if err != nil {
+		log.Fatalf("failed to initialize Mongo cleanup job repository: %v", err)
+	}
+
+	indexCtx, cancelIndexes := context.WithTimeout(context.Background(), 10*time.Second)
+	if err := fileRepo.EnsureIndexes(indexCtx); err != nil {
+		log.Printf("failed to ensure Mongo indexes: %v", err)
+	}
+	if err := shareLinkRepo.EnsureIndexes(indexCtx); err != nil {
+		log.Printf("failed to ensure Mongo share link indexes: %v", err)
+	}
+	if err := uploadSessionRepo.EnsureIndexes(indexCtx); err != nil {
+		log.Printf("failed to ensure Mongo upload session indexes: %v", err)
+	}
+	if err := cleanupJobRepo.EnsureIndexes(indexCtx); err != nil {
+		log.Printf("failed to ensure Mongo cleanup job indexes: %v", err)
+	}
+	cancelIndexes()
+
+	shareLinkSigner, err := hmacsigner.NewHMACSigner(shareLinkSecret())
+	if err != nil {
+		log.Fatalf("failed to initialize share link signer: %v", err)
+	}
+
 	storage, err := minioStorage.NewMinIOStorage(minioEndpoint, minioUser, minioPassword, minioSSL, minioBucket)
 	if err != nil {
 		log.Fatalf("failed to initialize MinIO storage: %v", err)
 	}
+	if minioPublicEndpoint != "" {
+		if err := storage.UsePublicEndpoint(minioPublicEndpoint, minioUser, minioPassword, minioPublicSSL, minioRegion); err != nil {
+			log.Fatalf("failed to initialize MinIO public endpoint: %v", err)
+		}
+	}
 
 	authClient := auth.NewAuthClient(authBaseURL)
 
+	membershipClient := project.NewProjectMembershipClient(projectBaseURL)
+
 	idGenerator := uuidgen.UUIDGenerator{}
 
-	uploadFileUseCase := uploadfile.NewUploadFileUseCase(fileRepo, storage, idGenerator, authClient)
+	uploadFileUseCase := uploadfile.NewUploadFileUseCase(fileRepo, storage, idGenerator, authClient, uploadPolicy, storageUsageRepo, storageQuota, nil)
+	if contentAddressedStorage {
+		uploadFileUseCase = uploadfile.NewUploadFileUseCase(fileRepo, storage, idGenerator, authClient, uploadPolicy, storageUsageRepo, storageQuota, blobRepo)
+	}
+
+	getFileUseCase := getfile.NewGetFileByIdUseCase(fileRepo, storage, authClient, membershipClient)
+	getFileMetadataUseCase := getfilemetadata.NewGetFileMetadataUseCase(fileRepo, authClient, membershipClient)
+	listFilesUseCase := listfiles.NewListFilesUseCase(fileRepo, authClient, membershipClient)
+	searchFilesUseCase := searchfiles.NewSearchFilesUseCase(fileRepo, authClient, membershipClient)
+	getProjectTreeUseCase := getprojecttree.NewGetProjectTreeUseCase(fileRepo, authClient, membershipClient)
+	resolveProjectPathUseCase := resolveprojectpath.NewResolveProjectPathUseCase(fileRepo, authClient, membershipClient)
+	moveFolderUseCase := movefolder.NewMoveFolderUseCase(fileRepo, authClient)
+
+	deleteFileUseCase := deletefile.NewDeleteFileUseCase(fileRepo, storage, authClient, blobRepo, storageUsageRepo)
+
+	listTrashUseCase := listtrash.NewListTrashUseCase(fileRepo, authClient)
+
+	restoreFileUseCase := restorefile.NewRestoreFileUseCase(fileRepo, authClient)
+
+	purgeDeletedFilesUseCase := purgedeletedfiles.NewPurgeDeletedFilesUseCase(fileRepo, storage, blobRepo, storageUsageRepo)
+
+	cleanupStaleUploadsUseCase := cleanupstaleuploads.NewCleanupStaleUploadsUseCase(fileRepo, uploadSessionRepo, storage, storageUsageRepo)
+
+	uploadFileVersionUseCase := uploadfileversion.NewUploadFileVersionUseCase(fileRepo, storage, authClient, uploadPolicy, storageUsageRepo, storageQuota)
+
+	listFileVersionsUseCase := listfileversions.NewListFileVersionsUseCase(fileRepo, authClient, membershipClient)
+
+	restoreFileVersionUseCase := restorefileversion.NewRestoreFileVersionUseCase(fileRepo, authClient)
+
+	updateFileMetadataUseCase := updatefilemetadata.NewUpdateFileMetadataUseCase(fileRepo, storage, authClient)
+
+	shareFileUseCase := sharefile.NewShareFileUseCase(fileRepo, authClient)
+
+	revokeFileShareUseCase := revokefileshare.NewRevokeFileShareUseCase(fileRepo, authClient)
+
+	listSharedWithMeUseCase := listsharedwithme.NewListSharedWithMeUseCase(fileRepo, authClient)
+
+	passwordHasher := bcrypthasher.BcryptHasher{}
+
+	createShareLinkUseCase := createsharelink.NewCreateShareLinkUseCase(fileRepo, shareLinkRepo, idGenerator, shareLinkSigner, passwordHasher, authClient)
+
+	listShareLinksUseCase := listsharelinks.NewListShareLinksUseCase(fileRepo, shareLinkRepo, shareLinkSigner, authClient)
+
+	revokeShareLinkUseCase := revokesharelink.NewRevokeShareLinkUseCase(fileRepo, shareLinkRepo, authClient)
 
-	getFileUseCase := getfile.NewGetFileByIdUseCase(fileRepo, storage, authClient)
+	downloadSharedFileUseCase := downloadsharedfile.NewDownloadSharedFileUseCase(fileRepo, shareLinkRepo, storage, shareLinkSigner, passwordHasher)
 
-	deleteFileUseCase := deletefile.NewDeleteFileUseCase(fileRepo, storage, authClient)
+	initiateUploadUseCase := initiateupload.NewInitiateUploadUseCase(fileRepo, storage, idGenerator, authClient, uploadPolicy, presignedUploadExpiry)
 
-	fileController := rest.NewFileRestController(uploadFileUseCase, getFileUseCase, deleteFileUseCase)
+	completeUploadUseCase := completeupload.NewCompleteUploadUseCase(fileRepo, storage, authClient, uploadPolicy, storageUsageRepo, storageQuota, nil)
+	if contentAddressedStorage {
+		completeUploadUseCase = completeupload.NewCompleteUploadUseCase(fileRepo, storage, authClient, uploadPolicy, storageUsageRepo, storageQuota, blobRepo)
+	}
+
+	getFileDownloadURLUseCase := getfiledownloadurl.NewGetFileDownloadURLUseCase(fileRepo, storage, authClient, membershipClient, presignedDownloadExpiry)
+
+	createResumableUploadUseCase := createresumableupload.NewCreateResumableUploadUseCase(fileRepo, uploadSessionRepo, storage, idGenerator, authClient, uploadPolicy, storageUsageRepo, storageQuota, resumableUploadExpiry)
+
+	getResumableUploadUseCase := getresumableupload.NewGetResumableUploadUseCase(uploadSessionRepo, authClient)
+
+	appendResumableUploadUseCase := appendresumableupload.NewAppendResumableUploadUseCase(fileRepo, uploadSessionRepo, storage, authClient, uploadPolicy, storageUsageRepo, nil, resumableUploadPartSize)
+	if contentAddressedStorage {
+		appendResumableUploadUseCase = appendresumableupload.NewAppendResumableUploadUseCase(fileRepo, uploadSessionRepo, storage, authClient, uploadPolicy, storageUsageRepo, blobRepo, resumableUploadPartSize)
+	}
+
+	terminateResumableUploadUseCase := terminateresumableupload.NewTerminateResumableUploadUseCase(fileRepo, uploadSessionRepo, storage, authClient, storageUsageRepo)
+
+	getStorageUsageUseCase := getstorageusage.NewGetStorageUsageUseCase(storageUsageRepo, authClient, storageQuota)
+
+	receiveDeletionEventUseCase := receivedeletionevent.NewReceiveDeletionEventUseCase(cleanupJobRepo)
+
+	getCleanupJobUseCase := getcleanupjob.NewGetCleanupJobUseCase(cleanupJobRepo)
+
+	runCleanupJobsUseCase := runcleanupjobs.NewRunCleanupJobsUseCase(cleanupJobRepo, fileRepo)
+
+	fileController := rest.NewFileRestController(uploadFileUseCase, getFileUseCase, getFileMetadataUseCase, deleteFileUseCase, updateFileMetadataUseCase, getFileDownloadURLUseCase, listFilesUseCase, redirectDownloads, maxUploadSize)
+
+	trashController := rest.NewTrashRestController(listTrashUseCase, restoreFileUseCase)
+
+	versionController := rest.NewFileVersionRestController(uploadFileVersionUseCase, listFileVersionsUseCase, restoreFileVersionUseCase, getFileUseCase)
+
+	shareController := rest.NewShareRestController(shareFileUseCase, revokeFileShareUseCase, listSharedWithMeUseCase)
+
+	shareLinkController := rest.NewShareLinkRestController(createShareLinkUseCase, listShareLinksUseCase, revokeShareLinkUseCase, downloadSharedFileUseCase)
+
+	directUploadController := rest.NewDirectUploadRestController(initiateUploadUseCase, completeUploadUseCase)
+
+	tusController := rest.NewTusRestController(createResumableUploadUseCase, getResumableUploadUseCase, appendResumableUploadUseCase, terminateResumableUploadUseCase, "/files/tus")
+
+	storageController := rest.NewStorageRestController(getStorageUsageUseCase)
+
+	searchController := rest.NewSearchRestController(searchFilesUseCase)
+
+	projectTreeController := rest.NewProjectTreeRestController(getProjectTreeUseCase, resolveProjectPathUseCase, moveFolderUseCase)
+
+	eventController := rest.NewEventRestController(receiveDeletionEventUseCase, getCleanupJobUseCase, []byte(eventsWebhookSecret))
+
+	scheduler.NewJob("trash-purge", trashPurgeInterval, func(ctx context.Context) error {
+		_, err := purgeDeletedFilesUseCase.Execute(ctx, purgedeletedfiles.PurgeDeletedFilesCommand{
+			DeletedBefore: time.Now().Add(-trashRetention),
+			Limit:         100,
+		})
+		return err
+	}).Start(context.Background())
+
+	scheduler.NewJob("cascade-cleanup", cleanupInterval, func(ctx context.Context) error {
+		_, err := runCleanupJobsUseCase.Execute(ctx, runcleanupjobs.RunCleanupJobsCommand{
+			JobLimit:  10,
+			BatchSize: cleanupBatchSize,
+		})
+		return err
+	}).Start(context.Background())
+
+	scheduler.NewJob("stale-upload-sweep", uploadSweepInterval, func(ctx context.Context) error {
+		_, err := cleanupStaleUploadsUseCase.Execute(ctx, cleanupstaleuploads.CleanupStaleUploadsCommand{
+			StartedBefore: time.Now().Add(-pendingUploadTimeout),
+			Limit:         100,
+		})
+		return err
+	}).Start(context.Background())
 
 	router := gin.Default()
+	router.Use(rest.ErrorHandler())
+	router.GET("/files", fileController.ListFiles)
 	router.POST("/files", fileController.UploadFile)
+	router.PUT("/files", fileController.UploadRawFile)
+	router.POST("/files/uploads", directUploadController.InitiateUpload)
+	router.POST("/files/:id/complete", directUploadController.CompleteUpload)
+	router.OPTIONS("/files/tus", tusController.Options)
+	router.POST("/files/tus", tusController.CreateUpload)
+	router.HEAD("/files/tus/:id", tusController.GetOffset)
+	router.PATCH("/files/tus/:id", tusController.AppendChunk)
+	router.DELETE("/files/tus/:id", tusController.TerminateUpload)
+	router.GET("/files/search", searchController.SearchFiles)
+	router.GET("/files/trash", trashController.ListTrash)
+	router.POST("/files/:id/restore", trashController.RestoreFile)
+	router.GET("/files/shared-with-me", shareController.ListSharedWithMe)
+	router.POST("/files/:id/shares", shareController.ShareFile)
+	router.DELETE("/files/:id/shares/:profileId", shareController.RevokeShare)
+	router.POST("/files/:id/links", shareLinkController.CreateLink)
+	router.GET("/files/:id/links", shareLinkController.ListLinks)
+	router.DELETE("/files/:id/links/:linkId", shareLinkController.RevokeLink)
+	router.GET("/s/:token", shareLinkController.DownloadSharedFile)
+	router.POST("/s/:token", shareLinkController.DownloadSharedFile)
+	router.POST("/files/:id/versions", versionController.UploadVersion)
+	router.GET("/files/:id/versions", versionController.ListVersions)
+	router.GET("/files/:id/versions/:version/content", versionController.GetVersionContent)
+	router.POST("/files/:id/versions/:version/restore", versionController.RestoreVersion)
 	router.GET("/files/:id", fileController.GetFileMetadataById)
 	router.GET("/files/:id/content", fileController.GetFileContentById)
+	router.HEAD("/files/:id/content", fileController.HeadFileContent)
+	router.PATCH("/files/:id", fileController.UpdateFile)
 	router.DELETE("/files/:id", fileController.DeleteFile)
+	router.GET("/me/storage", storageController.GetMyStorage)
+	router.GET("/projects/:projectId/tree", projectTreeController.GetTree)
+	router.POST("/projects/:projectId/tree/move", projectTreeController.MoveFolder)
+	router.GET("/projects/:projectId/files/*path", projectTreeController.ResolvePath)
+	if eventsWebhookSecret != "" {
+		router.POST("/events", eventController.ReceiveEvent)
+		router.GET("/events/:id", eventController.GetEvent)
+	} else {
+		log.Printf("EVENTS_WEBHOOK_SECRET is not set; deletion events will not be received")
+	}
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -67,3 +361,71 @@ func main() {
 		log.Fatalf("failed to start server: %v", err)
 	}
 }
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("invalid duration for %s: %v", key, err)
+	}
+	return duration
+}
+
+func int64FromEnv(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid integer for %s: %v", key, err)
+	}
+	return parsed
+}
+
+// uploadPolicyFromFile reads the upload policy from a JSON file; without one
+// every upload is allowed.
+func uploadPolicyFromFile(path string) policy.UploadPolicy {
+	if path == "" {
+		return policy.UploadPolicy{}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read upload policy: %v", err)
+	}
+	var uploadPolicy policy.UploadPolicy
+	if err := json.Unmarshal(data, &uploadPolicy); err != nil {
+		log.Fatalf("invalid upload policy in %s: %v", path, err)
+	}
+	return uploadPolicy
+}
+
+// storageQuotaFromFile reads per-profile storage limits from a JSON file on
+// top of defaultLimit; a limit of zero leaves storage unlimited.
+func storageQuotaFromFile(path string, defaultLimit int64) policy.StorageQuota {
+	quota := policy.StorageQuota{DefaultLimit: defaultLimit}
+	if path == "" {
+		return quota
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("failed to read storage quota: %v", err)
+	}
+	if err := json.Unmarshal(data, &quota); err != nil {
+		log.Fatalf("invalid storage quota in %s: %v", path, err)
+	}
+	return quota
+}
+
+// shareLinkSecret is required: a generated secret would differ between
+// instances and restarts, silently invalidating links already handed out.
+func shareLinkSecret() []byte {
+	secret := os.Getenv("SHARE_LINK_SECRET")
+	if secret == "" {
+		log.Fatalf("SHARE_LINK_SECRET must be set")
+	}
+	return []byte(secret)
+}
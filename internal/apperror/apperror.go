@@ -0,0 +1,89 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrNotFound            = errors.New("not found")
+	ErrForbidden           = errors.New("forbidden")
+	ErrUnauthenticated     = errors.New("unauthenticated")
+	ErrValidation          = errors.New("validation failed")
+	ErrConflict            = errors.New("conflict")
+	ErrPayloadTooLarge     = errors.New("payload too large")
+	ErrUnsupportedMedia    = errors.New("unsupported media type")
+	ErrInsufficientStorage = errors.New("insufficient storage")
+	ErrUpstreamUnavailable = errors.New("upstream unavailable")
+)
+
+var kinds = []error{
+	ErrNotFound,
+	ErrForbidden,
+	ErrUnauthenticated,
+	ErrValidation,
+	ErrConflict,
+	ErrPayloadTooLarge,
+	ErrUnsupportedMedia,
+	ErrInsufficientStorage,
+	ErrUpstreamUnavailable,
+}
+
+// Error classifies a failure by kind. Message is written for clients; the
+// cause is only kept for logs and errors.Is/As.
+type Error struct {
+	kind    error
+	message string
+	cause   error
+}
+
+func New(kind error, format string, args ...any) error {
+	return &Error{kind: kind, message: fmt.Sprintf(format, args...)}
+}
+
+func Wrap(kind error, cause error, message string) error {
+	return &Error{kind: kind, message: message, cause: cause}
+}
+
+func (e *Error) Error() string {
+	if e.cause == nil {
+		return e.message
+	}
+	return e.message + ": " + e.cause.Error()
+}
+
+func (e *Error) Message() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() []error {
+	if e.cause == nil {
+		return []error{e.kind}
+	}
+	return []error{e.kind, e.cause}
+}
+
+// KindOf returns the first kind found in err's chain, or nil when err was
+// never classified.
+func KindOf(err error) error {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.kind
+	}
+	for _, kind := range kinds {
+		if errors.Is(err, kind) {
+			return kind
+		}
+	}
+	return nil
+}
+
+// MessageOf returns the client-facing message of the outermost classified
+// error in err's chain.
+func MessageOf(err error) string {
+	var classified *Error
+	if errors.As(err, &classified) {
+		return classified.message
+	}
+	return ""
+}
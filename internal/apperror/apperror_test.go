@@ -0,0 +1,38 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew_ShouldClassifyWithFormattedMessage(t *testing.T) {
+	err := New(ErrNotFound, "version %d not found", 3)
+
+	assert.EqualError(t, err, "version 3 not found")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, ErrNotFound, KindOf(err))
+	assert.Equal(t, "version 3 not found", MessageOf(err))
+}
+
+func TestWrap_ShouldKeepCauseOutOfMessage(t *testing.T) {
+	cause := errors.New("dial tcp: connection refused")
+	err := Wrap(ErrUpstreamUnavailable, cause, "database unavailable")
+
+	assert.ErrorIs(t, err, cause)
+	assert.ErrorIs(t, err, ErrUpstreamUnavailable)
+	assert.Equal(t, "database unavailable: dial tcp: connection refused", err.Error())
+	assert.Equal(t, "database unavailable", MessageOf(err))
+}
+
+func TestKindOf_ShouldPreferOutermostClassification(t *testing.T) {
+	inner := New(ErrNotFound, "blob not found")
+	outer := Wrap(ErrConflict, inner, "file changed")
+
+	assert.Equal(t, ErrConflict, KindOf(fmt.Errorf("upload: %w", outer)))
+	assert.Equal(t, ErrValidation, KindOf(fmt.Errorf("bad input: %w", ErrValidation)))
+	assert.Nil(t, KindOf(errors.New("boom")))
+	assert.Empty(t, MessageOf(errors.New("boom")))
+}
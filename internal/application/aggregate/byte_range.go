@@ -0,0 +1,66 @@
+package aggregate
+
+import (
+	"errors"
+	"io"
+	"time"
+)
+
+var ErrRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// RangeSpec is a requested byte range before the content size is known.
+// A negative First asks for the last Last bytes; a negative Last reads
+// from First to the end.
+type RangeSpec struct {
+	First int64
+	Last  int64
+}
+
+type ByteRange struct {
+	Start  int64
+	Length int64
+}
+
+type RangeContent struct {
+	Range   ByteRange
+	Content io.ReadCloser
+}
+
+// RangeCondition carries an If-Range validator: ranges are only served while
+// the content still matches the ETag or Last-Modified the client saw.
+type RangeCondition struct {
+	ETag         string
+	LastModified time.Time
+}
+
+func (spec RangeSpec) Resolve(size int64) (ByteRange, bool) {
+	if spec.First < 0 {
+		if spec.Last <= 0 || size == 0 {
+			return ByteRange{}, false
+		}
+		length := min(spec.Last, size)
+		return ByteRange{Start: size - length, Length: length}, true
+	}
+	if spec.First >= size {
+		return ByteRange{}, false
+	}
+	last := size - 1
+	if spec.Last >= 0 && spec.Last < last {
+		last = spec.Last
+	}
+	return ByteRange{Start: spec.First, Length: last - spec.First + 1}, true
+}
+
+func (r ByteRange) End() int64 {
+	return r.Start + r.Length - 1
+}
+
+func (condition RangeCondition) Matches(validators Validators) bool {
+	if condition.ETag != "" {
+		return validators.ETag != "" && condition.ETag == validators.ETag
+	}
+	if !condition.LastModified.IsZero() {
+		return condition.LastModified.Equal(validators.LastModified)
+	}
+	return true
+}
@@ -0,0 +1,55 @@
+package aggregate
+
+import (
+	"devconnectstorage/internal/domain"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var ErrNotModified = errors.New("not modified")
+
+// Validators identify the current representation of a file's content for
+// conditional requests.
+type Validators struct {
+	ETag         string
+	LastModified time.Time
+}
+
+type Preconditions struct {
+	IfNoneMatch     []string
+	IfModifiedSince time.Time
+}
+
+func NewValidators(file domain.File, etag string) Validators {
+	return Validators{
+		ETag:         etag,
+		LastModified: file.ContentModifiedAt().UTC().Truncate(time.Second),
+	}
+}
+
+// NewMetadataValidators identify a file's metadata, which changes with every
+// stored revision even when the content stays the same.
+func NewMetadataValidators(file domain.File) Validators {
+	return Validators{
+		ETag:         file.ID() + "-r" + strconv.FormatInt(file.Revision(), 10),
+		LastModified: file.UpdatedAt().UTC().Truncate(time.Second),
+	}
+}
+
+// NotModified evaluates If-None-Match first; If-Modified-Since is only
+// considered when no entity tags were sent, as RFC 9110 requires.
+func (preconditions Preconditions) NotModified(validators Validators) bool {
+	if len(preconditions.IfNoneMatch) > 0 {
+		for _, tag := range preconditions.IfNoneMatch {
+			if tag == "*" || (validators.ETag != "" && tag == validators.ETag) {
+				return true
+			}
+		}
+		return false
+	}
+	if !preconditions.IfModifiedSince.IsZero() {
+		return !validators.LastModified.After(preconditions.IfModifiedSince)
+	}
+	return false
+}
@@ -2,10 +2,24 @@ package aggregate
 
 import (
 	"devconnectstorage/internal/domain"
+	"errors"
 	"io"
 )
 
 type FileContent struct {
-	Metadata domain.File
-	Content  io.ReadCloser
+	Metadata   domain.File
+	Validators Validators
+	Content    io.ReadCloser
+	Parts      []RangeContent
+}
+
+func (content *FileContent) Close() error {
+	var closeErrors []error
+	if content.Content != nil {
+		closeErrors = append(closeErrors, content.Content.Close())
+	}
+	for _, part := range content.Parts {
+		closeErrors = append(closeErrors, part.Content.Close())
+	}
+	return errors.Join(closeErrors...)
 }
@@ -0,0 +1,107 @@
+package aggregate
+
+import (
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+)
+
+type FileSort string
+
+const (
+	SortByCreatedAt FileSort = "created_at"
+	SortBySize      FileSort = "size"
+	SortByName      FileSort = "name"
+)
+
+func (s FileSort) IsValid() bool {
+	return s == SortByCreatedAt || s == SortBySize || s == SortByName
+}
+
+// FileFilter narrows a listing; zero values match everything. Files must
+// carry every tag and attribute given. CreatedFrom is inclusive and CreatedTo
+// exclusive.
+type FileFilter struct {
+	OwnerID     string
+	ProjectID   *string
+	Visibility  domain.Visibility
+	Status      domain.Status
+	MimePrefix  string
+	Tags        []string
+	Attributes  map[string]string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	ReadableBy  *Reader
+}
+
+// Reader limits a listing to files a profile may read without owning them:
+// public files, files shared with it and project files of MemberOf.
+type Reader struct {
+	ProfileID string
+	MemberOf  []string
+}
+
+type FileListing struct {
+	Filter     FileFilter
+	Sort       FileSort
+	Descending bool
+	After      *FileCursor
+	Limit      int64
+}
+
+type FilePage struct {
+	Files      []domain.File
+	NextCursor string
+}
+
+// FileCursor marks where a page ended by the sort key and ID of its last
+// file, so later pages stay stable while files are added or removed.
+type FileCursor struct {
+	Sort       FileSort  `json:"sort"`
+	Descending bool      `json:"desc,omitempty"`
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	Size       int64     `json:"size,omitempty"`
+	Name       string    `json:"name,omitempty"`
+}
+
+func NewFileCursor(file domain.File, sort FileSort, descending bool) FileCursor {
+	cursor := FileCursor{Sort: sort, Descending: descending, ID: file.ID()}
+	switch sort {
+	case SortBySize:
+		cursor.Size = file.Size()
+	case SortByName:
+		cursor.Name = file.FileName()
+	default:
+		cursor.CreatedAt = file.CreatedAt()
+	}
+	return cursor
+}
+
+func DecodeFileCursor(token string) (FileCursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return FileCursor{}, apperror.Wrap(apperror.ErrValidation, err, "invalid cursor")
+	}
+	var cursor FileCursor
+	if err := json.Unmarshal(payload, &cursor); err != nil || cursor.ID == "" || !cursor.Sort.IsValid() {
+		return FileCursor{}, apperror.New(apperror.ErrValidation, "invalid cursor")
+	}
+	return cursor, nil
+}
+
+func (c FileCursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+// FileSearch ranks available files matching Text among those Reader owns or
+// may read, optionally within one project.
+type FileSearch struct {
+	Text      string
+	ProjectID *string
+	Reader    Reader
+	Limit     int64
+}
@@ -0,0 +1,12 @@
+package aggregate
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type PresignedDownload struct {
+	File      domain.File
+	URL       string
+	ExpiresAt time.Time
+}
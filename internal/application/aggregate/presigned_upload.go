@@ -0,0 +1,13 @@
+package aggregate
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type PresignedUpload struct {
+	File      domain.File
+	URL       string
+	Headers   map[string]string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,24 @@
+package aggregate
+
+import "devconnectstorage/internal/domain"
+
+// FolderListing asks for the direct children of Prefix in a project's folder
+// tree, limited to files Reader owns or may read. An empty Prefix is the root.
+// Children are listed by name, at most Limit folders and Limit files named
+// after After.
+type FolderListing struct {
+	ProjectID string
+	Prefix    string
+	Reader    Reader
+	After     string
+	Limit     int64
+}
+
+// Folder is one page of a folder's children. NextCursor is the name to list
+// after for the next page, empty on the last one.
+type Folder struct {
+	Prefix     string
+	Folders    []string
+	Files      []domain.File
+	NextCursor string
+}
@@ -0,0 +1,8 @@
+package aggregate
+
+import "devconnectstorage/internal/domain"
+
+type SignedShareLink struct {
+	Link  domain.ShareLink
+	Token string
+}
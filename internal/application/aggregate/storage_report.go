@@ -0,0 +1,10 @@
+package aggregate
+
+import "devconnectstorage/internal/domain"
+
+// StorageReport pairs an owner's usage with their quota; a Limit of zero or
+// less means unlimited.
+type StorageReport struct {
+	Usage domain.StorageUsage
+	Limit int64
+}
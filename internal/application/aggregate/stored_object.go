@@ -0,0 +1,8 @@
+package aggregate
+
+type StoredObject struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ETag        string
+}
@@ -0,0 +1,47 @@
+package checksum
+
+import (
+	"crypto/sha256"
+	"devconnectstorage/internal/apperror"
+	"encoding/hex"
+	"hash"
+	"io"
+)
+
+type Reader struct {
+	reader io.Reader
+	hash   hash.Hash
+	size   int64
+}
+
+func NewReader(reader io.Reader) *Reader {
+	return &Reader{
+		reader: reader,
+		hash:   sha256.New(),
+	}
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	r.size += int64(n)
+	return n, err
+}
+
+func (r *Reader) Size() int64 {
+	return r.size
+}
+
+func (r *Reader) Sum() string {
+	return hex.EncodeToString(r.hash.Sum(nil))
+}
+
+func (r *Reader) Verify(expected string) error {
+	if expected == "" {
+		return nil
+	}
+	if actual := r.Sum(); actual != expected {
+		return apperror.New(apperror.ErrValidation, "checksum mismatch: expected %s, computed %s", expected, actual)
+	}
+	return nil
+}
@@ -0,0 +1,31 @@
+package checksum
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestReader_HashesAndCountsWhatWasRead(t *testing.T) {
+	reader := NewReader(bytes.NewReader([]byte("hello")))
+
+	content, err := io.ReadAll(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Equal(t, int64(5), reader.Size())
+	assert.Equal(t, helloChecksum, reader.Sum())
+}
+
+func TestReader_Verify(t *testing.T) {
+	reader := NewReader(bytes.NewReader([]byte("hello")))
+	_, _ = io.ReadAll(reader)
+
+	assert.NoError(t, reader.Verify(""))
+	assert.NoError(t, reader.Verify(helloChecksum))
+	assert.Error(t, reader.Verify("0000"))
+}
@@ -0,0 +1,181 @@
+package ingest
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/checksum"
+	"devconnectstorage/internal/application/mimesniff"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"errors"
+	"io"
+)
+
+type FileRepository interface {
+	Update(ctx context.Context, file domain.File) error
+}
+
+type Storage interface {
+	SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error)
+	DeleteObject(ctx context.Context, storageKey string) error
+	PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error)
+}
+
+type BlobRepository interface {
+	GetBlob(ctx context.Context, checksum string) (domain.Blob, error)
+	Acquire(ctx context.Context, blob domain.Blob) (domain.Blob, error)
+	Release(ctx context.Context, checksum string) (bool, error)
+}
+
+// Ingester is the last step of every upload path: it checks content against
+// the upload policy, records what was stored and makes the file available.
+// Passing a blob repository switches to content-addressed storage shared
+// between files.
+type Ingester struct {
+	files        FileRepository
+	storage      Storage
+	uploadPolicy policy.UploadPolicy
+	blobs        BlobRepository
+}
+
+func NewIngester(files FileRepository, storage Storage, uploadPolicy policy.UploadPolicy, blobs BlobRepository) Ingester {
+	return Ingester{
+		files:        files,
+		storage:      storage,
+		uploadPolicy: uploadPolicy,
+		blobs:        blobs,
+	}
+}
+
+// Store copies content that reached storage before it could be inspected to
+// file's own key. Its type is sniffed and settled by the upload policy and its
+// checksum recorded on the way; content that is rejected marks file as failed.
+func (i Ingester) Store(ctx context.Context, file *domain.File, content io.Reader) (string, error) {
+	detection, upload, err := mimesniff.Detect(content)
+	if err != nil {
+		return "", err
+	}
+	mimeType, err := i.uploadPolicy.ResolveMimeType(file.ProjectID(), file.MimeType(), detection)
+	if err != nil {
+		return "", i.MarkAsFailed(ctx, file, err)
+	}
+	if err := i.uploadPolicy.Check(policy.UploadCandidate{
+		ProjectID:  file.ProjectID(),
+		FileName:   file.FileName(),
+		MimeType:   mimeType,
+		Size:       file.Size(),
+		Visibility: file.Visibility(),
+	}); err != nil {
+		return "", i.MarkAsFailed(ctx, file, err)
+	}
+	if err := file.RecordMimeType(mimeType); err != nil {
+		return "", err
+	}
+	if err := file.RecordDetectedMimeType(detection.MimeType()); err != nil {
+		return "", err
+	}
+
+	hashed := checksum.NewReader(upload)
+	storageKey, err := i.storage.SaveFile(ctx, hashed, *file)
+	if err != nil {
+		return "", err
+	}
+	if hashed.Size() != file.Size() {
+		return "", i.Discard(ctx, file, storageKey, apperror.New(apperror.ErrValidation, "uploaded size %d does not match declared size %d", hashed.Size(), file.Size()))
+	}
+	if err := file.RecordChecksum(hashed.Sum()); err != nil {
+		return "", i.Discard(ctx, file, storageKey, err)
+	}
+	return storageKey, nil
+}
+
+// Publish makes file available with the content under storageKey and saves
+// it. With content addressing the content joins the blob of its checksum and
+// storageKey is removed.
+func (i Ingester) Publish(ctx context.Context, file *domain.File, storageKey string) error {
+	if i.blobs != nil {
+		return i.storeAsBlob(ctx, file, storageKey)
+	}
+	if err := file.MarkAsAvailable(storageKey); err != nil {
+		return i.MarkAsFailed(ctx, file, err)
+	}
+	return i.files.Update(ctx, *file)
+}
+
+// Link makes file available from blob without storing any content.
+func (i Ingester) Link(ctx context.Context, file *domain.File, blob domain.Blob) error {
+	return i.linkBlob(ctx, file, blob, "")
+}
+
+// Discard removes content stored for file and marks it as failed.
+func (i Ingester) Discard(ctx context.Context, file *domain.File, storageKey string, cause error) error {
+	if err := i.storage.DeleteObject(ctx, storageKey); err != nil {
+		cause = errors.Join(cause, err)
+	}
+	return i.MarkAsFailed(ctx, file, cause)
+}
+
+func (i Ingester) MarkAsFailed(ctx context.Context, file *domain.File, cause error) error {
+	if err := file.MarkAsFailed(); err != nil {
+		return errors.Join(cause, err)
+	}
+	if err := i.files.Update(ctx, *file); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (i Ingester) storeAsBlob(ctx context.Context, file *domain.File, storageKey string) error {
+	blob, err := i.blobs.GetBlob(ctx, file.Checksum())
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		blob, err = i.promoteToBlob(ctx, *file, storageKey)
+	}
+	if err != nil {
+		return i.Discard(ctx, file, storageKey, err)
+	}
+
+	err = i.linkBlob(ctx, file, blob, storageKey)
+	_ = i.storage.DeleteObject(ctx, storageKey)
+	return err
+}
+
+func (i Ingester) promoteToBlob(ctx context.Context, file domain.File, storageKey string) (domain.Blob, error) {
+	blobKey, err := i.storage.PromoteBlob(ctx, storageKey, file.Checksum())
+	if err != nil {
+		return domain.Blob{}, err
+	}
+	return domain.NewBlob(file.Checksum(), blobKey, file.Size())
+}
+
+// linkBlob takes a reference on blob for file. uploadKey holds the uploaded
+// bytes, if any, so a blob whose last reference was released concurrently can
+// be re-promoted instead of pointing the file at a deleted object.
+func (i Ingester) linkBlob(ctx context.Context, file *domain.File, blob domain.Blob, uploadKey string) error {
+	acquired, err := i.blobs.Acquire(ctx, blob)
+	if err != nil {
+		return i.MarkAsFailed(ctx, file, err)
+	}
+	if acquired.References() == 1 && blob.References() > 0 {
+		if uploadKey == "" {
+			return i.MarkAsFailed(ctx, file, errors.Join(domain.ErrBlobNotFound, i.releaseBlob(ctx, acquired)))
+		}
+		if _, err := i.storage.PromoteBlob(ctx, uploadKey, file.Checksum()); err != nil {
+			return i.MarkAsFailed(ctx, file, errors.Join(err, i.releaseBlob(ctx, acquired)))
+		}
+	}
+	if err := file.MarkAsAvailableFromBlob(acquired); err != nil {
+		return i.MarkAsFailed(ctx, file, errors.Join(err, i.releaseBlob(ctx, acquired)))
+	}
+	if err := i.files.Update(ctx, *file); err != nil {
+		return errors.Join(err, i.releaseBlob(ctx, acquired))
+	}
+	return nil
+}
+
+func (i Ingester) releaseBlob(ctx context.Context, blob domain.Blob) error {
+	last, err := i.blobs.Release(ctx, blob.Checksum())
+	if err != nil || !last {
+		return err
+	}
+	return i.storage.DeleteObject(ctx, blob.StorageKey())
+}
@@ -0,0 +1,108 @@
+package ingest
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+type fileRepositoryMock struct {
+	updates []domain.Status
+}
+
+func (m *fileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	m.updates = append(m.updates, file.Status())
+	return nil
+}
+
+type storageMock struct {
+	saved   string
+	deleted []string
+}
+
+func (m *storageMock) SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error) {
+	saved, err := io.ReadAll(fileBytes)
+	m.saved = string(saved)
+	return "12/1/hello.txt", err
+}
+
+func (m *storageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	m.deleted = append(m.deleted, storageKey)
+	return nil
+}
+
+func (m *storageMock) PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error) {
+	return "blobs/" + checksum, nil
+}
+
+func pendingFile(t *testing.T, mimeType string, size int64) domain.File {
+	file, err := domain.RehydrateFile("1", "12", nil, "hello.txt", mimeType, size, "", domain.VisibilityPrivate, domain.StatusPending, time.Now())
+	require.NoError(t, err)
+	return file
+}
+
+func TestIngester_StoreRecordsWhatWasStored(t *testing.T) {
+	files, storage := &fileRepositoryMock{}, &storageMock{}
+	ingester := NewIngester(files, storage, policy.UploadPolicy{}, nil)
+	file := pendingFile(t, "text/plain", 5)
+
+	storageKey, err := ingester.Store(context.Background(), &file, strings.NewReader("hello"))
+
+	require.NoError(t, err)
+	assert.Equal(t, "12/1/hello.txt", storageKey)
+	assert.Equal(t, "hello", storage.saved)
+	assert.Equal(t, helloChecksum, file.Checksum())
+	assert.Equal(t, "text/plain", file.DetectedMimeType())
+	assert.Empty(t, files.updates)
+}
+
+func TestIngester_StoreMarksRejectedContentAsFailed(t *testing.T) {
+	files, storage := &fileRepositoryMock{}, &storageMock{}
+	uploadPolicy := policy.UploadPolicy{UploadRules: policy.UploadRules{MimeMismatch: policy.MimeMismatchReject}}
+	ingester := NewIngester(files, storage, uploadPolicy, nil)
+	file := pendingFile(t, "video/mp4", 5)
+
+	_, err := ingester.Store(context.Background(), &file, strings.NewReader("hello"))
+
+	assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+	assert.Empty(t, storage.saved)
+	assert.Equal(t, domain.StatusFailed, file.Status())
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, files.updates)
+}
+
+func TestIngester_StoreDiscardsContentOfTheWrongSize(t *testing.T) {
+	files, storage := &fileRepositoryMock{}, &storageMock{}
+	ingester := NewIngester(files, storage, policy.UploadPolicy{}, nil)
+	file := pendingFile(t, "text/plain", 10)
+
+	_, err := ingester.Store(context.Background(), &file, strings.NewReader("hello"))
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+	assert.Equal(t, []string{"12/1/hello.txt"}, storage.deleted)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, files.updates)
+}
+
+func TestIngester_PublishMakesFileAvailable(t *testing.T) {
+	files, storage := &fileRepositoryMock{}, &storageMock{}
+	ingester := NewIngester(files, storage, policy.UploadPolicy{}, nil)
+	file := pendingFile(t, "text/plain", 5)
+	_, err := ingester.Store(context.Background(), &file, strings.NewReader("hello"))
+	require.NoError(t, err)
+
+	err = ingester.Publish(context.Background(), &file, "12/1/hello.txt")
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusAvailable, file.Status())
+	assert.Equal(t, "12/1/hello.txt", file.StorageKey())
+	assert.Equal(t, []domain.Status{domain.StatusAvailable}, files.updates)
+}
@@ -0,0 +1,72 @@
+package mimesniff
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/gabriel-vasile/mimetype"
+)
+
+// sniffLength matches the number of bytes mimetype inspects by default.
+const sniffLength = 3072
+
+// plainTextFormats are text types the detector reports as text/plain. Other
+// text types, such as text/html, are detectable and must be sniffed as such.
+var plainTextFormats = map[string]bool{
+	"text/markdown":             true,
+	"text/x-markdown":           true,
+	"text/csv":                  true,
+	"text/tab-separated-values": true,
+	"text/x-log":                true,
+}
+
+type Detection struct {
+	mime *mimetype.MIME
+}
+
+// Detect sniffs the first bytes of content and returns a reader that still
+// yields the whole content.
+func Detect(content io.Reader) (Detection, io.Reader, error) {
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return Detection{}, nil, err
+	}
+	head = head[:n]
+	return Detection{mime: mimetype.Detect(head)}, io.MultiReader(bytes.NewReader(head), content), nil
+}
+
+// MimeType returns the detected media type without parameters.
+func (d Detection) MimeType() string {
+	if d.mime == nil {
+		return ""
+	}
+	mediaType, _, _ := strings.Cut(d.mime.String(), ";")
+	return mediaType
+}
+
+// Confirms reports whether declared is consistent with the content: it names
+// the detected type or one of its ancestors, or the detector could not have
+// told them apart. Plain text only confirms the formats in plainTextFormats.
+func (d Detection) Confirms(declared string) bool {
+	if d.mime == nil || strings.TrimSpace(declared) == "" {
+		return false
+	}
+	for mime := d.mime; mime != nil; mime = mime.Parent() {
+		if mime.Is(declared) {
+			return true
+		}
+	}
+	mediaType, _, _ := strings.Cut(strings.ToLower(declared), ";")
+	mediaType = strings.TrimSpace(mediaType)
+	switch {
+	case d.mime.Is("application/octet-stream"):
+		return mimetype.Lookup(mediaType) == nil
+	case d.mime.Is("text/plain"):
+		return plainTextFormats[mediaType]
+	default:
+		return false
+	}
+}
@@ -0,0 +1,51 @@
+package mimesniff
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const pngHeader = "\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"
+
+func TestDetect_KeepsFullContentReadable(t *testing.T) {
+	body := "<html><body>" + strings.Repeat("a", 2*sniffLength) + "</body></html>"
+
+	detection, content, err := Detect(strings.NewReader(body))
+	assert.NoError(t, err)
+
+	read, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(read))
+	assert.Equal(t, "text/html", detection.MimeType())
+}
+
+func TestDetection_Confirms(t *testing.T) {
+	detect := func(body string) Detection {
+		detection, _, _ := Detect(strings.NewReader(body))
+		return detection
+	}
+
+	html := detect("<html><body>hi</body></html>")
+	assert.False(t, html.Confirms("image/png"))
+	assert.True(t, html.Confirms("text/html; charset=utf-8"))
+	assert.True(t, html.Confirms("text/plain"))
+
+	png := detect(pngHeader)
+	assert.True(t, png.Confirms("image/png"))
+	assert.False(t, png.Confirms("application/pdf"))
+
+	text := detect("hello world")
+	assert.True(t, text.Confirms("text/markdown"))
+	assert.True(t, text.Confirms("text/csv; charset=utf-8"))
+	assert.False(t, text.Confirms("text/html"))
+	assert.False(t, text.Confirms("text/javascript"))
+	assert.False(t, text.Confirms("image/png"))
+
+	unknown := detect("\x00\x01\x02\x03")
+	assert.True(t, unknown.Confirms("application/x-custom"))
+	assert.False(t, unknown.Confirms("image/png"))
+	assert.False(t, unknown.Confirms(""))
+}
@@ -0,0 +1,50 @@
+package policy
+
+import (
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	"strconv"
+)
+
+func CanReadFile(membershipClient project.IProjectMembershipClient, token string, profileID int64, file domain.File) (bool, error) {
+	requesterID := strconv.FormatInt(profileID, 10)
+	if file.OwnerID() == requesterID {
+		return true, nil
+	}
+	if _, shared := file.PermissionFor(requesterID); shared {
+		return true, nil
+	}
+
+	switch file.Visibility() {
+	case domain.VisibilityPublic:
+		return true, nil
+	case domain.VisibilityProject:
+		return membershipClient.IsMember(token, *file.ProjectID(), profileID)
+	default:
+		return false, nil
+	}
+}
+
+// ReaderFor describes what profileID may read of other profiles' files when
+// listing or searching them: project files are readable in every project the
+// profile belongs to, or in projectID when given and the profile is a member.
+func ReaderFor(membershipClient project.IProjectMembershipClient, token string, profileID int64, projectID *string) (aggregate.Reader, error) {
+	reader := aggregate.Reader{ProfileID: strconv.FormatInt(profileID, 10)}
+	if projectID == nil {
+		projects, err := membershipClient.ListProjects(token, profileID)
+		if err != nil {
+			return aggregate.Reader{}, err
+		}
+		reader.MemberOf = projects
+		return reader, nil
+	}
+	member, err := membershipClient.IsMember(token, *projectID, profileID)
+	if err != nil {
+		return aggregate.Reader{}, err
+	}
+	if member {
+		reader.MemberOf = []string{*projectID}
+	}
+	return reader, nil
+}
@@ -0,0 +1,130 @@
+package policy
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type MembershipClientMock struct {
+	mock.Mock
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	args := m.Called(token, projectID, profileID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	args := m.Called(token, profileID)
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func TestCanReadFile(t *testing.T) {
+	projectID := "project-1"
+	fileWith := func(visibility domain.Visibility) domain.File {
+		file, _ := domain.RehydrateFile("1", "123", &projectID, "a.txt", "text/plain", 1, "key", visibility, domain.StatusAvailable, time.Now())
+		return file
+	}
+
+	t.Run("Owner Reads Private File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+
+		allowed, err := CanReadFile(membership, "token", 123, fileWith(domain.VisibilityPrivate))
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		membership.AssertNotCalled(t, "IsMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Anyone Reads Public File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+
+		allowed, err := CanReadFile(membership, "token", 456, fileWith(domain.VisibilityPublic))
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("Stranger Cannot Read Private File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+
+		allowed, err := CanReadFile(membership, "token", 456, fileWith(domain.VisibilityPrivate))
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("Share Grants Read On Private File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+		file := fileWith(domain.VisibilityPrivate)
+		_ = file.ShareWith("123", "456", domain.PermissionRead)
+
+		allowed, err := CanReadFile(membership, "token", 456, file)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("Project Member Reads Project File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+		membership.On("IsMember", "token", projectID, int64(456)).Return(true, nil)
+
+		allowed, err := CanReadFile(membership, "token", 456, fileWith(domain.VisibilityProject))
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+	})
+
+	t.Run("Non Member Cannot Read Project File", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+		membership.On("IsMember", "token", projectID, int64(456)).Return(false, nil)
+
+		allowed, err := CanReadFile(membership, "token", 456, fileWith(domain.VisibilityProject))
+
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+	})
+
+	t.Run("Membership Lookup Fails", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+		expectedErr := errors.New("project service down")
+		membership.On("IsMember", "token", projectID, int64(456)).Return(false, expectedErr)
+
+		allowed, err := CanReadFile(membership, "token", 456, fileWith(domain.VisibilityProject))
+
+		assert.Equal(t, expectedErr, err)
+		assert.False(t, allowed)
+	})
+}
+
+func TestReaderFor(t *testing.T) {
+	t.Run("Reads Every Project Of The Profile Without Filter", func(t *testing.T) {
+		membership := new(MembershipClientMock)
+		membership.On("ListProjects", "token", int64(456)).Return([]string{"project-1", "project-2"}, nil)
+
+		reader, err := ReaderFor(membership, "token", 456, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "456", reader.ProfileID)
+		assert.Equal(t, []string{"project-1", "project-2"}, reader.MemberOf)
+		membership.AssertNotCalled(t, "IsMember", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reads Filtered Project Only For Members", func(t *testing.T) {
+		projectID := "project-1"
+		membership := new(MembershipClientMock)
+		membership.On("IsMember", "token", projectID, int64(456)).Return(false, nil)
+
+		reader, err := ReaderFor(membership, "token", 456, &projectID)
+
+		assert.NoError(t, err)
+		assert.Empty(t, reader.MemberOf)
+		membership.AssertNotCalled(t, "ListProjects", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,15 @@
+package policy
+
+// StorageQuota holds the storage limit of each profile. Limits of zero or
+// less mean unlimited.
+type StorageQuota struct {
+	DefaultLimit  int64            `json:"default_limit"`
+	ProfileLimits map[string]int64 `json:"profile_limits"`
+}
+
+func (q StorageQuota) LimitFor(ownerID string) int64 {
+	if limit, found := q.ProfileLimits[ownerID]; found {
+		return limit
+	}
+	return q.DefaultLimit
+}
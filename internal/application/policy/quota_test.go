@@ -0,0 +1,19 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageQuota_LimitFor(t *testing.T) {
+	quota := StorageQuota{
+		DefaultLimit:  100,
+		ProfileLimits: map[string]int64{"123": 1000, "456": 0},
+	}
+
+	assert.Equal(t, int64(100), quota.LimitFor("789"))
+	assert.Equal(t, int64(1000), quota.LimitFor("123"))
+	assert.Equal(t, int64(0), quota.LimitFor("456"))
+	assert.Equal(t, int64(0), StorageQuota{}.LimitFor("123"))
+}
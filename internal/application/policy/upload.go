@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/mimesniff"
+	"devconnectstorage/internal/domain"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// MimeMismatch values decide what happens when sniffed content contradicts
+// the declared MIME type.
+const (
+	MimeMismatchReplace = "replace"
+	MimeMismatchReject  = "reject"
+)
+
+// UploadRules limits what may be uploaded. Zero values impose no limit; MIME
+// patterns may name a whole family such as "image/*". A mismatched declared
+// type is replaced by the detected one unless MimeMismatch is "reject".
+type UploadRules struct {
+	MaxSize             int64            `json:"max_size"`
+	MaxSizeByVisibility map[string]int64 `json:"max_size_by_visibility"`
+	MaxSizeByMimeFamily map[string]int64 `json:"max_size_by_mime_family"`
+	AllowedMimeTypes    []string         `json:"allowed_mime_types"`
+	BlockedMimeTypes    []string         `json:"blocked_mime_types"`
+	AllowedExtensions   []string         `json:"allowed_extensions"`
+	BlockedExtensions   []string         `json:"blocked_extensions"`
+	MimeMismatch        string           `json:"mime_mismatch"`
+}
+
+// UploadPolicy applies the default rules to every upload. Rules listed under
+// a project replace the matching defaults for uploads into that project.
+type UploadPolicy struct {
+	UploadRules
+	Projects map[string]UploadRules `json:"projects"`
+}
+
+type UploadCandidate struct {
+	ProjectID  *string
+	FileName   string
+	MimeType   string
+	Size       int64
+	Visibility domain.Visibility
+}
+
+func (p UploadPolicy) Check(candidate UploadCandidate) error {
+	rules := p.rulesFor(candidate.ProjectID)
+
+	mimeType := normalizeMimeType(candidate.MimeType)
+	if matchesMimeType(rules.BlockedMimeTypes, mimeType) ||
+		len(rules.AllowedMimeTypes) > 0 && !matchesMimeType(rules.AllowedMimeTypes, mimeType) {
+		return apperror.New(apperror.ErrUnsupportedMedia, "mime type %q is not allowed", mimeType)
+	}
+
+	extension := strings.ToLower(filepath.Ext(candidate.FileName))
+	if matchesExtension(rules.BlockedExtensions, extension) ||
+		len(rules.AllowedExtensions) > 0 && !matchesExtension(rules.AllowedExtensions, extension) {
+		if extension == "" {
+			return apperror.New(apperror.ErrUnsupportedMedia, "files without an extension are not allowed")
+		}
+		return apperror.New(apperror.ErrUnsupportedMedia, "extension %q is not allowed", extension)
+	}
+
+	if limit := rules.maxSize(candidate.Visibility, mimeType); limit > 0 && candidate.Size > limit {
+		return tooLarge(limit)
+	}
+	return nil
+}
+
+// ResolveMimeType returns the MIME type to store for content declared as
+// declared, falling back to the detected type when none was declared.
+func (p UploadPolicy) ResolveMimeType(projectID *string, declared string, detection mimesniff.Detection) (string, error) {
+	if isGeneric(declared) {
+		return detection.MimeType(), nil
+	}
+	if detection.Confirms(declared) {
+		return declared, nil
+	}
+	if p.rulesFor(projectID).MimeMismatch == MimeMismatchReject {
+		return "", apperror.New(apperror.ErrUnsupportedMedia, "declared mime type %q does not match detected type %q", declared, detection.MimeType())
+	}
+	return detection.MimeType(), nil
+}
+
+// MaxSize returns the size limit for candidate, or 0 when it has none.
+func (p UploadPolicy) MaxSize(candidate UploadCandidate) int64 {
+	return p.rulesFor(candidate.ProjectID).maxSize(candidate.Visibility, normalizeMimeType(candidate.MimeType))
+}
+
+// LimitReader fails reads once content grows past limit, for uploads whose
+// size is not known up front.
+func LimitReader(content io.Reader, limit int64) io.Reader {
+	if limit <= 0 {
+		return content
+	}
+	return &limitedReader{content: content, remaining: limit, limit: limit}
+}
+
+type limitedReader struct {
+	content   io.Reader
+	remaining int64
+	limit     int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, tooLarge(r.limit)
+	}
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.content.Read(p)
+	r.remaining -= int64(n)
+	if r.remaining < 0 {
+		return n + int(r.remaining), tooLarge(r.limit)
+	}
+	return n, err
+}
+
+func (p UploadPolicy) rulesFor(projectID *string) UploadRules {
+	if projectID == nil {
+		return p.UploadRules
+	}
+	override, found := p.Projects[*projectID]
+	if !found {
+		return p.UploadRules
+	}
+
+	rules := p.UploadRules
+	if override.MaxSize > 0 {
+		rules.MaxSize = override.MaxSize
+	}
+	rules.MaxSizeByVisibility = mergeLimits(rules.MaxSizeByVisibility, override.MaxSizeByVisibility)
+	rules.MaxSizeByMimeFamily = mergeLimits(rules.MaxSizeByMimeFamily, override.MaxSizeByMimeFamily)
+	if override.AllowedMimeTypes != nil {
+		rules.AllowedMimeTypes = override.AllowedMimeTypes
+	}
+	if override.BlockedMimeTypes != nil {
+		rules.BlockedMimeTypes = override.BlockedMimeTypes
+	}
+	if override.AllowedExtensions != nil {
+		rules.AllowedExtensions = override.AllowedExtensions
+	}
+	if override.BlockedExtensions != nil {
+		rules.BlockedExtensions = override.BlockedExtensions
+	}
+	if override.MimeMismatch != "" {
+		rules.MimeMismatch = override.MimeMismatch
+	}
+	return rules
+}
+
+// maxSize returns the tightest limit that applies, or 0 when none does.
+func (r UploadRules) maxSize(visibility domain.Visibility, mimeType string) int64 {
+	family, _, _ := strings.Cut(mimeType, "/")
+	limit := r.MaxSize
+	for _, candidate := range []int64{r.MaxSizeByVisibility[string(visibility)], r.MaxSizeByMimeFamily[family]} {
+		if candidate > 0 && (limit <= 0 || candidate < limit) {
+			limit = candidate
+		}
+	}
+	return limit
+}
+
+func mergeLimits(base map[string]int64, override map[string]int64) map[string]int64 {
+	if len(override) == 0 {
+		return base
+	}
+	merged := make(map[string]int64, len(base)+len(override))
+	for key, limit := range base {
+		merged[key] = limit
+	}
+	for key, limit := range override {
+		merged[key] = limit
+	}
+	return merged
+}
+
+func matchesMimeType(patterns []string, mimeType string) bool {
+	family, _, _ := strings.Cut(mimeType, "/")
+	for _, pattern := range patterns {
+		pattern = normalizeMimeType(pattern)
+		if pattern == mimeType || pattern == "*/*" || pattern == family+"/*" {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesExtension(extensions []string, extension string) bool {
+	for _, candidate := range extensions {
+		candidate = strings.ToLower(strings.TrimSpace(candidate))
+		if candidate != "" && !strings.HasPrefix(candidate, ".") {
+			candidate = "." + candidate
+		}
+		if candidate == extension {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeMimeType(mimeType string) string {
+	mediaType, _, _ := strings.Cut(mimeType, ";")
+	return strings.ToLower(strings.TrimSpace(mediaType))
+}
+
+// isGeneric reports whether declared says nothing about the content.
+func isGeneric(declared string) bool {
+	mimeType := normalizeMimeType(declared)
+	return mimeType == "" || mimeType == "application/octet-stream"
+}
+
+func tooLarge(limit int64) error {
+	return apperror.New(apperror.ErrPayloadTooLarge, "file exceeds the maximum upload size of %d bytes", limit)
+}
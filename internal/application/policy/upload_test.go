@@ -0,0 +1,139 @@
+package policy
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/mimesniff"
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadPolicy_Check(t *testing.T) {
+	projectID := "project-1"
+	uploadPolicy := UploadPolicy{
+		UploadRules: UploadRules{
+			MaxSize:             100,
+			MaxSizeByVisibility: map[string]int64{"PUBLIC": 50},
+			MaxSizeByMimeFamily: map[string]int64{"video": 80},
+			AllowedMimeTypes:    []string{"image/*", "video/*", "application/pdf"},
+			BlockedMimeTypes:    []string{"image/svg+xml"},
+			BlockedExtensions:   []string{"exe", ".sh"},
+		},
+		Projects: map[string]UploadRules{
+			projectID: {
+				MaxSize:          1000,
+				AllowedMimeTypes: []string{"*/*"},
+			},
+		},
+	}
+	candidate := func(name string, mimeType string, size int64) UploadCandidate {
+		return UploadCandidate{FileName: name, MimeType: mimeType, Size: size, Visibility: domain.VisibilityPrivate}
+	}
+
+	t.Run("Allows Matching Upload", func(t *testing.T) {
+		assert.NoError(t, uploadPolicy.Check(candidate("photo.PNG", "Image/PNG; charset=binary", 100)))
+	})
+
+	t.Run("Rejects Mime Type Outside Allowlist", func(t *testing.T) {
+		err := uploadPolicy.Check(candidate("notes.txt", "text/plain", 10))
+
+		assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+		assert.Equal(t, `mime type "text/plain" is not allowed`, apperror.MessageOf(err))
+	})
+
+	t.Run("Blocklist Wins Over Allowlist", func(t *testing.T) {
+		err := uploadPolicy.Check(candidate("logo.svg", "image/svg+xml", 10))
+
+		assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+	})
+
+	t.Run("Rejects Blocked Extension", func(t *testing.T) {
+		err := uploadPolicy.Check(candidate("install.SH", "application/pdf", 10))
+
+		assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+		assert.Equal(t, `extension ".sh" is not allowed`, apperror.MessageOf(err))
+	})
+
+	t.Run("Applies Tightest Size Limit", func(t *testing.T) {
+		assert.ErrorIs(t, uploadPolicy.Check(candidate("clip.mp4", "video/mp4", 90)), apperror.ErrPayloadTooLarge)
+
+		public := candidate("photo.png", "image/png", 60)
+		public.Visibility = domain.VisibilityPublic
+		err := uploadPolicy.Check(public)
+
+		assert.ErrorIs(t, err, apperror.ErrPayloadTooLarge)
+		assert.Equal(t, "file exceeds the maximum upload size of 50 bytes", apperror.MessageOf(err))
+	})
+
+	t.Run("Project Override Replaces Defaults", func(t *testing.T) {
+		upload := candidate("notes.txt", "text/plain", 500)
+		upload.ProjectID = &projectID
+
+		assert.NoError(t, uploadPolicy.Check(upload))
+
+		upload.FileName = "run.exe"
+		assert.ErrorIs(t, uploadPolicy.Check(upload), apperror.ErrUnsupportedMedia)
+	})
+
+	t.Run("Empty Policy Allows Everything", func(t *testing.T) {
+		assert.NoError(t, UploadPolicy{}.Check(candidate("anything", "", 1<<40)))
+	})
+}
+
+func TestUploadPolicy_ResolveMimeType(t *testing.T) {
+	projectID := "project-1"
+	detection, _, _ := mimesniff.Detect(strings.NewReader("<html><body>hi</body></html>"))
+	uploadPolicy := UploadPolicy{
+		Projects: map[string]UploadRules{projectID: {MimeMismatch: MimeMismatchReject}},
+	}
+
+	t.Run("Keeps Confirmed Declared Type", func(t *testing.T) {
+		mimeType, err := uploadPolicy.ResolveMimeType(nil, "text/html; charset=utf-8", detection)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text/html; charset=utf-8", mimeType)
+	})
+
+	t.Run("Uses Detected Type When Declared Type Is Generic", func(t *testing.T) {
+		for _, declared := range []string{"", "application/octet-stream"} {
+			mimeType, err := uploadPolicy.ResolveMimeType(&projectID, declared, detection)
+
+			assert.NoError(t, err)
+			assert.Equal(t, "text/html", mimeType)
+		}
+	})
+
+	t.Run("Replaces Mismatched Type By Default", func(t *testing.T) {
+		mimeType, err := uploadPolicy.ResolveMimeType(nil, "image/png", detection)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "text/html", mimeType)
+	})
+
+	t.Run("Rejects Mismatched Type When Configured", func(t *testing.T) {
+		_, err := uploadPolicy.ResolveMimeType(&projectID, "image/png", detection)
+
+		assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+		assert.Equal(t, `declared mime type "image/png" does not match detected type "text/html"`, apperror.MessageOf(err))
+	})
+}
+
+func TestLimitReader(t *testing.T) {
+	t.Run("Reads Content Within Limit", func(t *testing.T) {
+		content, err := io.ReadAll(LimitReader(strings.NewReader("hello"), 5))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	})
+
+	t.Run("Fails Past Limit", func(t *testing.T) {
+		content, err := io.ReadAll(LimitReader(strings.NewReader("hello"), 4))
+
+		assert.ErrorIs(t, err, apperror.ErrPayloadTooLarge)
+		assert.Equal(t, "hell", string(content))
+	})
+}
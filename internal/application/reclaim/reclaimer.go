@@ -0,0 +1,69 @@
+package reclaim
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"errors"
+)
+
+type Storage interface {
+	DeleteObject(ctx context.Context, storageKey string) error
+}
+
+type BlobRepository interface {
+	Release(ctx context.Context, checksum string) (bool, error)
+}
+
+type StorageUsageRepository interface {
+	Release(ctx context.Context, ownerID string, bytes int64, files int64) error
+}
+
+// Reclaimer gives back what a file held once it has been deleted for good:
+// its share of content-addressed blobs and the quota charged for its content.
+type Reclaimer struct {
+	storage Storage
+	blobs   BlobRepository
+	usage   StorageUsageRepository
+}
+
+func NewReclaimer(storage Storage, blobs BlobRepository, usage StorageUsageRepository) Reclaimer {
+	return Reclaimer{
+		storage: storage,
+		blobs:   blobs,
+		usage:   usage,
+	}
+}
+
+// Reclaim releases every blob file's versions point at, deleting those no
+// other file uses anymore, and the quota file was charged. Files that never
+// stored content were never charged.
+func (r Reclaimer) Reclaim(ctx context.Context, file domain.File) error {
+	return errors.Join(r.releaseBlobs(ctx, file), r.releaseUsage(ctx, file))
+}
+
+func (r Reclaimer) releaseUsage(ctx context.Context, file domain.File) error {
+	if len(file.Versions()) == 0 {
+		return nil
+	}
+	return r.usage.Release(ctx, file.OwnerID(), file.StoredBytes(), 1)
+}
+
+func (r Reclaimer) releaseBlobs(ctx context.Context, file domain.File) error {
+	var releaseErrors []error
+	for _, version := range file.Versions() {
+		if !version.ContentAddressed() {
+			continue
+		}
+		last, err := r.blobs.Release(ctx, version.Checksum())
+		if err != nil {
+			releaseErrors = append(releaseErrors, err)
+			continue
+		}
+		if last {
+			if err := r.storage.DeleteObject(ctx, version.StorageKey()); err != nil {
+				releaseErrors = append(releaseErrors, err)
+			}
+		}
+	}
+	return errors.Join(releaseErrors...)
+}
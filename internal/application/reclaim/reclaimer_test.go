@@ -0,0 +1,82 @@
+package reclaim
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+type storageMock struct {
+	deleted []string
+}
+
+func (m *storageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	m.deleted = append(m.deleted, storageKey)
+	return nil
+}
+
+type blobRepositoryMock struct {
+	last bool
+}
+
+func (m *blobRepositoryMock) Release(ctx context.Context, checksum string) (bool, error) {
+	return m.last, nil
+}
+
+type usageRepositoryMock struct {
+	bytes, files int64
+}
+
+func (m *usageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	m.bytes += bytes
+	m.files += files
+	return nil
+}
+
+func blobFile(t *testing.T) domain.File {
+	blob, err := domain.NewBlob(helloChecksum, "blobs/"+helloChecksum, 5)
+	require.NoError(t, err)
+	file, err := domain.NewFile("1", "12", nil, "hello.txt", "text/plain", 5, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	require.NoError(t, file.MarkAsAvailableFromBlob(blob))
+	return file
+}
+
+func TestReclaimer_DeletesBlobsNoOtherFileUses(t *testing.T) {
+	storage, usage := &storageMock{}, &usageRepositoryMock{}
+	reclaimer := NewReclaimer(storage, &blobRepositoryMock{last: true}, usage)
+
+	err := reclaimer.Reclaim(context.Background(), blobFile(t))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"blobs/" + helloChecksum}, storage.deleted)
+	assert.Equal(t, int64(5), usage.bytes)
+	assert.Equal(t, int64(1), usage.files)
+}
+
+func TestReclaimer_KeepsSharedBlobs(t *testing.T) {
+	storage := &storageMock{}
+	reclaimer := NewReclaimer(storage, &blobRepositoryMock{last: false}, &usageRepositoryMock{})
+
+	err := reclaimer.Reclaim(context.Background(), blobFile(t))
+
+	require.NoError(t, err)
+	assert.Empty(t, storage.deleted)
+}
+
+func TestReclaimer_DoesNotReleaseQuotaOfFilesWithoutContent(t *testing.T) {
+	file, err := domain.NewFile("1", "12", nil, "hello.txt", "text/plain", 5, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	usage := &usageRepositoryMock{}
+	reclaimer := NewReclaimer(&storageMock{}, &blobRepositoryMock{}, usage)
+
+	err = reclaimer.Reclaim(context.Background(), file)
+
+	require.NoError(t, err)
+	assert.Zero(t, usage.files)
+}
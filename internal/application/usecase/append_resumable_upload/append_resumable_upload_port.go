@@ -0,0 +1,10 @@
+package appendresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IAppendResumableUploadUseCase interface {
+	Execute(ctx context.Context, command AppendResumableUploadCommand) (domain.UploadSession, error)
+}
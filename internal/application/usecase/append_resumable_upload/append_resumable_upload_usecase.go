@@ -0,0 +1,206 @@
+package appendresumableupload
+
+import (
+	"bytes"
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/ingest"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/append_resumable_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"io"
+	"strconv"
+	"time"
+)
+
+type AppendResumableUploadUseCase struct {
+	fileRepository    port.FileRepository
+	sessionRepository port.UploadSessionRepository
+	storage           port.Storage
+	authClient        auth.IAuthClient
+	uploadPolicy      policy.UploadPolicy
+	usage             port.StorageUsageRepository
+	blobs             port.BlobRepository
+	partSize          int64
+}
+
+func NewAppendResumableUploadUseCase(fileRepository port.FileRepository, sessionRepository port.UploadSessionRepository, storage port.Storage, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, usage port.StorageUsageRepository, blobs port.BlobRepository, partSize int64) *AppendResumableUploadUseCase {
+	return &AppendResumableUploadUseCase{
+		fileRepository:    fileRepository,
+		sessionRepository: sessionRepository,
+		storage:           storage,
+		authClient:        authClient,
+		uploadPolicy:      uploadPolicy,
+		usage:             usage,
+		blobs:             blobs,
+		partSize:          partSize,
+	}
+}
+
+func (uc *AppendResumableUploadUseCase) Execute(ctx context.Context, command AppendResumableUploadCommand) (domain.UploadSession, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.UploadSession{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.UploadSession{}, authError
+	}
+
+	session, err := uc.sessionRepository.GetUploadSession(ctx, command.Id)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+	if session.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.UploadSession{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if err := session.CheckAppendAt(command.Offset, time.Now()); err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, session.ID())
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+	if file.Status() != domain.StatusPending {
+		return domain.UploadSession{}, apperror.New(apperror.ErrConflict, "upload cannot be resumed from %s", file.Status())
+	}
+
+	session, err = uc.store(ctx, file, session, command.Content)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	if session.IsComplete() {
+		if err := uc.finish(ctx, file, session); err != nil {
+			return domain.UploadSession{}, err
+		}
+	}
+	return session, nil
+}
+
+func (uc *AppendResumableUploadUseCase) store(ctx context.Context, file domain.File, session domain.UploadSession, content io.Reader) (domain.UploadSession, error) {
+	reader := io.LimitReader(content, session.Length()-session.Offset())
+	if session.TailSize() > 0 {
+		tail, err := uc.storage.GetUploadTail(ctx, file, session.TailNumber())
+		if err != nil {
+			return session, err
+		}
+		defer func() { _ = tail.Close() }()
+		reader = io.MultiReader(io.LimitReader(tail, session.TailSize()), reader)
+	}
+
+	buffer := make([]byte, uc.partSize)
+	for {
+		n, readErr := io.ReadFull(reader, buffer)
+		if n > 0 {
+			if err := uc.persist(ctx, file, &session, buffer[:n]); err != nil {
+				return session, err
+			}
+		}
+		if readErr == io.EOF || readErr == io.ErrUnexpectedEOF {
+			return session, nil
+		}
+		if readErr != nil {
+			return session, readErr
+		}
+	}
+}
+
+// persist stores chunk under a freshly reserved number, so a concurrent
+// request for the same offset can only fail to record its progress and never
+// overwrites the part or tail the other one recorded.
+func (uc *AppendResumableUploadUseCase) persist(ctx context.Context, file domain.File, session *domain.UploadSession, chunk []byte) error {
+	previousOffset := session.Offset()
+	size := int64(len(chunk))
+
+	number, err := uc.sessionRepository.ReservePartNumber(ctx, session.ID())
+	if err != nil {
+		return err
+	}
+	if size == uc.partSize || session.PartsSize()+size == session.Length() {
+		etag, err := uc.storage.UploadPart(ctx, file, session.MultipartID(), number, bytes.NewReader(chunk), size)
+		if err != nil {
+			return err
+		}
+		if err := session.AddPart(number, etag, size); err != nil {
+			return err
+		}
+	} else {
+		if err := uc.storage.SaveUploadTail(ctx, file, number, bytes.NewReader(chunk), size); err != nil {
+			return err
+		}
+		if err := session.SetTail(number, size); err != nil {
+			return err
+		}
+	}
+	return uc.sessionRepository.Update(ctx, *session, previousOffset)
+}
+
+// finish assembles the parts into the staging object and ingests it like any
+// other upload. The quota was reserved when the upload was created; content
+// the upload policy rejects gives it back and ends the upload.
+func (uc *AppendResumableUploadUseCase) finish(ctx context.Context, file domain.File, session domain.UploadSession) error {
+	if err := uc.assemble(ctx, file, session); err != nil {
+		return err
+	}
+	storageKey, err := uc.storeAssembled(ctx, &file)
+	if err != nil {
+		if file.Status() == domain.StatusFailed {
+			return uc.abandon(ctx, file, session, err)
+		}
+		return err
+	}
+	if err := uc.ingester().Publish(ctx, &file, storageKey); err != nil {
+		if file.Status() == domain.StatusFailed {
+			return uc.abandon(ctx, file, session, err)
+		}
+		return err
+	}
+	uc.cleanUp(ctx, file, session)
+	return nil
+}
+
+// assemble completes the multipart upload unless an earlier attempt already
+// did and then failed to ingest it, in which case the client retries at the
+// final offset and the staging object is ingested again.
+func (uc *AppendResumableUploadUseCase) assemble(ctx context.Context, file domain.File, session domain.UploadSession) error {
+	_, err := uc.storage.StatUpload(ctx, file)
+	if err == nil || !errors.Is(err, apperror.ErrNotFound) {
+		return err
+	}
+	_, err = uc.storage.CompleteMultipartUpload(ctx, file, session.MultipartID(), session.Parts())
+	return err
+}
+
+func (uc *AppendResumableUploadUseCase) storeAssembled(ctx context.Context, file *domain.File) (string, error) {
+	staged, err := uc.storage.OpenUpload(ctx, *file)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = staged.Close() }()
+	return uc.ingester().Store(ctx, file, staged)
+}
+
+func (uc *AppendResumableUploadUseCase) abandon(ctx context.Context, file domain.File, session domain.UploadSession, cause error) error {
+	uc.cleanUp(ctx, file, session)
+	if err := uc.usage.Release(ctx, file.OwnerID(), session.Length(), 1); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (uc *AppendResumableUploadUseCase) cleanUp(ctx context.Context, file domain.File, session domain.UploadSession) {
+	_ = uc.storage.DeleteUpload(ctx, file)
+	_ = uc.storage.DeleteUploadTail(ctx, file)
+	_ = uc.sessionRepository.Delete(ctx, session.ID())
+}
+
+func (uc *AppendResumableUploadUseCase) ingester() ingest.Ingester {
+	return ingest.NewIngester(uc.fileRepository, uc.storage, uc.uploadPolicy, uc.blobs)
+}
@@ -0,0 +1,367 @@
+package appendresumableupload
+
+import (
+	"bytes"
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StorageUsageRepositoryMock struct {
+	released int64
+}
+
+func (m *StorageUsageRepositoryMock) Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+	return domain.RehydrateStorageUsage(ownerID, bytes, files)
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	m.released += bytes
+	return nil
+}
+
+type FileRepositoryMock struct {
+	file    domain.File
+	updates []domain.Status
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.file, nil
+}
+
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	m.file = file
+	m.updates = append(m.updates, file.Status())
+	return nil
+}
+
+type UploadSessionRepositoryMock struct {
+	session  domain.UploadSession
+	nextPart int
+	deleted  bool
+}
+
+func (m *UploadSessionRepositoryMock) GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error) {
+	if m.deleted {
+		return domain.UploadSession{}, domain.ErrUploadSessionNotFound
+	}
+	return m.session, nil
+}
+
+func (m *UploadSessionRepositoryMock) ReservePartNumber(ctx context.Context, id string) (int, error) {
+	m.nextPart++
+	return m.nextPart, nil
+}
+
+func (m *UploadSessionRepositoryMock) Update(ctx context.Context, session domain.UploadSession, expectedOffset int64) error {
+	if m.session.Offset() != expectedOffset {
+		return domain.ErrUploadOffsetMismatch
+	}
+	m.session = session
+	return nil
+}
+
+func (m *UploadSessionRepositoryMock) Delete(ctx context.Context, id string) error {
+	m.deleted = true
+	return nil
+}
+
+type StorageMock struct {
+	parts        map[int][]byte
+	tails        map[int][]byte
+	completed    []byte
+	assembled    bool
+	saved        []byte
+	saveFileErr  error
+	uploadPartFn func(number int) error
+}
+
+func (m *StorageMock) UploadPart(ctx context.Context, file domain.File, uploadID string, number int, content io.Reader, size int64) (string, error) {
+	if m.uploadPartFn != nil {
+		if err := m.uploadPartFn(number); err != nil {
+			return "", err
+		}
+	}
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return "", err
+	}
+	if int64(len(data)) != size {
+		return "", fmt.Errorf("size mismatch")
+	}
+	m.parts[number] = data
+	return fmt.Sprintf("etag-%d", number), nil
+}
+
+func (m *StorageMock) CompleteMultipartUpload(ctx context.Context, file domain.File, uploadID string, parts []domain.UploadPart) (string, error) {
+	if m.assembled {
+		return "", apperror.New(apperror.ErrNotFound, "upload not found")
+	}
+	m.assembled = true
+	var content []byte
+	for _, part := range parts {
+		content = append(content, m.parts[part.Number()]...)
+	}
+	m.completed = content
+	return "uploads/12/1/demo.txt", nil
+}
+
+func (m *StorageMock) SaveUploadTail(ctx context.Context, file domain.File, number int, content io.Reader, size int64) error {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+	m.tails[number] = data
+	return nil
+}
+
+func (m *StorageMock) GetUploadTail(ctx context.Context, file domain.File, number int) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.tails[number])), nil
+}
+
+func (m *StorageMock) DeleteUploadTail(ctx context.Context, file domain.File) error {
+	m.tails = map[int][]byte{}
+	return nil
+}
+
+func (m *StorageMock) StatUpload(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+	if m.completed == nil {
+		return aggregate.StoredObject{}, apperror.New(apperror.ErrNotFound, "uploaded content not found")
+	}
+	return aggregate.StoredObject{Size: int64(len(m.completed))}, nil
+}
+
+func (m *StorageMock) OpenUpload(ctx context.Context, file domain.File) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.completed)), nil
+}
+
+func (m *StorageMock) DeleteUpload(ctx context.Context, file domain.File) error {
+	m.completed = nil
+	return nil
+}
+
+func (m *StorageMock) SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error) {
+	if m.saveFileErr != nil {
+		return "", m.saveFileErr
+	}
+	saved, err := io.ReadAll(fileBytes)
+	m.saved = saved
+	return "12/1/demo.txt", err
+}
+
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	return nil
+}
+
+func (m *StorageMock) PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error) {
+	return "blobs/" + checksum, nil
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func authClientFor(profileID int64) *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return &profileID, nil
+		},
+	}
+}
+
+func newFixtures(t *testing.T, expiresAt time.Time) (*FileRepositoryMock, *UploadSessionRepositoryMock, *StorageMock) {
+	file, err := domain.RehydrateFile("1", "12", nil, "demo.txt", "text/plain", 10, "", domain.VisibilityPrivate, domain.StatusPending, time.Now())
+	require.NoError(t, err)
+	session, err := domain.RehydrateUploadSession("1", "12", "multipart-1", 10, nil, 0, 0, time.Now(), expiresAt)
+	require.NoError(t, err)
+	return &FileRepositoryMock{file: file}, &UploadSessionRepositoryMock{session: session}, &StorageMock{parts: map[int][]byte{}, tails: map[int][]byte{}}
+}
+
+func TestAppendResumableUploadUseCase_ShouldAssembleChunksIntoParts(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	session, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("ab")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), session.Offset())
+	assert.Empty(t, session.Parts())
+	assert.Equal(t, "ab", string(storage.tails[session.TailNumber()]))
+
+	session, err = uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 2, Content: strings.NewReader("cdefgh")})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), session.Offset())
+	assert.Len(t, session.Parts(), 2)
+	assert.Equal(t, "abcd", string(storage.parts[2]))
+	assert.Equal(t, "efgh", string(storage.parts[3]))
+
+	session, err = uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 8, Content: strings.NewReader("ij")})
+	require.NoError(t, err)
+	assert.True(t, session.IsComplete())
+	assert.Equal(t, "abcdefghij", string(storage.saved))
+	assert.Equal(t, domain.StatusAvailable, files.file.Status())
+	assert.Equal(t, "12/1/demo.txt", files.file.StorageKey())
+	assert.Len(t, files.file.Checksum(), 64)
+	assert.Nil(t, storage.completed)
+	assert.Empty(t, storage.tails)
+	assert.True(t, sessions.deleted)
+}
+
+func TestAppendResumableUploadUseCase_ShouldIgnoreBytesBeyondLength(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	session, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("abcdefghijEXTRA")})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), session.Offset())
+	assert.Equal(t, "abcdefghij", string(storage.saved))
+}
+
+func TestAppendResumableUploadUseCase_ShouldKeepReceivedBytesWhenBodyIsInterrupted(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+	body := io.MultiReader(strings.NewReader("abcdef"), iotest.ErrReader(errors.New("connection reset")))
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: body})
+
+	assert.EqualError(t, err, "connection reset")
+	assert.Equal(t, int64(6), sessions.session.Offset())
+	assert.Equal(t, "abcd", string(storage.parts[1]))
+	assert.Equal(t, "ef", string(storage.tails[sessions.session.TailNumber()]))
+	assert.Equal(t, domain.StatusPending, files.file.Status())
+}
+
+func TestAppendResumableUploadUseCase_ShouldRejectOffsetMismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 3, Content: strings.NewReader("ab")})
+
+	assert.ErrorIs(t, err, domain.ErrUploadOffsetMismatch)
+	assert.Empty(t, storage.parts)
+}
+
+func TestAppendResumableUploadUseCase_ShouldRejectExpiredSession(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Millisecond))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+	time.Sleep(5 * time.Millisecond)
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("ab")})
+
+	assert.ErrorIs(t, err, domain.ErrUploadSessionExpired)
+}
+
+func TestAppendResumableUploadUseCase_ShouldRejectOtherProfiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(99), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("ab")})
+
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestAppendResumableUploadUseCase_ShouldNotAdvanceOffsetWhenPartUploadFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	storage.uploadPartFn = func(number int) error {
+		return errors.New("storage error")
+	}
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("abcd")})
+
+	assert.EqualError(t, err, "storage error")
+	assert.Equal(t, int64(0), sessions.session.Offset())
+}
+
+func TestAppendResumableUploadUseCase_ShouldFailWithoutToken(t *testing.T) {
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	_, err := uc.Execute(context.Background(), AppendResumableUploadCommand{Id: "1", Content: strings.NewReader("ab")})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestAppendResumableUploadUseCase_ShouldStoreRetriedChunksUnderNewNumbers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+	recorded := sessions.session
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("abcd")})
+	require.NoError(t, err)
+	winner := sessions.session
+	sessions.session = recorded
+
+	_, err = uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("wxyz")})
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, winner.Parts()[0].Number())
+	assert.Equal(t, "abcd", string(storage.parts[1]))
+	assert.Equal(t, "wxyz", string(storage.parts[2]))
+}
+
+func TestAppendResumableUploadUseCase_ShouldReleaseQuotaWhenContentIsRejected(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	file, err := domain.RehydrateFile("1", "12", nil, "demo.mp4", "video/mp4", 10, "", domain.VisibilityPrivate, domain.StatusPending, time.Now())
+	require.NoError(t, err)
+	files.file = file
+	usage := &StorageUsageRepositoryMock{}
+	uploadPolicy := policy.UploadPolicy{UploadRules: policy.UploadRules{MimeMismatch: policy.MimeMismatchReject}}
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), uploadPolicy, usage, nil, 4)
+
+	_, err = uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("abcdefghij")})
+
+	assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+	assert.Nil(t, storage.saved)
+	assert.Nil(t, storage.completed)
+	assert.Equal(t, domain.StatusFailed, files.file.Status())
+	assert.Equal(t, int64(10), usage.released)
+	assert.True(t, sessions.deleted)
+}
+
+func TestAppendResumableUploadUseCase_ShouldPublishAssembledUploadOnRetry(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	files, sessions, storage := newFixtures(t, time.Now().Add(time.Hour))
+	storage.saveFileErr = apperror.New(apperror.ErrUpstreamUnavailable, "object storage unavailable")
+	uc := NewAppendResumableUploadUseCase(files, sessions, storage, authClientFor(12), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, nil, 4)
+
+	_, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 0, Content: strings.NewReader("abcdefghij")})
+	require.ErrorIs(t, err, apperror.ErrUpstreamUnavailable)
+	assert.True(t, storage.assembled)
+	assert.Equal(t, domain.StatusPending, files.file.Status())
+
+	storage.saveFileErr = nil
+	session, err := uc.Execute(ctx, AppendResumableUploadCommand{Id: "1", Offset: 10, Content: strings.NewReader("")})
+
+	require.NoError(t, err)
+	assert.True(t, session.IsComplete())
+	assert.Equal(t, "abcdefghij", string(storage.saved))
+	assert.Equal(t, domain.StatusAvailable, files.file.Status())
+	assert.True(t, sessions.deleted)
+}
@@ -0,0 +1,9 @@
+package appendresumableupload
+
+import "io"
+
+type AppendResumableUploadCommand struct {
+	Id      string
+	Offset  int64
+	Content io.Reader
+}
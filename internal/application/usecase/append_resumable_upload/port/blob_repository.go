@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type BlobRepository interface {
+	GetBlob(ctx context.Context, checksum string) (domain.Blob, error)
+	Acquire(ctx context.Context, blob domain.Blob) (domain.Blob, error)
+	Release(ctx context.Context, checksum string) (bool, error)
+}
@@ -0,0 +1,22 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"io"
+)
+
+type Storage interface {
+	UploadPart(ctx context.Context, file domain.File, uploadID string, number int, content io.Reader, size int64) (string, error)
+	CompleteMultipartUpload(ctx context.Context, file domain.File, uploadID string, parts []domain.UploadPart) (string, error)
+	SaveUploadTail(ctx context.Context, file domain.File, number int, content io.Reader, size int64) error
+	GetUploadTail(ctx context.Context, file domain.File, number int) (io.ReadCloser, error)
+	DeleteUploadTail(ctx context.Context, file domain.File) error
+	StatUpload(ctx context.Context, file domain.File) (aggregate.StoredObject, error)
+	OpenUpload(ctx context.Context, file domain.File) (io.ReadCloser, error)
+	DeleteUpload(ctx context.Context, file domain.File) error
+	SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error)
+	DeleteObject(ctx context.Context, storageKey string) error
+	PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error)
+}
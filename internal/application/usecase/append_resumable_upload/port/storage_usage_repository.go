@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type StorageUsageRepository interface {
+	Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error)
+	Release(ctx context.Context, ownerID string, bytes int64, files int64) error
+}
@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type UploadSessionRepository interface {
+	GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error)
+	ReservePartNumber(ctx context.Context, id string) (int, error)
+	Update(ctx context.Context, session domain.UploadSession, expectedOffset int64) error
+	Delete(ctx context.Context, id string) error
+}
@@ -0,0 +1,7 @@
+package cleanupstaleuploads
+
+import "context"
+
+type ICleanupStaleUploadsUseCase interface {
+	Execute(ctx context.Context, command CleanupStaleUploadsCommand) (int, error)
+}
@@ -0,0 +1,85 @@
+package cleanupstaleuploads
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/cleanup_stale_uploads/port"
+	"devconnectstorage/internal/domain"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type CleanupStaleUploadsUseCase struct {
+	repository port.FileRepository
+	sessions   port.UploadSessionRepository
+	storage    port.Storage
+	usage      port.StorageUsageRepository
+}
+
+func NewCleanupStaleUploadsUseCase(repository port.FileRepository, sessions port.UploadSessionRepository, storage port.Storage, usage port.StorageUsageRepository) *CleanupStaleUploadsUseCase {
+	return &CleanupStaleUploadsUseCase{
+		repository: repository,
+		sessions:   sessions,
+		storage:    storage,
+		usage:      usage,
+	}
+}
+
+func (uc *CleanupStaleUploadsUseCase) Execute(ctx context.Context, command CleanupStaleUploadsCommand) (int, error) {
+	if command.Limit <= 0 {
+		return 0, apperror.New(apperror.ErrValidation, "limit must be positive")
+	}
+
+	files, err := uc.repository.ListStaleUploads(ctx, command.StartedBefore, command.Limit)
+	if err != nil {
+		return 0, err
+	}
+
+	cleaned := 0
+	var cleanupErrors []error
+	for _, file := range files {
+		done, err := uc.cleanUp(ctx, file)
+		if err != nil {
+			cleanupErrors = append(cleanupErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+			continue
+		}
+		if done {
+			cleaned++
+		}
+	}
+
+	return cleaned, errors.Join(cleanupErrors...)
+}
+
+// cleanUp removes a stale upload. Resumable uploads keep going until their
+// session expires and hold a quota reservation for their whole length, which
+// is given back once everything else is gone.
+func (uc *CleanupStaleUploadsUseCase) cleanUp(ctx context.Context, file domain.File) (bool, error) {
+	session, err := uc.sessions.GetUploadSession(ctx, file.ID())
+	resumable := err == nil
+	if err != nil && !errors.Is(err, domain.ErrUploadSessionNotFound) {
+		return false, err
+	}
+	if resumable && file.Status() == domain.StatusPending && time.Now().Before(session.ExpiresAt()) {
+		return false, nil
+	}
+
+	if err := uc.storage.DiscardUpload(ctx, file); err != nil {
+		return false, err
+	}
+	if resumable {
+		if err := uc.sessions.Delete(ctx, session.ID()); err != nil {
+			return false, err
+		}
+	}
+	if err := uc.repository.DeleteFile(ctx, file.ID()); err != nil {
+		return false, err
+	}
+	if resumable {
+		if err := uc.usage.Release(ctx, file.OwnerID(), session.Length(), 1); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,172 @@
+package cleanupstaleuploads
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) ListStaleUploads(ctx context.Context, startedBefore time.Time, limit int64) ([]domain.File, error) {
+	args := m.Called(ctx, startedBefore, limit)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) DeleteFile(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type StorageMock struct {
+	mock.Mock
+}
+
+func (m *StorageMock) DiscardUpload(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type SessionRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *SessionRepositoryMock) GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.UploadSession), args.Error(1)
+}
+
+func (m *SessionRepositoryMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type UsageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	args := m.Called(ctx, ownerID, bytes, files)
+	return args.Error(0)
+}
+
+func withoutSessions() *SessionRepositoryMock {
+	sessions := new(SessionRepositoryMock)
+	sessions.On("GetUploadSession", mock.Anything, mock.Anything).Return(domain.UploadSession{}, domain.ErrUploadSessionNotFound)
+	return sessions
+}
+
+func uploadSession(id string, expiresAt time.Time) domain.UploadSession {
+	session, _ := domain.RehydrateUploadSession(id, "123", "multipart", 32, nil, 0, 0, expiresAt.Add(-time.Hour), expiresAt)
+	return session
+}
+
+func pendingFile(id string) domain.File {
+	file, _ := domain.NewFile(id, "123", nil, "path", "text/plain", 32, domain.VisibilityPrivate)
+	return file
+}
+
+func TestCleanupStaleUploadsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	cutoff := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		files := []domain.File{pendingFile("1"), pendingFile("2")}
+
+		repo.On("ListStaleUploads", ctx, cutoff, int64(10)).Return(files, nil)
+		storage.On("DiscardUpload", ctx, mock.Anything).Return(nil)
+		repo.On("DeleteFile", ctx, "1").Return(nil)
+		repo.On("DeleteFile", ctx, "2").Return(nil)
+
+		cleaned, err := NewCleanupStaleUploadsUseCase(repo, withoutSessions(), storage, new(UsageRepositoryMock)).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, cleaned)
+		storage.AssertNumberOfCalls(t, "DiscardUpload", 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Keeps Record When Partial Object Cannot Be Removed", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		first, second := pendingFile("1"), pendingFile("2")
+
+		repo.On("ListStaleUploads", ctx, cutoff, int64(10)).Return([]domain.File{first, second}, nil)
+		storage.On("DiscardUpload", ctx, first).Return(errors.New("storage error"))
+		storage.On("DiscardUpload", ctx, second).Return(nil)
+		repo.On("DeleteFile", ctx, "2").Return(nil)
+
+		cleaned, err := NewCleanupStaleUploadsUseCase(repo, withoutSessions(), storage, new(UsageRepositoryMock)).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff, Limit: 10})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, cleaned)
+		repo.AssertNotCalled(t, "DeleteFile", ctx, "1")
+	})
+
+	t.Run("Skips Resumable Upload Before Its Session Expires", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		sessions := new(SessionRepositoryMock)
+		storage := new(StorageMock)
+		usage := new(UsageRepositoryMock)
+
+		repo.On("ListStaleUploads", ctx, cutoff, int64(10)).Return([]domain.File{pendingFile("1")}, nil)
+		sessions.On("GetUploadSession", ctx, "1").Return(uploadSession("1", time.Now().Add(time.Hour)), nil)
+
+		cleaned, err := NewCleanupStaleUploadsUseCase(repo, sessions, storage, usage).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, cleaned)
+		storage.AssertNotCalled(t, "DiscardUpload", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		usage.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Removes Expired Session And Releases Its Reservation", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		sessions := new(SessionRepositoryMock)
+		storage := new(StorageMock)
+		usage := new(UsageRepositoryMock)
+
+		repo.On("ListStaleUploads", ctx, cutoff, int64(10)).Return([]domain.File{pendingFile("1")}, nil)
+		sessions.On("GetUploadSession", ctx, "1").Return(uploadSession("1", time.Now().Add(-time.Minute)), nil)
+		storage.On("DiscardUpload", ctx, mock.Anything).Return(nil)
+		sessions.On("Delete", ctx, "1").Return(nil)
+		repo.On("DeleteFile", ctx, "1").Return(nil)
+		usage.On("Release", ctx, "123", int64(32), int64(1)).Return(nil)
+
+		cleaned, err := NewCleanupStaleUploadsUseCase(repo, sessions, storage, usage).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, cleaned)
+		sessions.AssertExpectations(t)
+		repo.AssertExpectations(t)
+		usage.AssertExpectations(t)
+	})
+
+	t.Run("Error On List", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		expectedErr := errors.New("db error")
+
+		repo.On("ListStaleUploads", ctx, cutoff, int64(10)).Return([]domain.File{}, expectedErr)
+
+		_, err := NewCleanupStaleUploadsUseCase(repo, withoutSessions(), new(StorageMock), new(UsageRepositoryMock)).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff, Limit: 10})
+
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("Error Invalid Limit", func(t *testing.T) {
+		_, err := NewCleanupStaleUploadsUseCase(new(RepositoryMock), withoutSessions(), new(StorageMock), new(UsageRepositoryMock)).Execute(ctx, CleanupStaleUploadsCommand{StartedBefore: cutoff})
+
+		assert.EqualError(t, err, "limit must be positive")
+	})
+}
@@ -0,0 +1,8 @@
+package cleanupstaleuploads
+
+import "time"
+
+type CleanupStaleUploadsCommand struct {
+	StartedBefore time.Time
+	Limit         int64
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type Storage interface {
+	DiscardUpload(ctx context.Context, file domain.File) error
+}
@@ -0,0 +1,5 @@
+package completeupload
+
+type CompleteUploadCommand struct {
+	Id string
+}
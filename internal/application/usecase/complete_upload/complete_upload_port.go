@@ -0,0 +1,10 @@
+package completeupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ICompleteUploadUseCase interface {
+	Execute(ctx context.Context, command CompleteUploadCommand) (domain.File, error)
+}
@@ -0,0 +1,125 @@
+package completeupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/ingest"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/complete_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strconv"
+)
+
+type CompleteUploadUseCase struct {
+	fileRepository port.FileRepository
+	storage        port.Storage
+	authClient     auth.IAuthClient
+	uploadPolicy   policy.UploadPolicy
+	usage          port.StorageUsageRepository
+	quota          policy.StorageQuota
+	blobs          port.BlobRepository
+}
+
+func NewCompleteUploadUseCase(repo port.FileRepository, storage port.Storage, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, usage port.StorageUsageRepository, quota policy.StorageQuota, blobs port.BlobRepository) *CompleteUploadUseCase {
+	return &CompleteUploadUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		authClient:     authClient,
+		uploadPolicy:   uploadPolicy,
+		usage:          usage,
+		quota:          quota,
+		blobs:          blobs,
+	}
+}
+
+func (uc *CompleteUploadUseCase) Execute(ctx context.Context, command CompleteUploadCommand) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, command.Id)
+	if err != nil {
+		return domain.File{}, err
+	}
+	if file.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if file.Status() != domain.StatusPending {
+		return domain.File{}, apperror.New(apperror.ErrConflict, "upload cannot be completed from %s", file.Status())
+	}
+
+	object, err := uc.storage.StatUpload(ctx, file)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if object.Size != file.Size() {
+		return domain.File{}, uc.markAsFailed(ctx, file, apperror.New(apperror.ErrValidation, "uploaded size %d does not match declared size %d", object.Size, file.Size()))
+	}
+	if object.ContentType != file.MimeType() {
+		return domain.File{}, uc.markAsFailed(ctx, file, apperror.New(apperror.ErrValidation, "uploaded content type %q does not match declared type %q", object.ContentType, file.MimeType()))
+	}
+
+	if err := uc.reserveQuota(ctx, file); err != nil {
+		return domain.File{}, uc.markAsFailed(ctx, file, err)
+	}
+
+	storageKey, err := uc.store(ctx, &file)
+	if err != nil {
+		return domain.File{}, uc.release(ctx, file, err)
+	}
+	if err := uc.ingester().Publish(ctx, &file, storageKey); err != nil {
+		return domain.File{}, uc.release(ctx, file, err)
+	}
+	_ = uc.storage.DeleteUpload(ctx, file)
+	return file, nil
+}
+
+// store copies the staged upload to the file's own key so it is inspected
+// like any other upload before it becomes available.
+func (uc *CompleteUploadUseCase) store(ctx context.Context, file *domain.File) (string, error) {
+	staged, err := uc.storage.OpenUpload(ctx, *file)
+	if err != nil {
+		return "", err
+	}
+	defer staged.Close()
+	return uc.ingester().Store(ctx, file, staged)
+}
+
+// reserveQuota charges the upload to its owner's storage usage when usage is
+// kept, failing when the upload would exceed the owner's quota.
+func (uc *CompleteUploadUseCase) reserveQuota(ctx context.Context, file domain.File) error {
+	if uc.usage == nil {
+		return nil
+	}
+	_, err := uc.usage.Reserve(ctx, file.OwnerID(), file.Size(), 1, uc.quota.LimitFor(file.OwnerID()))
+	return err
+}
+
+func (uc *CompleteUploadUseCase) release(ctx context.Context, file domain.File, cause error) error {
+	if uc.usage == nil {
+		return cause
+	}
+	if err := uc.usage.Release(ctx, file.OwnerID(), file.Size(), 1); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (uc *CompleteUploadUseCase) markAsFailed(ctx context.Context, file domain.File, cause error) error {
+	return uc.ingester().MarkAsFailed(ctx, &file, cause)
+}
+
+func (uc *CompleteUploadUseCase) ingester() ingest.Ingester {
+	return ingest.NewIngester(uc.fileRepository, uc.storage, uc.uploadPolicy, uc.blobs)
+}
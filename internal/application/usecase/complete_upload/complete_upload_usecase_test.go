@@ -0,0 +1,323 @@
+package completeupload
+
+import (
+	"bytes"
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FileRepositoryMock struct {
+	GetFileFn func(ctx context.Context, id string) (domain.File, error)
+	UpdateFn  func(ctx context.Context, file domain.File) error
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.GetFileFn(ctx, id)
+}
+
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	return m.UpdateFn(ctx, file)
+}
+
+type StorageMock struct {
+	StatUploadFn func(ctx context.Context, file domain.File) (aggregate.StoredObject, error)
+	content      []byte
+	saved        []byte
+	deleted      []string
+}
+
+func (m *StorageMock) StatUpload(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+	return m.StatUploadFn(ctx, file)
+}
+
+func (m *StorageMock) OpenUpload(ctx context.Context, file domain.File) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m.content)), nil
+}
+
+func (m *StorageMock) DeleteUpload(ctx context.Context, file domain.File) error {
+	m.deleted = append(m.deleted, "uploads/12/1/video.mp4")
+	return nil
+}
+
+func (m *StorageMock) SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error) {
+	saved, err := io.ReadAll(fileBytes)
+	m.saved = saved
+	return "12/1/video.mp4", err
+}
+
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	m.deleted = append(m.deleted, storageKey)
+	return nil
+}
+
+func (m *StorageMock) PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error) {
+	return "blobs/" + checksum, nil
+}
+
+type BlobRepositoryMock struct {
+	acquired []domain.Blob
+}
+
+func (m *BlobRepositoryMock) GetBlob(ctx context.Context, checksum string) (domain.Blob, error) {
+	return domain.Blob{}, domain.ErrBlobNotFound
+}
+
+func (m *BlobRepositoryMock) Acquire(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+	m.acquired = append(m.acquired, blob)
+	return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 1, time.Now())
+}
+
+func (m *BlobRepositoryMock) Release(ctx context.Context, checksum string) (bool, error) {
+	return false, nil
+}
+
+type StorageUsageRepositoryMock struct {
+	ReserveFn func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error)
+}
+
+func (m *StorageUsageRepositoryMock) Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+	if m.ReserveFn == nil {
+		return domain.RehydrateStorageUsage(ownerID, bytes, files)
+	}
+	return m.ReserveFn(ctx, ownerID, bytes, files, limit)
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	return nil
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuthClient() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+// mp4Content is an ISO base media header padded to the pending file's size.
+var mp4Content = append([]byte("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"), make([]byte, 1000)...)
+
+func pendingFile(t *testing.T) domain.File {
+	file, err := domain.RehydrateFile("1", "12", nil, "video.mp4", "video/mp4", 1024, "", domain.VisibilityPublic, domain.StatusPending, time.Now())
+	assert.NoError(t, err)
+	return file
+}
+
+func repoReturning(file domain.File, updated *[]domain.Status) *FileRepositoryMock {
+	return &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			*updated = append(*updated, file.Status())
+			return nil
+		},
+	}
+}
+
+func TestCompleteUploadUseCase_Success(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "uploads/12/1/video.mp4", Size: 1024, ContentType: "video/mp4"}, nil
+		},
+		content: mp4Content,
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	file, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusAvailable, file.Status())
+	assert.Equal(t, "12/1/video.mp4", file.StorageKey())
+	assert.Equal(t, "video/mp4", file.DetectedMimeType())
+	assert.Len(t, file.Checksum(), 64)
+	assert.Equal(t, mp4Content, storage.saved)
+	assert.Equal(t, []string{"uploads/12/1/video.mp4"}, storage.deleted)
+	assert.Equal(t, []domain.Status{domain.StatusAvailable}, updated)
+}
+
+func TestCompleteUploadUseCase_SucceedsWithoutUsageTracking(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "uploads/12/1/video.mp4", Size: 1024, ContentType: "video/mp4"}, nil
+		},
+		content: mp4Content,
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, nil)
+
+	file, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusAvailable, file.Status())
+}
+
+func TestCompleteUploadUseCase_StoresContentAsBlob(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "uploads/12/1/video.mp4", Size: 1024, ContentType: "video/mp4"}, nil
+		},
+		content: mp4Content,
+	}
+	blobs := &BlobRepositoryMock{}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, blobs)
+
+	file, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusAvailable, file.Status())
+	assert.Equal(t, "blobs/"+file.Checksum(), file.StorageKey())
+	assert.Len(t, blobs.acquired, 1)
+	assert.Equal(t, []string{"12/1/video.mp4", "uploads/12/1/video.mp4"}, storage.deleted)
+}
+
+func TestCompleteUploadUseCase_RejectsContentThatContradictsDeclaredType(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "uploads/12/1/video.mp4", Size: 1024, ContentType: "video/mp4"}, nil
+		},
+		content: append([]byte("<html><script>alert(1)</script>"), bytes.Repeat([]byte(" "), 993)...),
+	}
+	uploadPolicy := policy.UploadPolicy{UploadRules: policy.UploadRules{MimeMismatch: policy.MimeMismatchReject}}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), uploadPolicy, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+	assert.Nil(t, storage.saved)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updated)
+}
+
+func TestCompleteUploadUseCase_NoToken(t *testing.T) {
+	uc := NewCompleteUploadUseCase(&FileRepositoryMock{}, &StorageMock{}, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(context.Background(), CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestCompleteUploadUseCase_NotOwner(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	authClient := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 99
+			return &result, nil
+		},
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), &StorageMock{}, authClient, policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+	assert.Empty(t, updated)
+}
+
+func TestCompleteUploadUseCase_NotPending(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	file, _ := domain.RehydrateFile("1", "12", nil, "video.mp4", "video/mp4", 1024, "12/1/video.mp4", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	uc := NewCompleteUploadUseCase(repoReturning(file, &updated), &StorageMock{}, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "upload cannot be completed from AVAILABLE")
+}
+
+func TestCompleteUploadUseCase_ObjectMissingKeepsFilePending(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{}, errors.New("object not found")
+		},
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "object not found")
+	assert.Empty(t, updated)
+}
+
+func TestCompleteUploadUseCase_SizeMismatchMarksFileAsFailed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "12/1/video.mp4", Size: 10, ContentType: "video/mp4"}, nil
+		},
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "uploaded size 10 does not match declared size 1024")
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updated)
+}
+
+func TestCompleteUploadUseCase_ContentTypeMismatchMarksFileAsFailed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "12/1/video.mp4", Size: 1024, ContentType: "text/html"}, nil
+		},
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, `uploaded content type "text/html" does not match declared type "video/mp4"`)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updated)
+}
+
+func TestCompleteUploadUseCase_FailsUploadOverQuota(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updated []domain.Status
+	storage := &StorageMock{
+		StatUploadFn: func(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: "12/1/video.mp4", Size: 1024, ContentType: "video/mp4"}, nil
+		},
+	}
+	usage := &StorageUsageRepositoryMock{
+		ReserveFn: func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+			assert.Equal(t, "12", ownerID)
+			assert.Equal(t, int64(1024), bytes)
+			assert.Equal(t, int64(512), limit)
+			return domain.StorageUsage{}, apperror.New(apperror.ErrInsufficientStorage, "storage quota exceeded")
+		},
+	}
+	uc := NewCompleteUploadUseCase(repoReturning(pendingFile(t), &updated), storage, validAuthClient(), policy.UploadPolicy{}, usage, policy.StorageQuota{DefaultLimit: 512}, nil)
+
+	_, err := uc.Execute(ctx, CompleteUploadCommand{Id: "1"})
+
+	assert.ErrorIs(t, err, apperror.ErrInsufficientStorage)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updated)
+}
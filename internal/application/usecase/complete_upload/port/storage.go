@@ -0,0 +1,17 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"io"
+)
+
+type Storage interface {
+	StatUpload(ctx context.Context, file domain.File) (aggregate.StoredObject, error)
+	OpenUpload(ctx context.Context, file domain.File) (io.ReadCloser, error)
+	DeleteUpload(ctx context.Context, file domain.File) error
+	SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error)
+	DeleteObject(ctx context.Context, storageKey string) error
+	PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error)
+}
@@ -0,0 +1,9 @@
+package createresumableupload
+
+type CreateResumableUploadCommand struct {
+	ProjectID  *string
+	FileName   string
+	MimeType   string
+	Length     int64
+	Visibility string
+}
@@ -0,0 +1,10 @@
+package createresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ICreateResumableUploadUseCase interface {
+	Execute(ctx context.Context, command CreateResumableUploadCommand) (domain.UploadSession, error)
+}
@@ -0,0 +1,117 @@
+package createresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/create_resumable_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strconv"
+	"time"
+)
+
+type CreateResumableUploadUseCase struct {
+	fileRepository    port.FileRepository
+	sessionRepository port.UploadSessionRepository
+	storage           port.Storage
+	generator         port.IdGenerator
+	authClient        auth.IAuthClient
+	uploadPolicy      policy.UploadPolicy
+	usage             port.StorageUsageRepository
+	quota             policy.StorageQuota
+	sessionExpiry     time.Duration
+}
+
+func NewCreateResumableUploadUseCase(fileRepository port.FileRepository, sessionRepository port.UploadSessionRepository, storage port.Storage, generator port.IdGenerator, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, usage port.StorageUsageRepository, quota policy.StorageQuota, sessionExpiry time.Duration) *CreateResumableUploadUseCase {
+	return &CreateResumableUploadUseCase{
+		fileRepository:    fileRepository,
+		sessionRepository: sessionRepository,
+		storage:           storage,
+		generator:         generator,
+		authClient:        authClient,
+		uploadPolicy:      uploadPolicy,
+		usage:             usage,
+		quota:             quota,
+		sessionExpiry:     sessionExpiry,
+	}
+}
+
+// Execute charges the declared length to the owner's quota up front, so every
+// accepted byte is already paid for; the reservation is given back when the
+// upload is terminated, rejected or expires.
+func (uc *CreateResumableUploadUseCase) Execute(ctx context.Context, command CreateResumableUploadCommand) (_ domain.UploadSession, err error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.UploadSession{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.UploadSession{}, authError
+	}
+
+	if command.Length <= 0 {
+		return domain.UploadSession{}, apperror.New(apperror.ErrValidation, "upload length must be positive")
+	}
+
+	if err := uc.uploadPolicy.Check(policy.UploadCandidate{
+		ProjectID:  command.ProjectID,
+		FileName:   command.FileName,
+		MimeType:   command.MimeType,
+		Size:       command.Length,
+		Visibility: domain.Visibility(command.Visibility),
+	}); err != nil {
+		return domain.UploadSession{}, err
+	}
+
+	ownerID := strconv.FormatInt(*profileId, 10)
+	if _, err := uc.usage.Reserve(ctx, ownerID, command.Length, 1, uc.quota.LimitFor(ownerID)); err != nil {
+		return domain.UploadSession{}, err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if releaseErr := uc.usage.Release(ctx, ownerID, command.Length, 1); releaseErr != nil {
+			err = errors.Join(err, releaseErr)
+		}
+	}()
+
+	file, domainErr := domain.NewFile(uc.generator.Generate(), ownerID, command.ProjectID, command.FileName, command.MimeType, command.Length, domain.Visibility(command.Visibility))
+	if domainErr != nil {
+		return domain.UploadSession{}, domainErr
+	}
+
+	file, saveError := uc.fileRepository.Save(ctx, file)
+	if saveError != nil {
+		return domain.UploadSession{}, saveError
+	}
+
+	multipartID, storageErr := uc.storage.CreateMultipartUpload(ctx, file)
+	if storageErr != nil {
+		return domain.UploadSession{}, uc.markAsFailed(ctx, file, storageErr)
+	}
+
+	session, err := domain.NewUploadSession(file.ID(), file.OwnerID(), multipartID, command.Length, time.Now().Add(uc.sessionExpiry))
+	if err != nil {
+		return domain.UploadSession{}, uc.markAsFailed(ctx, file, err)
+	}
+	if err := uc.sessionRepository.Save(ctx, session); err != nil {
+		return domain.UploadSession{}, uc.markAsFailed(ctx, file, err)
+	}
+	return session, nil
+}
+
+func (uc *CreateResumableUploadUseCase) markAsFailed(ctx context.Context, file domain.File, cause error) error {
+	if err := file.MarkAsFailed(); err != nil {
+		return errors.Join(cause, err)
+	}
+	if err := uc.fileRepository.Update(ctx, file); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
@@ -0,0 +1,225 @@
+package createresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	SaveFn   func(ctx context.Context, file domain.File) (domain.File, error)
+	UpdateFn func(ctx context.Context, file domain.File) error
+}
+
+func (m *FileRepositoryMock) Save(ctx context.Context, file domain.File) (domain.File, error) {
+	return m.SaveFn(ctx, file)
+}
+
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	return m.UpdateFn(ctx, file)
+}
+
+type UploadSessionRepositoryMock struct {
+	SaveFn func(ctx context.Context, session domain.UploadSession) error
+}
+
+func (m *UploadSessionRepositoryMock) Save(ctx context.Context, session domain.UploadSession) error {
+	return m.SaveFn(ctx, session)
+}
+
+type StorageMock struct {
+	CreateMultipartUploadFn func(ctx context.Context, file domain.File) (string, error)
+}
+
+func (m *StorageMock) CreateMultipartUpload(ctx context.Context, file domain.File) (string, error) {
+	return m.CreateMultipartUploadFn(ctx, file)
+}
+
+type StorageUsageRepositoryMock struct {
+	ReserveFn func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error)
+	reserved  int64
+	released  int64
+}
+
+func (m *StorageUsageRepositoryMock) Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+	if m.ReserveFn != nil {
+		return m.ReserveFn(ctx, ownerID, bytes, files, limit)
+	}
+	m.reserved += bytes
+	return domain.RehydrateStorageUsage(ownerID, bytes, files)
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	m.released += bytes
+	return nil
+}
+
+type IdGeneratorMock struct{}
+
+func (gen *IdGeneratorMock) Generate() string {
+	return "1"
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuthClient() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func validCommand() CreateResumableUploadCommand {
+	return CreateResumableUploadCommand{
+		FileName:   "demo.mp4",
+		MimeType:   "video/mp4",
+		Length:     1024,
+		Visibility: "PRIVATE",
+	}
+}
+
+func TestCreateResumableUploadUseCase_Success(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var savedFile domain.File
+	var savedSession domain.UploadSession
+	files := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			savedFile = file
+			return file, nil
+		},
+	}
+	sessions := &UploadSessionRepositoryMock{
+		SaveFn: func(ctx context.Context, session domain.UploadSession) error {
+			savedSession = session
+			return nil
+		},
+	}
+	storage := &StorageMock{
+		CreateMultipartUploadFn: func(ctx context.Context, file domain.File) (string, error) {
+			return "multipart-1", nil
+		},
+	}
+	usage := &StorageUsageRepositoryMock{}
+	uc := NewCreateResumableUploadUseCase(files, sessions, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, usage, policy.StorageQuota{}, time.Hour)
+
+	session, err := uc.Execute(ctx, validCommand())
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1024), usage.reserved)
+	assert.Zero(t, usage.released)
+	assert.Equal(t, domain.StatusPending, savedFile.Status())
+	assert.Equal(t, int64(1024), savedFile.Size())
+	assert.Equal(t, "1", session.ID())
+	assert.Equal(t, "12", session.OwnerID())
+	assert.Equal(t, "multipart-1", session.MultipartID())
+	assert.Equal(t, int64(0), session.Offset())
+	assert.Equal(t, savedSession, session)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), session.ExpiresAt(), 5*time.Second)
+}
+
+func TestCreateResumableUploadUseCase_NoToken(t *testing.T) {
+	uc := NewCreateResumableUploadUseCase(&FileRepositoryMock{}, &UploadSessionRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, time.Hour)
+
+	_, err := uc.Execute(context.Background(), validCommand())
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestCreateResumableUploadUseCase_InvalidLength(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewCreateResumableUploadUseCase(&FileRepositoryMock{}, &UploadSessionRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, time.Hour)
+	command := validCommand()
+	command.Length = 0
+
+	_, err := uc.Execute(ctx, command)
+
+	assert.EqualError(t, err, "upload length must be positive")
+}
+
+func TestCreateResumableUploadUseCase_StorageErrorMarksFileAsFailed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updatedStatus domain.Status
+	files := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatus = file.Status()
+			return nil
+		},
+	}
+	storage := &StorageMock{
+		CreateMultipartUploadFn: func(ctx context.Context, file domain.File) (string, error) {
+			return "", errors.New("storage error")
+		},
+	}
+	uc := NewCreateResumableUploadUseCase(files, &UploadSessionRepositoryMock{}, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, &StorageUsageRepositoryMock{}, policy.StorageQuota{}, time.Hour)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.EqualError(t, err, "storage error")
+	assert.Equal(t, domain.StatusFailed, updatedStatus)
+}
+
+func TestCreateResumableUploadUseCase_SessionSaveErrorMarksFileAsFailed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updatedStatus domain.Status
+	files := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatus = file.Status()
+			return nil
+		},
+	}
+	sessions := &UploadSessionRepositoryMock{
+		SaveFn: func(ctx context.Context, session domain.UploadSession) error {
+			return errors.New("db error")
+		},
+	}
+	storage := &StorageMock{
+		CreateMultipartUploadFn: func(ctx context.Context, file domain.File) (string, error) {
+			return "multipart-1", nil
+		},
+	}
+	usage := &StorageUsageRepositoryMock{}
+	uc := NewCreateResumableUploadUseCase(files, sessions, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, usage, policy.StorageQuota{}, time.Hour)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.EqualError(t, err, "db error")
+	assert.Equal(t, domain.StatusFailed, updatedStatus)
+	assert.Equal(t, int64(1024), usage.released)
+}
+
+func TestCreateResumableUploadUseCase_RejectsUploadOverQuota(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	usage := &StorageUsageRepositoryMock{
+		ReserveFn: func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+			return domain.StorageUsage{}, apperror.New(apperror.ErrInsufficientStorage, "storage quota exceeded")
+		},
+	}
+	uc := NewCreateResumableUploadUseCase(&FileRepositoryMock{}, &UploadSessionRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, usage, policy.StorageQuota{DefaultLimit: 512}, time.Hour)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.ErrorIs(t, err, apperror.ErrInsufficientStorage)
+	assert.Zero(t, usage.released)
+}
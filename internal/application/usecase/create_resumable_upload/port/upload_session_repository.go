@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type UploadSessionRepository interface {
+	Save(ctx context.Context, session domain.UploadSession) error
+}
@@ -0,0 +1,10 @@
+package createsharelink
+
+import "time"
+
+type CreateShareLinkCommand struct {
+	FileId       string
+	ExpiresIn    time.Duration
+	MaxDownloads int64
+	Password     string
+}
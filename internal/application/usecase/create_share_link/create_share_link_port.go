@@ -0,0 +1,10 @@
+package createsharelink
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type ICreateShareLinkUseCase interface {
+	Execute(ctx context.Context, command CreateShareLinkCommand) (aggregate.SignedShareLink, error)
+}
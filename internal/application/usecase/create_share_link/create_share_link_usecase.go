@@ -0,0 +1,84 @@
+package createsharelink
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/usecase/create_share_link/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+	"time"
+)
+
+type CreateShareLinkUseCase struct {
+	fileRepository      port.FileRepository
+	shareLinkRepository port.ShareLinkRepository
+	idGenerator         port.IdGenerator
+	signer              port.TokenSigner
+	hasher              port.PasswordHasher
+	authClient          auth.IAuthClient
+}
+
+func NewCreateShareLinkUseCase(fileRepository port.FileRepository, shareLinkRepository port.ShareLinkRepository, idGenerator port.IdGenerator, signer port.TokenSigner, hasher port.PasswordHasher, authClient auth.IAuthClient) *CreateShareLinkUseCase {
+	return &CreateShareLinkUseCase{
+		fileRepository:      fileRepository,
+		shareLinkRepository: shareLinkRepository,
+		idGenerator:         idGenerator,
+		signer:              signer,
+		hasher:              hasher,
+		authClient:          authClient,
+	}
+}
+
+func (uc *CreateShareLinkUseCase) Execute(ctx context.Context, command CreateShareLinkCommand) (aggregate.SignedShareLink, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.SignedShareLink{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.SignedShareLink{}, authError
+	}
+
+	if command.ExpiresIn <= 0 {
+		return aggregate.SignedShareLink{}, apperror.New(apperror.ErrValidation, "expiration must be positive")
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return aggregate.SignedShareLink{}, err
+	}
+	if file.Status() != domain.StatusAvailable {
+		return aggregate.SignedShareLink{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+	if !file.CanBeManagedBy(strconv.FormatInt(*profileId, 10)) {
+		return aggregate.SignedShareLink{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	var passwordHash string
+	if command.Password != "" {
+		passwordHash, err = uc.hasher.Hash(command.Password)
+		if err != nil {
+			return aggregate.SignedShareLink{}, err
+		}
+	}
+
+	expiresAt := time.Now().Add(command.ExpiresIn).Truncate(time.Second)
+	link, err := domain.NewShareLink(uc.idGenerator.Generate(), file.ID(), file.OwnerID(), expiresAt, command.MaxDownloads, passwordHash)
+	if err != nil {
+		return aggregate.SignedShareLink{}, err
+	}
+
+	if err := uc.shareLinkRepository.Save(ctx, link); err != nil {
+		return aggregate.SignedShareLink{}, err
+	}
+
+	return aggregate.SignedShareLink{
+		Link:  link,
+		Token: uc.signer.Sign(link.ID(), link.ExpiresAt()),
+	}, nil
+}
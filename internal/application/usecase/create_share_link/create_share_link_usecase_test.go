@@ -0,0 +1,167 @@
+package createsharelink
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type FileRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type ShareLinkRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ShareLinkRepositoryMock) Save(ctx context.Context, link domain.ShareLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+type IdGeneratorMock struct{}
+
+func (IdGeneratorMock) Generate() string {
+	return "link-1"
+}
+
+type SignerMock struct{}
+
+func (SignerMock) Sign(linkID string, expiresAt time.Time) string {
+	return "signed-" + linkID
+}
+
+type HasherMock struct {
+	mock.Mock
+}
+
+func (m *HasherMock) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestCreateShareLinkUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*FileRepositoryMock, *ShareLinkRepositoryMock, *HasherMock, *AuthClientMock, *CreateShareLinkUseCase) {
+		files := new(FileRepositoryMock)
+		links := new(ShareLinkRepositoryMock)
+		hasher := new(HasherMock)
+		authCli := new(AuthClientMock)
+		uc := NewCreateShareLinkUseCase(files, links, IdGeneratorMock{}, SignerMock{}, hasher, authCli)
+		return files, links, hasher, authCli, uc
+	}
+
+	availableFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		files, links, hasher, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		links.On("Save", ctxWithToken, mock.MatchedBy(func(link domain.ShareLink) bool {
+			return link.FileID() == "1" && link.MaxDownloads() == 5 && !link.RequiresPassword()
+		})).Return(nil)
+
+		result, err := uc.Execute(ctxWithToken, CreateShareLinkCommand{FileId: "1", ExpiresIn: time.Hour, MaxDownloads: 5})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "signed-link-1", result.Token)
+		assert.Equal(t, "link-1", result.Link.ID())
+		hasher.AssertNotCalled(t, "Hash", mock.Anything)
+		links.AssertExpectations(t)
+	})
+
+	t.Run("Success With Password", func(t *testing.T) {
+		files, links, hasher, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		hasher.On("Hash", "s3cret").Return("hashed", nil)
+		links.On("Save", ctxWithToken, mock.MatchedBy(func(link domain.ShareLink) bool {
+			return link.PasswordHash() == "hashed"
+		})).Return(nil)
+
+		result, err := uc.Execute(ctxWithToken, CreateShareLinkCommand{FileId: "1", ExpiresIn: time.Hour, Password: "s3cret"})
+
+		assert.NoError(t, err)
+		assert.True(t, result.Link.RequiresPassword())
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, _, _, uc := setup()
+		_, err := uc.Execute(context.Background(), CreateShareLinkCommand{FileId: "1", ExpiresIn: time.Hour})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Non Positive Expiration", func(t *testing.T) {
+		files, _, _, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+
+		_, err := uc.Execute(ctxWithToken, CreateShareLinkCommand{FileId: "1"})
+
+		assert.EqualError(t, err, "expiration must be positive")
+		files.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Unauthorized", func(t *testing.T) {
+		files, links, _, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, CreateShareLinkCommand{FileId: "1", ExpiresIn: time.Hour})
+
+		assert.EqualError(t, err, "unauthorized")
+		links.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Save", func(t *testing.T) {
+		files, links, _, authCli, uc := setup()
+		var ownerID int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		links.On("Save", ctxWithToken, mock.Anything).Return(expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, CreateShareLinkCommand{FileId: "1", ExpiresIn: time.Hour})
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,5 @@
+package port
+
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+}
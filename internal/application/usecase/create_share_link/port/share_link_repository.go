@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ShareLinkRepository interface {
+	Save(ctx context.Context, link domain.ShareLink) error
+}
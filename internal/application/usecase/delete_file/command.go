@@ -1,5 +1,6 @@
 package deletefile
 
 type DeleteFileCommand struct {
-	Id string
+	Id        string
+	Permanent bool
 }
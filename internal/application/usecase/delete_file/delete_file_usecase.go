@@ -2,9 +2,11 @@ package deletefile
 
 import (
 	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/reclaim"
 	"devconnectstorage/internal/application/usecase/delete_file/port"
+	"devconnectstorage/internal/domain"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
-	"errors"
 	"strconv"
 )
 
@@ -12,13 +14,17 @@ type DeleteFileUseCase struct {
 	repository port.Repository
 	storage    port.Storage
 	authClient auth.IAuthClient
+	blobs      port.BlobRepository
+	usage      port.StorageUsageRepository
 }
 
-func NewDeleteFileUseCase(repo port.Repository, storage port.Storage, authClient auth.IAuthClient) *DeleteFileUseCase {
+func NewDeleteFileUseCase(repo port.Repository, storage port.Storage, authClient auth.IAuthClient, blobs port.BlobRepository, usage port.StorageUsageRepository) *DeleteFileUseCase {
 	return &DeleteFileUseCase{
 		repository: repo,
 		storage:    storage,
 		authClient: authClient,
+		blobs:      blobs,
+		usage:      usage,
 	}
 }
 
@@ -27,7 +33,7 @@ func (uc *DeleteFileUseCase) Execute(ctx context.Context, command DeleteFileComm
 	token := ctx.Value(auth.AuthTokenKey)
 
 	if token == nil {
-		return errors.New("token cannot be null")
+		return apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
 	}
 
 	profileId, authError := uc.authClient.GetProfile(token.(string))
@@ -41,15 +47,38 @@ func (uc *DeleteFileUseCase) Execute(ctx context.Context, command DeleteFileComm
 		return err
 	}
 
-	if existentFile.OwnerID() != strconv.FormatInt(*profileId, 10) {
-		return errors.New("unauthorized")
+	if !existentFile.CanBeManagedBy(strconv.FormatInt(*profileId, 10)) {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
 	}
 
-	err = uc.storage.DeleteFile(ctx, existentFile)
+	if !command.Permanent {
+		if err := existentFile.MarkAsDeleted(); err != nil {
+			return err
+		}
+		return uc.repository.Update(ctx, existentFile)
+	}
+
+	// An upload in progress may still hold a resumable session, its quota
+	// reservation and a multipart upload; it is cancelled through the upload,
+	// or swept once stale, so none of them is left behind.
+	if existentFile.Status() == domain.StatusPending {
+		return apperror.New(apperror.ErrConflict, "file is still uploading")
+	}
+
+	if existentFile.StorageKey() != "" {
+		err = uc.storage.DeleteFile(ctx, existentFile)
+		if err != nil {
+			return err
+		}
+	}
+	err = uc.repository.DeleteFile(ctx, command.Id)
 	if err != nil {
 		return err
 	}
-	err = uc.repository.DeleteFile(ctx, command.Id)
 
-	return err
+	return uc.reclaimer().Reclaim(ctx, existentFile)
+}
+
+func (uc *DeleteFileUseCase) reclaimer() reclaim.Reclaimer {
+	return reclaim.NewReclaimer(uc.storage, uc.blobs, uc.usage)
 }
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"devconnectstorage/internal/apperror"
 	"devconnectstorage/internal/domain"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
 
@@ -26,6 +28,11 @@ func (m *RepositoryMock) DeleteFile(ctx context.Context, id string) error {
 	return args.Error(0)
 }
 
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
 type StorageMock struct {
 	mock.Mock
 }
@@ -35,6 +42,35 @@ func (m *StorageMock) DeleteFile(ctx context.Context, file domain.File) error {
 	return args.Error(0)
 }
 
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	args := m.Called(ctx, storageKey)
+	return args.Error(0)
+}
+
+type BlobRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *BlobRepositoryMock) Release(ctx context.Context, checksum string) (bool, error) {
+	args := m.Called(ctx, checksum)
+	return args.Bool(0), args.Error(1)
+}
+
+type StorageUsageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	args := m.Called(ctx, ownerID, bytes, files)
+	return args.Error(0)
+}
+
+func releasingUsage() *StorageUsageRepositoryMock {
+	usage := new(StorageUsageRepositoryMock)
+	usage.On("Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return usage
+}
+
 type AuthClientMock struct {
 	mock.Mock
 }
@@ -47,6 +83,23 @@ func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
 	return args.Get(0).(*int64), args.Error(1)
 }
 
+func availableFile(t *testing.T, ownerID string) domain.File {
+	file, err := domain.RehydrateFile("1", ownerID, nil, "path", "text/plain", 32, "storage/key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	assert.NoError(t, err)
+	return file
+}
+
+const blobChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func contentAddressedFile(t *testing.T, status domain.Status, options ...domain.RehydrateOption) domain.File {
+	version, err := domain.RehydrateFileVersion(1, "blobs/2c/"+blobChecksum, "text/plain", 5, blobChecksum, true, time.Now())
+	assert.NoError(t, err)
+	options = append(options, domain.WithChecksum(blobChecksum), domain.WithVersions(1, []domain.FileVersion{version}))
+	file, err := domain.RehydrateFile("1", "123", nil, "hello.txt", "text/plain", 5, "blobs/2c/"+blobChecksum, domain.VisibilityPrivate, status, time.Now(), options...)
+	assert.NoError(t, err)
+	return file
+}
+
 func TestDeleteFileUseCase_Execute(t *testing.T) {
 	const validToken = "valid-token"
 
@@ -56,22 +109,41 @@ func TestDeleteFileUseCase_Execute(t *testing.T) {
 		repo := new(RepositoryMock)
 		storage := new(StorageMock)
 		authCli := new(AuthClientMock)
-		uc := NewDeleteFileUseCase(repo, storage, authCli)
+		uc := NewDeleteFileUseCase(repo, storage, authCli, new(BlobRepositoryMock), releasingUsage())
 		return repo, storage, authCli, uc
 	}
 
-	t.Run("Success", func(t *testing.T) {
+	t.Run("Success Moves File To Trash", func(t *testing.T) {
 		repo, storage, authCli, uc := setup()
-		ownerIDStr := "123"
 		var ownerIDInt int64 = 123
-		file, _ := domain.NewFile("1", ownerIDStr, nil, "path", "text/plain", 32, domain.VisibilityPrivate)
+		file := availableFile(t, "123")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(updated domain.File) bool {
+			return updated.Status() == domain.StatusDeleted && updated.DeletedAt() != nil
+		})).Return(nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+
+		assert.NoError(t, err)
+		authCli.AssertExpectations(t)
+		repo.AssertExpectations(t)
+		storage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Permanent", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		file := availableFile(t, "123")
 
 		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
 		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
 		storage.On("DeleteFile", ctxWithToken, file).Return(nil)
 		repo.On("DeleteFile", ctxWithToken, "file-id").Return(nil)
 
-		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
 
 		assert.NoError(t, err)
 		authCli.AssertExpectations(t)
@@ -79,6 +151,95 @@ func TestDeleteFileUseCase_Execute(t *testing.T) {
 		storage.AssertExpectations(t)
 	})
 
+	t.Run("Error Permanent Pending File", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		file, _ := domain.NewFile("1", "123", nil, "path", "text/plain", 32, domain.VisibilityPrivate)
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
+
+		assert.ErrorIs(t, err, apperror.ErrConflict)
+		storage.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "DeleteFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Permanent Releases Last Blob Reference", func(t *testing.T) {
+		repo, storage, authCli, _ := setup()
+		blobs := new(BlobRepositoryMock)
+		uc := NewDeleteFileUseCase(repo, storage, authCli, blobs, releasingUsage())
+		var ownerIDInt int64 = 123
+		file := contentAddressedFile(t, domain.StatusAvailable)
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		storage.On("DeleteFile", ctxWithToken, file).Return(nil)
+		repo.On("DeleteFile", ctxWithToken, "file-id").Return(nil)
+		blobs.On("Release", ctxWithToken, blobChecksum).Return(true, nil)
+		storage.On("DeleteObject", ctxWithToken, "blobs/2c/"+blobChecksum).Return(nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
+
+		assert.NoError(t, err)
+		blobs.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Success Permanent Keeps Shared Blob", func(t *testing.T) {
+		repo, storage, authCli, _ := setup()
+		blobs := new(BlobRepositoryMock)
+		uc := NewDeleteFileUseCase(repo, storage, authCli, blobs, releasingUsage())
+		var ownerIDInt int64 = 123
+		file := contentAddressedFile(t, domain.StatusAvailable)
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		storage.On("DeleteFile", ctxWithToken, file).Return(nil)
+		repo.On("DeleteFile", ctxWithToken, "file-id").Return(nil)
+		blobs.On("Release", ctxWithToken, blobChecksum).Return(false, nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
+
+		assert.NoError(t, err)
+		storage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Permanent Releases Storage Usage", func(t *testing.T) {
+		repo, storage, authCli, _ := setup()
+		usage := new(StorageUsageRepositoryMock)
+		uc := NewDeleteFileUseCase(repo, storage, authCli, new(BlobRepositoryMock), usage)
+		var ownerIDInt int64 = 123
+		file := availableFile(t, "123")
+		_ = file.AddVersion("storage/key-v2", "text/plain", 8, "")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		storage.On("DeleteFile", ctxWithToken, file).Return(nil)
+		repo.On("DeleteFile", ctxWithToken, "file-id").Return(nil)
+		usage.On("Release", ctxWithToken, "123", int64(40), int64(1)).Return(nil).Once()
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
+
+		assert.NoError(t, err)
+		usage.AssertExpectations(t)
+	})
+
+	t.Run("Error Trash Pending File", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		file, _ := domain.NewFile("1", "123", nil, "path", "text/plain", 32, domain.VisibilityPrivate)
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error No Token In Context", func(t *testing.T) {
 		_, _, _, uc := setup()
 		err := uc.Execute(context.Background(), DeleteFileCommand{Id: "file-id"})
@@ -98,6 +259,37 @@ func TestDeleteFileUseCase_Execute(t *testing.T) {
 		repo.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
 	})
 
+	t.Run("Success Manager Moves Shared File To Trash", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var managerID int64 = 456
+		file := availableFile(t, "123")
+		_ = file.ShareWith("123", "456", domain.PermissionManage)
+
+		authCli.On("GetProfile", validToken).Return(&managerID, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error Reader Cannot Delete Shared File", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var readerID int64 = 456
+		file := availableFile(t, "123")
+		_ = file.ShareWith("123", "456", domain.PermissionRead)
+
+		authCli.On("GetProfile", validToken).Return(&readerID, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+
+		assert.EqualError(t, err, "unauthorized")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
 	t.Run("Error Unauthorized Owner", func(t *testing.T) {
 		repo, storage, authCli, uc := setup()
 		differentOwnerID := int64(456)
@@ -126,18 +318,32 @@ func TestDeleteFileUseCase_Execute(t *testing.T) {
 
 	t.Run("Error On Storage Delete", func(t *testing.T) {
 		repo, storage, authCli, uc := setup()
-		ownerIDStr := "123"
 		var ownerIDInt int64 = 123
-		file, _ := domain.NewFile("1", ownerIDStr, nil, "path", "text/plain", 32, domain.VisibilityPrivate)
+		file := availableFile(t, "123")
 		expectedErr := errors.New("storage error")
 
 		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
 		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
 		storage.On("DeleteFile", ctxWithToken, file).Return(expectedErr)
 
-		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id", Permanent: true})
 
 		assert.Equal(t, expectedErr, err)
 		repo.AssertNotCalled(t, "DeleteFile", ctxWithToken, "file-id")
 	})
+
+	t.Run("Error On Repository Update", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		file := availableFile(t, "123")
+		expectedErr := errors.New("update error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr)
+
+		err := uc.Execute(ctxWithToken, DeleteFileCommand{Id: "file-id"})
+
+		assert.Equal(t, expectedErr, err)
+	})
 }
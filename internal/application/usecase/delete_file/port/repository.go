@@ -8,4 +8,5 @@ import (
 type Repository interface {
 	DeleteFile(ctx context.Context, id string) error
 	GetFile(ctx context.Context, id string) (domain.File, error)
+	Update(ctx context.Context, file domain.File) error
 }
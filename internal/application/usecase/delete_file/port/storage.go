@@ -7,4 +7,5 @@ import (
 
 type Storage interface {
 	DeleteFile(ctx context.Context, file domain.File) error
+	DeleteObject(ctx context.Context, storageKey string) error
 }
@@ -0,0 +1,10 @@
+package downloadsharedfile
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IDownloadSharedFileUseCase interface {
+	Execute(ctx context.Context, query DownloadSharedFileQuery) (*aggregate.FileContent, error)
+}
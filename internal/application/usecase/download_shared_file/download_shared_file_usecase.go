@@ -0,0 +1,76 @@
+package downloadsharedfile
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/usecase/download_shared_file/port"
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type DownloadSharedFileUseCase struct {
+	fileRepository      port.FileRepository
+	shareLinkRepository port.ShareLinkRepository
+	storage             port.FileStorage
+	verifier            port.TokenVerifier
+	hasher              port.PasswordHasher
+}
+
+func NewDownloadSharedFileUseCase(fileRepository port.FileRepository, shareLinkRepository port.ShareLinkRepository, storage port.FileStorage, verifier port.TokenVerifier, hasher port.PasswordHasher) *DownloadSharedFileUseCase {
+	return &DownloadSharedFileUseCase{
+		fileRepository:      fileRepository,
+		shareLinkRepository: shareLinkRepository,
+		storage:             storage,
+		verifier:            verifier,
+		hasher:              hasher,
+	}
+}
+
+func (uc *DownloadSharedFileUseCase) Execute(ctx context.Context, query DownloadSharedFileQuery) (*aggregate.FileContent, error) {
+	now := time.Now()
+	linkID, err := uc.verifier.Verify(query.Token, now)
+	if err != nil {
+		return &aggregate.FileContent{}, err
+	}
+
+	link, err := uc.shareLinkRepository.GetShareLink(ctx, linkID)
+	if err != nil {
+		return &aggregate.FileContent{}, err
+	}
+	if err := link.CheckUsableAt(now); err != nil {
+		return &aggregate.FileContent{}, err
+	}
+	if link.RequiresPassword() {
+		if query.Password == "" {
+			return &aggregate.FileContent{}, apperror.New(apperror.ErrUnauthenticated, "password required")
+		}
+		if err := uc.hasher.Compare(link.PasswordHash(), query.Password); err != nil {
+			return &aggregate.FileContent{}, err
+		}
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, link.FileID())
+	if err != nil {
+		return &aggregate.FileContent{}, err
+	}
+	if file.Status() != domain.StatusAvailable {
+		return &aggregate.FileContent{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+
+	content, err := uc.storage.GetFile(ctx, file.StorageKey(), 0, -1)
+	if err != nil {
+		return &aggregate.FileContent{}, err
+	}
+
+	if err := uc.shareLinkRepository.RegisterDownload(ctx, link.ID(), now); err != nil {
+		_ = content.Close()
+		return &aggregate.FileContent{}, err
+	}
+
+	return &aggregate.FileContent{
+		Metadata:   file,
+		Validators: aggregate.NewValidators(file, file.Checksum()),
+		Content:    content,
+	}, nil
+}
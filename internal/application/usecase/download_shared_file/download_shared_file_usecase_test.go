@@ -0,0 +1,213 @@
+package downloadsharedfile
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type FileRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type ShareLinkRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ShareLinkRepositoryMock) GetShareLink(ctx context.Context, id string) (domain.ShareLink, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ShareLink), args.Error(1)
+}
+
+func (m *ShareLinkRepositoryMock) RegisterDownload(ctx context.Context, id string, now time.Time) error {
+	args := m.Called(ctx, id, now)
+	return args.Error(0)
+}
+
+type StorageMock struct {
+	mock.Mock
+}
+
+func (m *StorageMock) GetFile(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error) {
+	args := m.Called(ctx, storageKey, offset, length)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(io.ReadCloser), args.Error(1)
+}
+
+type VerifierMock struct {
+	mock.Mock
+}
+
+func (m *VerifierMock) Verify(token string, now time.Time) (string, error) {
+	args := m.Called(token, now)
+	return args.String(0), args.Error(1)
+}
+
+type HasherMock struct {
+	mock.Mock
+}
+
+func (m *HasherMock) Compare(hash string, password string) error {
+	args := m.Called(hash, password)
+	return args.Error(0)
+}
+
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestDownloadSharedFileUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func() (*FileRepositoryMock, *ShareLinkRepositoryMock, *StorageMock, *VerifierMock, *HasherMock, *DownloadSharedFileUseCase) {
+		files := new(FileRepositoryMock)
+		links := new(ShareLinkRepositoryMock)
+		storage := new(StorageMock)
+		verifier := new(VerifierMock)
+		hasher := new(HasherMock)
+		uc := NewDownloadSharedFileUseCase(files, links, storage, verifier, hasher)
+		return files, links, storage, verifier, hasher, uc
+	}
+
+	availableFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 7, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		return file
+	}
+	link := func(passwordHash string) domain.ShareLink {
+		link, _ := domain.NewShareLink("link-1", "1", "123", time.Now().Add(time.Hour), 0, passwordHash)
+		return link
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		files, links, storage, verifier, _, uc := setup()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link(""), nil)
+		files.On("GetFile", ctx, "1").Return(availableFile(), nil)
+		storage.On("GetFile", ctx, "key", int64(0), int64(-1)).Return(io.NopCloser(bytes.NewReader([]byte("content"))), nil)
+		links.On("RegisterDownload", ctx, "link-1", mock.Anything).Return(nil)
+
+		result, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "1", result.Metadata.ID())
+		links.AssertExpectations(t)
+	})
+
+	t.Run("Success With Password", func(t *testing.T) {
+		files, links, storage, verifier, hasher, uc := setup()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link("hashed"), nil)
+		hasher.On("Compare", "hashed", "s3cret").Return(nil)
+		files.On("GetFile", ctx, "1").Return(availableFile(), nil)
+		storage.On("GetFile", ctx, "key", int64(0), int64(-1)).Return(io.NopCloser(bytes.NewReader([]byte("content"))), nil)
+		links.On("RegisterDownload", ctx, "link-1", mock.Anything).Return(nil)
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token", Password: "s3cret"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("Error Invalid Token", func(t *testing.T) {
+		_, links, _, verifier, _, uc := setup()
+
+		verifier.On("Verify", "token", mock.Anything).Return("", errors.New("invalid share token"))
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.EqualError(t, err, "invalid share token")
+		links.AssertNotCalled(t, "GetShareLink", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Password Required", func(t *testing.T) {
+		files, links, _, verifier, _, uc := setup()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link("hashed"), nil)
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.EqualError(t, err, "password required")
+		files.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Wrong Password", func(t *testing.T) {
+		files, links, _, verifier, hasher, uc := setup()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link("hashed"), nil)
+		hasher.On("Compare", "hashed", "wrong").Return(errors.New("invalid password"))
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token", Password: "wrong"})
+
+		assert.EqualError(t, err, "invalid password")
+		files.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Revoked Link", func(t *testing.T) {
+		files, links, _, verifier, _, uc := setup()
+		revoked := link("")
+		_ = revoked.Revoke()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(revoked, nil)
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.EqualError(t, err, "share link revoked")
+		files.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Download Limit Closes Content", func(t *testing.T) {
+		files, links, storage, verifier, _, uc := setup()
+		content := &closeTracker{Reader: bytes.NewReader([]byte("content"))}
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link(""), nil)
+		files.On("GetFile", ctx, "1").Return(availableFile(), nil)
+		storage.On("GetFile", ctx, "key", int64(0), int64(-1)).Return(content, nil)
+		links.On("RegisterDownload", ctx, "link-1", mock.Anything).Return(errors.New("share link download limit reached"))
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.EqualError(t, err, "share link download limit reached")
+		assert.True(t, content.closed)
+	})
+
+	t.Run("Error File Not Available", func(t *testing.T) {
+		files, links, storage, verifier, _, uc := setup()
+		deleted := availableFile()
+		_ = deleted.MarkAsDeleted()
+
+		verifier.On("Verify", "token", mock.Anything).Return("link-1", nil)
+		links.On("GetShareLink", ctx, "link-1").Return(link(""), nil)
+		files.On("GetFile", ctx, "1").Return(deleted, nil)
+
+		_, err := uc.Execute(ctx, DownloadSharedFileQuery{Token: "token"})
+
+		assert.EqualError(t, err, "file not found")
+		storage.AssertNotCalled(t, "GetFile", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
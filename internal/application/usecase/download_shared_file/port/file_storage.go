@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"io"
+)
+
+type FileStorage interface {
+	GetFile(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error)
+}
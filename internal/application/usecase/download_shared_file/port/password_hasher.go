@@ -0,0 +1,5 @@
+package port
+
+type PasswordHasher interface {
+	Compare(hash string, password string) error
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type ShareLinkRepository interface {
+	GetShareLink(ctx context.Context, id string) (domain.ShareLink, error)
+	RegisterDownload(ctx context.Context, id string, now time.Time) error
+}
@@ -0,0 +1,7 @@
+package port
+
+import "time"
+
+type TokenVerifier interface {
+	Verify(token string, now time.Time) (string, error)
+}
@@ -0,0 +1,6 @@
+package downloadsharedfile
+
+type DownloadSharedFileQuery struct {
+	Token    string
+	Password string
+}
@@ -0,0 +1,10 @@
+package getcleanupjob
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IGetCleanupJobUseCase interface {
+	Execute(ctx context.Context, query GetCleanupJobQuery) (domain.CleanupJob, error)
+}
@@ -0,0 +1,25 @@
+package getcleanupjob
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/get_cleanup_job/port"
+	"devconnectstorage/internal/domain"
+)
+
+type GetCleanupJobUseCase struct {
+	jobs port.CleanupJobRepository
+}
+
+func NewGetCleanupJobUseCase(jobs port.CleanupJobRepository) *GetCleanupJobUseCase {
+	return &GetCleanupJobUseCase{
+		jobs: jobs,
+	}
+}
+
+func (uc *GetCleanupJobUseCase) Execute(ctx context.Context, query GetCleanupJobQuery) (domain.CleanupJob, error) {
+	if query.Id == "" {
+		return domain.CleanupJob{}, apperror.New(apperror.ErrValidation, "id cannot be empty")
+	}
+	return uc.jobs.GetJob(ctx, query.Id)
+}
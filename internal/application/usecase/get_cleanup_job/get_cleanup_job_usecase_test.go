@@ -0,0 +1,38 @@
+package getcleanupjob
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CleanupJobRepositoryMock struct {
+	GetJobFn func(ctx context.Context, id string) (domain.CleanupJob, error)
+}
+
+func (m *CleanupJobRepositoryMock) GetJob(ctx context.Context, id string) (domain.CleanupJob, error) {
+	return m.GetJobFn(ctx, id)
+}
+
+func TestGetCleanupJobUseCase_ShouldReturnJob(t *testing.T) {
+	repo := &CleanupJobRepositoryMock{
+		GetJobFn: func(ctx context.Context, id string) (domain.CleanupJob, error) {
+			return domain.NewCleanupJob(id, domain.CleanupScopeOwner, "12")
+		},
+	}
+
+	job, err := NewGetCleanupJobUseCase(repo).Execute(context.Background(), GetCleanupJobQuery{Id: "event-1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "event-1", job.ID())
+}
+
+func TestGetCleanupJobUseCase_ShouldRejectEmptyId(t *testing.T) {
+	_, err := NewGetCleanupJobUseCase(&CleanupJobRepositoryMock{}).Execute(context.Background(), GetCleanupJobQuery{})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
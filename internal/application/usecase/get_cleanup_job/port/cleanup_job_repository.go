@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type CleanupJobRepository interface {
+	GetJob(ctx context.Context, id string) (domain.CleanupJob, error)
+}
@@ -0,0 +1,5 @@
+package getcleanupjob
+
+type GetCleanupJobQuery struct {
+	Id string
+}
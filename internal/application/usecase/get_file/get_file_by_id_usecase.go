@@ -2,25 +2,28 @@ package getfile
 
 import (
 	"context"
+	"devconnectstorage/internal/apperror"
 	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
 	"devconnectstorage/internal/application/usecase/get_file/port"
 	"devconnectstorage/internal/domain"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
-	"errors"
-	"strconv"
+	"devconnectstorage/internal/infraestructure/outbound/project"
 )
 
 type GetFileByIdUseCase struct {
-	repository port.FileRepository
-	storage    port.FileStorage
-	authClient auth.IAuthClient
+	repository       port.FileRepository
+	storage          port.FileStorage
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
 }
 
-func NewGetFileByIdUseCase(repository port.FileRepository, storage port.FileStorage, authClient auth.IAuthClient) *GetFileByIdUseCase {
+func NewGetFileByIdUseCase(repository port.FileRepository, storage port.FileStorage, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *GetFileByIdUseCase {
 	return &GetFileByIdUseCase{
-		repository: repository,
-		storage:    storage,
-		authClient: authClient,
+		repository:       repository,
+		storage:          storage,
+		authClient:       authClient,
+		membershipClient: membershipClient,
 	}
 }
 
@@ -28,7 +31,7 @@ func (uc *GetFileByIdUseCase) Execute(ctx context.Context, query GetFileByIdQuer
 	token := ctx.Value(auth.AuthTokenKey)
 
 	if token == nil {
-		return &aggregate.FileContent{}, errors.New("token cannot be null")
+		return &aggregate.FileContent{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
 	}
 
 	profileId, authError := uc.authClient.GetProfile(token.(string))
@@ -41,17 +44,76 @@ func (uc *GetFileByIdUseCase) Execute(ctx context.Context, query GetFileByIdQuer
 	if repositoryError != nil {
 		return &aggregate.FileContent{}, repositoryError
 	}
-	if metadata.Visibility() == domain.VisibilityPrivate && metadata.OwnerID() != strconv.FormatInt(*profileId, 10) {
-		return &aggregate.FileContent{}, errors.New("unauthorized")
+	if metadata.Status() != domain.StatusAvailable {
+		return &aggregate.FileContent{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+	allowed, accessError := policy.CanReadFile(uc.membershipClient, token.(string), *profileId, metadata)
+	if accessError != nil {
+		return &aggregate.FileContent{}, accessError
+	}
+	if !allowed {
+		return &aggregate.FileContent{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if query.Version != 0 {
+		metadata, repositoryError = metadata.AtVersion(query.Version)
+		if repositoryError != nil {
+			return &aggregate.FileContent{}, repositoryError
+		}
+	}
+
+	validators, storageError := uc.validators(ctx, metadata)
+	if storageError != nil {
+		return &aggregate.FileContent{}, storageError
+	}
+	if query.Preconditions.NotModified(validators) {
+		return &aggregate.FileContent{Metadata: metadata, Validators: validators}, aggregate.ErrNotModified
+	}
+
+	if len(query.Ranges) > 0 && query.IfRange.Matches(validators) {
+		return uc.openRanges(ctx, metadata, validators, query.Ranges)
 	}
 
-	content, storageError := uc.storage.GetFile(ctx, metadata.StorageKey())
+	content, storageError := uc.storage.GetFile(ctx, metadata.StorageKey(), 0, -1)
 	if storageError != nil {
 		return &aggregate.FileContent{}, storageError
 	}
 
 	return &aggregate.FileContent{
-		Metadata: metadata,
-		Content:  content,
+		Metadata:   metadata,
+		Validators: validators,
+		Content:    content,
 	}, nil
 }
+
+// validators prefers the stored checksum and only stats the object for files
+// uploaded before checksums were recorded.
+func (uc *GetFileByIdUseCase) validators(ctx context.Context, metadata domain.File) (aggregate.Validators, error) {
+	if checksum := metadata.Checksum(); checksum != "" {
+		return aggregate.NewValidators(metadata, checksum), nil
+	}
+	object, err := uc.storage.StatFile(ctx, metadata.StorageKey())
+	if err != nil {
+		return aggregate.Validators{}, err
+	}
+	return aggregate.NewValidators(metadata, object.ETag), nil
+}
+
+func (uc *GetFileByIdUseCase) openRanges(ctx context.Context, metadata domain.File, validators aggregate.Validators, specs []aggregate.RangeSpec) (*aggregate.FileContent, error) {
+	result := &aggregate.FileContent{Metadata: metadata, Validators: validators}
+	for _, spec := range specs {
+		byteRange, ok := spec.Resolve(metadata.Size())
+		if !ok {
+			continue
+		}
+		content, err := uc.storage.GetFile(ctx, metadata.StorageKey(), byteRange.Start, byteRange.Length)
+		if err != nil {
+			_ = result.Close()
+			return &aggregate.FileContent{}, err
+		}
+		result.Parts = append(result.Parts, aggregate.RangeContent{Range: byteRange, Content: content})
+	}
+	if len(result.Parts) == 0 {
+		return result, aggregate.ErrRangeNotSatisfiable
+	}
+	return result, nil
+}
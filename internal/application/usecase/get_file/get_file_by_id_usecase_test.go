@@ -8,6 +8,7 @@ import (
 	"devconnectstorage/internal/infraestructure/outbound/auth"
 	"errors"
 	"io"
+	"strings"
 	"testing"
 	"time"
 
@@ -24,13 +25,25 @@ func (repo *MockRepositoryPort) GetFile(ctx context.Context, id string) (domain.
 }
 
 type MockStoragePort struct {
-	mock func(ctx context.Context, storageKey string) (io.ReadCloser, error)
+	mock      func(ctx context.Context, storageKey string) (io.ReadCloser, error)
+	rangeMock func(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error)
+	statMock  func(ctx context.Context, storageKey string) (aggregate.StoredObject, error)
 }
 
-func (storage *MockStoragePort) GetFile(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+func (storage *MockStoragePort) GetFile(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error) {
+	if storage.rangeMock != nil {
+		return storage.rangeMock(ctx, storageKey, offset, length)
+	}
 	return storage.mock(ctx, storageKey)
 }
 
+func (storage *MockStoragePort) StatFile(ctx context.Context, storageKey string) (aggregate.StoredObject, error) {
+	if storage.statMock != nil {
+		return storage.statMock(ctx, storageKey)
+	}
+	return aggregate.StoredObject{Key: storageKey, ETag: "object-etag"}, nil
+}
+
 type AuthClientMock struct {
 	GetProfileFn func(token string) (*int64, error)
 }
@@ -39,6 +52,19 @@ func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
 	return m.GetProfileFn(token)
 }
 
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
 func TestGetFileByIdUseCase_ShouldSuccess(t *testing.T) {
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
 	auth := &AuthClientMock{
@@ -73,6 +99,7 @@ func TestGetFileByIdUseCase_ShouldSuccess(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -118,6 +145,7 @@ func TestGetFileByIdUseCase_ShouldSuccessWithPrivateVisibility(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -163,6 +191,7 @@ func TestGetFileByIdUseCase_ShouldFailWithDifferentOwnerAndPrivate(t *testing.T)
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -206,6 +235,7 @@ func TestGetFileByIdUseCase_ShouldFailWithoutToken(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -238,6 +268,7 @@ func TestGetFileByIdUseCase_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -281,6 +312,7 @@ func TestGetFileByIdUseCase_ShouldReturnErrorWhenStorageFails(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -323,6 +355,7 @@ func TestGetFileByIdUseCase_ShouldReturnErrorWhenAuthFails(t *testing.T) {
 		&mockRepository,
 		&mockStorage,
 		auth,
+		&MembershipClientMock{},
 	)
 
 	query := GetFileByIdQuery{
@@ -331,3 +364,300 @@ func TestGetFileByIdUseCase_ShouldReturnErrorWhenAuthFails(t *testing.T) {
 	_, err := usecase.Execute(ctx, query)
 	require.Error(t, err)
 }
+
+func TestGetFileByIdUseCase_ShouldFailWhenFileIsInTrash(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	storageCalled := false
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			storageCalled = true
+			return io.NopCloser(bytes.NewReader([]byte("file content"))), nil
+		},
+	}
+	mockRepository := MockRepositoryPort{
+		mock: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.RehydrateFile(
+				id,
+				"12",
+				nil,
+				"text.txt",
+				"plain/text",
+				12,
+				"ssfdasdfdsfa",
+				domain.VisibilityPrivate,
+				domain.StatusDeleted,
+				time.Now(),
+				domain.WithDeletedAt(time.Now()),
+			)
+		},
+	}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+
+	usecase := NewGetFileByIdUseCase(
+		&mockRepository,
+		&mockStorage,
+		auth,
+		&MembershipClientMock{},
+	)
+
+	_, err := usecase.Execute(ctx, GetFileByIdQuery{Id: "1"})
+	require.EqualError(t, err, "file not found")
+	assert.False(t, storageCalled)
+}
+
+func versionedFile(id string) (domain.File, error) {
+	file, err := domain.RehydrateFile(id, "12", nil, "text.txt", "text/plain", 2, "key/v1", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	if err != nil {
+		return domain.File{}, err
+	}
+	err = file.AddVersion("key/v2", "text/markdown", 3, "")
+	return file, err
+}
+
+func TestGetFileByIdUseCase_ShouldReadRequestedVersion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requestedKey string
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			requestedKey = storageKey
+			return io.NopCloser(bytes.NewReader([]byte("v1"))), nil
+		},
+	}
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return versionedFile(id)
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+
+	usecase := NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, &MembershipClientMock{})
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{Id: "1", Version: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "key/v1", requestedKey)
+	assert.Equal(t, int64(2), result.Metadata.Size())
+	assert.Equal(t, 1, result.Metadata.Version())
+}
+
+func TestGetFileByIdUseCase_ShouldFailForUnknownVersion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("v1"))), nil
+		},
+	}
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return versionedFile(id)
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+
+	usecase := NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, &MembershipClientMock{})
+
+	_, err := usecase.Execute(ctx, GetFileByIdQuery{Id: "1", Version: 9})
+	require.Error(t, err)
+}
+
+func TestGetFileByIdUseCase_ShouldAllowProjectMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader([]byte("file content"))), nil
+		},
+	}
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.RehydrateFile(id, "owner 1", &projectID, "text.txt", "plain/text", 12, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now())
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, pid string, profileID int64) (bool, error) {
+			return pid == projectID && profileID == 12, nil
+		},
+	}
+
+	usecase := NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, membership)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{Id: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", result.Metadata.ID())
+}
+
+func TestGetFileByIdUseCase_ShouldRejectProjectOutsider(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			t.Fatal("storage must not be opened for outsiders")
+			return nil, nil
+		},
+	}
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.RehydrateFile(id, "owner 1", &projectID, "text.txt", "plain/text", 12, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now())
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, pid string, profileID int64) (bool, error) {
+			return false, nil
+		},
+	}
+
+	usecase := NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, membership)
+
+	_, err := usecase.Execute(ctx, GetFileByIdQuery{Id: "1"})
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func rangeUseCase(t *testing.T, checksum string, requested *[][2]int64) *GetFileByIdUseCase {
+	t.Helper()
+	mockStorage := MockStoragePort{
+		rangeMock: func(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error) {
+			*requested = append(*requested, [2]int64{offset, length})
+			return io.NopCloser(bytes.NewReader([]byte("0123456789"))), nil
+		},
+	}
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.RehydrateFile(id, "12", nil, "text.txt", "text/plain", 10, "key", domain.VisibilityPublic, domain.StatusAvailable, time.Now(), domain.WithChecksum(checksum))
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+	return NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, &MembershipClientMock{})
+}
+
+func TestGetFileByIdUseCase_ShouldOpenRequestedRanges(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requested [][2]int64
+	usecase := rangeUseCase(t, "", &requested)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id:     "1",
+		Ranges: []aggregate.RangeSpec{{First: 2, Last: 4}, {First: -1, Last: 3}, {First: 8, Last: -1}, {First: 20, Last: -1}},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, result.Content)
+	assert.Equal(t, [][2]int64{{2, 3}, {7, 3}, {8, 2}}, requested)
+	require.Len(t, result.Parts, 3)
+	assert.Equal(t, int64(9), result.Parts[1].Range.End())
+}
+
+func TestGetFileByIdUseCase_ShouldFailWhenNoRangeIsSatisfiable(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requested [][2]int64
+	usecase := rangeUseCase(t, "", &requested)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id:     "1",
+		Ranges: []aggregate.RangeSpec{{First: 10, Last: 12}, {First: -1, Last: 0}},
+	})
+	require.ErrorIs(t, err, aggregate.ErrRangeNotSatisfiable)
+	assert.Equal(t, int64(10), result.Metadata.Size())
+	assert.Empty(t, requested)
+}
+
+func TestGetFileByIdUseCase_ShouldServeFullContentWhenIfRangeDoesNotMatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	checksum := strings.Repeat("a", 64)
+	var requested [][2]int64
+	usecase := rangeUseCase(t, checksum, &requested)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id:      "1",
+		Ranges:  []aggregate.RangeSpec{{First: 0, Last: 1}},
+		IfRange: aggregate.RangeCondition{ETag: strings.Repeat("b", 64)},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, result.Parts)
+	assert.NotNil(t, result.Content)
+	assert.Equal(t, [][2]int64{{0, -1}}, requested)
+}
+
+func TestGetFileByIdUseCase_ShouldReturnNotModifiedWithoutOpeningStorage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	checksum := strings.Repeat("a", 64)
+	var requested [][2]int64
+	usecase := rangeUseCase(t, checksum, &requested)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{"other", checksum}},
+	})
+	require.ErrorIs(t, err, aggregate.ErrNotModified)
+	assert.Equal(t, checksum, result.Validators.ETag)
+	assert.Nil(t, result.Content)
+	assert.Empty(t, requested)
+}
+
+func TestGetFileByIdUseCase_ShouldIgnoreModifiedSinceWhenEntityTagsDiffer(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requested [][2]int64
+	usecase := rangeUseCase(t, strings.Repeat("a", 64), &requested)
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id: "1",
+		Preconditions: aggregate.Preconditions{
+			IfNoneMatch:     []string{strings.Repeat("b", 64)},
+			IfModifiedSince: time.Now().Add(time.Hour),
+		},
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, result.Content)
+	assert.Len(t, requested, 1)
+}
+
+func TestGetFileByIdUseCase_ShouldUseObjectETagWhenChecksumIsMissing(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	mockStorage := MockStoragePort{
+		mock: func(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+			t.Fatal("content must not be opened")
+			return nil, nil
+		},
+		statMock: func(ctx context.Context, storageKey string) (aggregate.StoredObject, error) {
+			return aggregate.StoredObject{Key: storageKey, ETag: "d41d8cd98f00b204e9800998ecf8427e"}, nil
+		},
+	}
+	createdAt := time.Date(2024, 3, 1, 10, 30, 15, 500, time.UTC)
+	mockRepository := MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.RehydrateFile(id, "12", nil, "text.txt", "text/plain", 10, "key", domain.VisibilityPublic, domain.StatusAvailable, createdAt)
+	}}
+	auth := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+	usecase := NewGetFileByIdUseCase(&mockRepository, &mockStorage, auth, &MembershipClientMock{})
+
+	result, err := usecase.Execute(ctx, GetFileByIdQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfModifiedSince: createdAt.Truncate(time.Second)},
+	})
+	require.ErrorIs(t, err, aggregate.ErrNotModified)
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", result.Validators.ETag)
+	assert.Equal(t, createdAt.Truncate(time.Second), result.Validators.LastModified)
+}
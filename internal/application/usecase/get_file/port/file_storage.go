@@ -2,9 +2,11 @@ package port
 
 import (
 	"context"
+	"devconnectstorage/internal/application/aggregate"
 	"io"
 )
 
 type FileStorage interface {
-	GetFile(ctx context.Context, storageKey string) (io.ReadCloser, error)
+	GetFile(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error)
+	StatFile(ctx context.Context, storageKey string) (aggregate.StoredObject, error)
 }
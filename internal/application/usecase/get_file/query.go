@@ -1,5 +1,11 @@
 package getfile
 
+import "devconnectstorage/internal/application/aggregate"
+
 type GetFileByIdQuery struct {
-	Id string
+	Id            string
+	Version       int
+	Ranges        []aggregate.RangeSpec
+	IfRange       aggregate.RangeCondition
+	Preconditions aggregate.Preconditions
 }
@@ -0,0 +1,10 @@
+package getfiledownloadurl
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IGetFileDownloadURLUseCase interface {
+	Execute(ctx context.Context, query GetFileDownloadURLQuery) (aggregate.PresignedDownload, error)
+}
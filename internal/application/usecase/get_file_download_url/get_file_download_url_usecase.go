@@ -0,0 +1,80 @@
+package getfiledownloadurl
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/get_file_download_url/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	"time"
+)
+
+type GetFileDownloadURLUseCase struct {
+	repository       port.FileRepository
+	storage          port.Storage
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+	urlExpiry        time.Duration
+}
+
+func NewGetFileDownloadURLUseCase(repository port.FileRepository, storage port.Storage, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient, urlExpiry time.Duration) *GetFileDownloadURLUseCase {
+	return &GetFileDownloadURLUseCase{
+		repository:       repository,
+		storage:          storage,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+		urlExpiry:        urlExpiry,
+	}
+}
+
+func (uc *GetFileDownloadURLUseCase) Execute(ctx context.Context, query GetFileDownloadURLQuery) (aggregate.PresignedDownload, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.PresignedDownload{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.PresignedDownload{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, query.Id)
+	if err != nil {
+		return aggregate.PresignedDownload{}, err
+	}
+	if file.Status() != domain.StatusAvailable {
+		return aggregate.PresignedDownload{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+
+	allowed, accessError := policy.CanReadFile(uc.membershipClient, token.(string), *profileId, file)
+	if accessError != nil {
+		return aggregate.PresignedDownload{}, accessError
+	}
+	if !allowed {
+		return aggregate.PresignedDownload{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	if query.Version != 0 {
+		file, err = file.AtVersion(query.Version)
+		if err != nil {
+			return aggregate.PresignedDownload{}, err
+		}
+	}
+
+	expiresAt := time.Now().Add(uc.urlExpiry)
+	downloadURL, err := uc.storage.PresignDownload(ctx, file.StorageKey(), file.FileName(), file.MimeType(), uc.urlExpiry)
+	if err != nil {
+		return aggregate.PresignedDownload{}, err
+	}
+
+	return aggregate.PresignedDownload{
+		File:      file,
+		URL:       downloadURL,
+		ExpiresAt: expiresAt,
+	}, nil
+}
@@ -0,0 +1,161 @@
+package getfiledownloadurl
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	GetFileFn func(ctx context.Context, id string) (domain.File, error)
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.GetFileFn(ctx, id)
+}
+
+type StorageMock struct {
+	PresignDownloadFn func(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error)
+}
+
+func (m *StorageMock) PresignDownload(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+	return m.PresignDownloadFn(ctx, storageKey, fileName, mimeType, expiry)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func validAuthClient() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func repoReturning(visibility domain.Visibility, status domain.Status) *FileRepositoryMock {
+	return &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.RehydrateFile(id, "owner 1", nil, "report.pdf", "application/pdf", 12, "owner 1/1/report.pdf", visibility, status, time.Now())
+		},
+	}
+}
+
+func TestGetFileDownloadURLUseCase_ShouldPresignReadableFile(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	storage := &StorageMock{
+		PresignDownloadFn: func(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+			assert.Equal(t, "owner 1/1/report.pdf", storageKey)
+			assert.Equal(t, "report.pdf", fileName)
+			assert.Equal(t, "application/pdf", mimeType)
+			assert.Equal(t, time.Minute, expiry)
+			return "http://minio/signed", nil
+		},
+	}
+	uc := NewGetFileDownloadURLUseCase(repoReturning(domain.VisibilityPublic, domain.StatusAvailable), storage, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	result, err := uc.Execute(ctx, GetFileDownloadURLQuery{Id: "1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://minio/signed", result.URL)
+	assert.Equal(t, "1", result.File.ID())
+	assert.WithinDuration(t, time.Now().Add(time.Minute), result.ExpiresAt, 5*time.Second)
+}
+
+func TestGetFileDownloadURLUseCase_ShouldPresignRequestedVersion(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	repo := &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			file, err := domain.RehydrateFile(id, "12", nil, "text.txt", "text/plain", 2, "key/v1", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+			if err != nil {
+				return domain.File{}, err
+			}
+			return file, file.AddVersion("key/v2", "text/plain", 3, "")
+		},
+	}
+	var presignedKey string
+	storage := &StorageMock{
+		PresignDownloadFn: func(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+			presignedKey = storageKey
+			return "http://minio/signed", nil
+		},
+	}
+	uc := NewGetFileDownloadURLUseCase(repo, storage, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	result, err := uc.Execute(ctx, GetFileDownloadURLQuery{Id: "1", Version: 1})
+
+	require.NoError(t, err)
+	assert.Equal(t, "key/v1", presignedKey)
+	assert.Equal(t, 1, result.File.Version())
+}
+
+func TestGetFileDownloadURLUseCase_ShouldRejectUnauthorizedReader(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	storage := &StorageMock{
+		PresignDownloadFn: func(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+			t.Fatal("url must not be presigned for unauthorized readers")
+			return "", nil
+		},
+	}
+	uc := NewGetFileDownloadURLUseCase(repoReturning(domain.VisibilityPrivate, domain.StatusAvailable), storage, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	_, err := uc.Execute(ctx, GetFileDownloadURLQuery{Id: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestGetFileDownloadURLUseCase_ShouldFailForUnavailableFile(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewGetFileDownloadURLUseCase(repoReturning(domain.VisibilityPublic, domain.StatusPending), &StorageMock{}, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	_, err := uc.Execute(ctx, GetFileDownloadURLQuery{Id: "1"})
+
+	assert.EqualError(t, err, "file not found")
+}
+
+func TestGetFileDownloadURLUseCase_ShouldFailWithoutToken(t *testing.T) {
+	uc := NewGetFileDownloadURLUseCase(&FileRepositoryMock{}, &StorageMock{}, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	_, err := uc.Execute(context.Background(), GetFileDownloadURLQuery{Id: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestGetFileDownloadURLUseCase_ShouldReturnStorageError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	storage := &StorageMock{
+		PresignDownloadFn: func(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+			return "", errors.New("presign error")
+		},
+	}
+	uc := NewGetFileDownloadURLUseCase(repoReturning(domain.VisibilityPublic, domain.StatusAvailable), storage, validAuthClient(), &MembershipClientMock{}, time.Minute)
+
+	_, err := uc.Execute(ctx, GetFileDownloadURLQuery{Id: "1"})
+
+	assert.EqualError(t, err, "presign error")
+}
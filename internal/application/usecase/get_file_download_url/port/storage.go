@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"time"
+)
+
+type Storage interface {
+	PresignDownload(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error)
+}
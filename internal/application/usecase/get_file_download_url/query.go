@@ -0,0 +1,6 @@
+package getfiledownloadurl
+
+type GetFileDownloadURLQuery struct {
+	Id      string
+	Version int
+}
@@ -0,0 +1,10 @@
+package getfilemetadata
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IGetFileMetadataUseCase interface {
+	Execute(ctx context.Context, query GetFileMetadataQuery) (domain.File, error)
+}
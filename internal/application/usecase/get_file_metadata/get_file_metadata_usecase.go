@@ -0,0 +1,63 @@
+package getfilemetadata
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/get_file_metadata/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+)
+
+type GetFileMetadataUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewGetFileMetadataUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *GetFileMetadataUseCase {
+	return &GetFileMetadataUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *GetFileMetadataUseCase) Execute(ctx context.Context, query GetFileMetadataQuery) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, repositoryError := uc.repository.GetFile(ctx, query.Id)
+	if repositoryError != nil {
+		return domain.File{}, repositoryError
+	}
+	if file.Status() != domain.StatusAvailable {
+		return domain.File{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+	allowed, accessError := policy.CanReadFile(uc.membershipClient, token.(string), *profileId, file)
+	if accessError != nil {
+		return domain.File{}, accessError
+	}
+	if !allowed {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	validators := aggregate.NewMetadataValidators(file)
+	if query.Content {
+		validators = aggregate.NewValidators(file, file.Checksum())
+	}
+	if query.Preconditions.NotModified(validators) {
+		return file, aggregate.ErrNotModified
+	}
+	return file, nil
+}
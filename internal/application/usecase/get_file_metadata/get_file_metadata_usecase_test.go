@@ -0,0 +1,148 @@
+package getfilemetadata
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type MockRepositoryPort struct {
+	mock func(ctx context.Context, id string) (domain.File, error)
+}
+
+func (repo *MockRepositoryPort) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return repo.mock(ctx, id)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func profileAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func fileRepository(visibility domain.Visibility, status domain.Status, options ...domain.RehydrateOption) *MockRepositoryPort {
+	return &MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.RehydrateFile(id, "owner 1", nil, "text.txt", "text/plain", 12, "key", visibility, status, time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC), options...)
+	}}
+}
+
+func TestGetFileMetadataUseCase_ShouldReturnMetadata(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPublic, domain.StatusAvailable), profileAuth(), &MembershipClientMock{})
+
+	file, err := usecase.Execute(ctx, GetFileMetadataQuery{Id: "1"})
+	require.NoError(t, err)
+	assert.Equal(t, "1", file.ID())
+	assert.Equal(t, int64(12), file.Size())
+}
+
+func TestGetFileMetadataUseCase_ShouldReturnNotModifiedForMatchingChecksum(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	checksum := strings.Repeat("a", 64)
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPublic, domain.StatusAvailable, domain.WithChecksum(checksum)), profileAuth(), &MembershipClientMock{})
+
+	file, err := usecase.Execute(ctx, GetFileMetadataQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{checksum}},
+		Content:       true,
+	})
+	require.ErrorIs(t, err, aggregate.ErrNotModified)
+	assert.Equal(t, "1", file.ID())
+}
+
+func TestGetFileMetadataUseCase_ShouldValidateMetadataByRevision(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	checksum := strings.Repeat("a", 64)
+	updatedAt := time.Date(2024, 3, 2, 8, 0, 0, 0, time.UTC)
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPublic, domain.StatusAvailable, domain.WithChecksum(checksum), domain.WithRevision(3, updatedAt)), profileAuth(), &MembershipClientMock{})
+
+	_, err := usecase.Execute(ctx, GetFileMetadataQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{checksum}},
+	})
+	require.NoError(t, err)
+
+	_, err = usecase.Execute(ctx, GetFileMetadataQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{"1-r2"}},
+	})
+	require.NoError(t, err)
+
+	_, err = usecase.Execute(ctx, GetFileMetadataQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{"1-r3"}},
+	})
+	require.ErrorIs(t, err, aggregate.ErrNotModified)
+
+	_, err = usecase.Execute(ctx, GetFileMetadataQuery{
+		Id:            "1",
+		Preconditions: aggregate.Preconditions{IfModifiedSince: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+}
+
+func TestGetFileMetadataUseCase_ShouldFailWithDifferentOwnerAndPrivate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPrivate, domain.StatusAvailable), profileAuth(), &MembershipClientMock{})
+
+	_, err := usecase.Execute(ctx, GetFileMetadataQuery{Id: "1"})
+	require.EqualError(t, err, "unauthorized")
+}
+
+func TestGetFileMetadataUseCase_ShouldFailWhenFileIsInTrash(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPublic, domain.StatusDeleted, domain.WithDeletedAt(time.Now())), profileAuth(), &MembershipClientMock{})
+
+	_, err := usecase.Execute(ctx, GetFileMetadataQuery{Id: "1"})
+	require.EqualError(t, err, "file not found")
+}
+
+func TestGetFileMetadataUseCase_ShouldFailWithoutToken(t *testing.T) {
+	usecase := NewGetFileMetadataUseCase(fileRepository(domain.VisibilityPublic, domain.StatusAvailable), profileAuth(), &MembershipClientMock{})
+
+	_, err := usecase.Execute(context.Background(), GetFileMetadataQuery{Id: "1"})
+	require.EqualError(t, err, "token cannot be null")
+}
+
+func TestGetFileMetadataUseCase_ShouldReturnErrorWhenRepositoryFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	repository := &MockRepositoryPort{mock: func(ctx context.Context, id string) (domain.File, error) {
+		return domain.File{}, errors.New("database down")
+	}}
+	usecase := NewGetFileMetadataUseCase(repository, profileAuth(), &MembershipClientMock{})
+
+	_, err := usecase.Execute(ctx, GetFileMetadataQuery{Id: "1"})
+	require.EqualError(t, err, "database down")
+}
@@ -0,0 +1,11 @@
+package getfilemetadata
+
+import "devconnectstorage/internal/application/aggregate"
+
+type GetFileMetadataQuery struct {
+	Id            string
+	Preconditions aggregate.Preconditions
+	// Content evaluates Preconditions against the file's content, as a HEAD
+	// on the content URL does, instead of its metadata.
+	Content bool
+}
@@ -0,0 +1,10 @@
+package getprojecttree
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IGetProjectTreeUseCase interface {
+	Execute(ctx context.Context, query GetProjectTreeQuery) (aggregate.Folder, error)
+}
@@ -0,0 +1,82 @@
+package getprojecttree
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/usecase/get_project_tree/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+type GetProjectTreeUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewGetProjectTreeUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *GetProjectTreeUseCase {
+	return &GetProjectTreeUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *GetProjectTreeUseCase) Execute(ctx context.Context, query GetProjectTreeQuery) (aggregate.Folder, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.Folder{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.Folder{}, authError
+	}
+
+	if query.ProjectID == "" {
+		return aggregate.Folder{}, apperror.New(apperror.ErrValidation, "projectID cannot be empty")
+	}
+	prefix := ""
+	if strings.Trim(strings.TrimSpace(query.Prefix), "/") != "" {
+		normalized, err := domain.NormalizePath(query.Prefix)
+		if err != nil {
+			return aggregate.Folder{}, err
+		}
+		prefix = normalized
+	}
+
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultPageSize
+	}
+	if limit < 0 || limit > maxPageSize {
+		return aggregate.Folder{}, apperror.New(apperror.ErrValidation, "limit must be between 1 and %d", maxPageSize)
+	}
+
+	listing := aggregate.FolderListing{
+		ProjectID: query.ProjectID,
+		Prefix:    prefix,
+		Reader:    aggregate.Reader{ProfileID: strconv.FormatInt(*profileId, 10)},
+		After:     query.Cursor,
+		Limit:     limit,
+	}
+	member, err := uc.membershipClient.IsMember(token.(string), query.ProjectID, *profileId)
+	if err != nil {
+		return aggregate.Folder{}, err
+	}
+	if member {
+		listing.Reader.MemberOf = []string{query.ProjectID}
+	}
+	return uc.repository.ListFolder(ctx, listing)
+}
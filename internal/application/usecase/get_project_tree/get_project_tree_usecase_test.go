@@ -0,0 +1,134 @@
+package getprojecttree
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	ListFolderFn func(ctx context.Context, listing aggregate.FolderListing) (aggregate.Folder, error)
+}
+
+func (m *FileRepositoryMock) ListFolder(ctx context.Context, listing aggregate.FolderListing) (aggregate.Folder, error) {
+	return m.ListFolderFn(ctx, listing)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func membership(member bool) *MembershipClientMock {
+	return &MembershipClientMock{
+		IsMemberFn: func(token string, projectID string, profileID int64) (bool, error) {
+			return member, nil
+		},
+	}
+}
+
+func TestGetProjectTreeUseCase_ShouldListNormalizedPrefixForMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FolderListing
+	repo := &FileRepositoryMock{
+		ListFolderFn: func(ctx context.Context, requested aggregate.FolderListing) (aggregate.Folder, error) {
+			listing = requested
+			return aggregate.Folder{Prefix: requested.Prefix, Folders: []string{"img"}}, nil
+		},
+	}
+
+	folder, err := NewGetProjectTreeUseCase(repo, validAuth(), membership(true)).Execute(ctx, GetProjectTreeQuery{ProjectID: "project-1", Prefix: "/docs/"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"img"}, folder.Folders)
+	assert.Equal(t, "docs", listing.Prefix)
+	assert.Equal(t, "12", listing.Reader.ProfileID)
+	assert.Equal(t, []string{"project-1"}, listing.Reader.MemberOf)
+	assert.Equal(t, int64(defaultPageSize), listing.Limit)
+}
+
+func TestGetProjectTreeUseCase_ShouldContinueFromCursor(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FolderListing
+	repo := &FileRepositoryMock{
+		ListFolderFn: func(ctx context.Context, requested aggregate.FolderListing) (aggregate.Folder, error) {
+			listing = requested
+			return aggregate.Folder{}, nil
+		},
+	}
+
+	_, err := NewGetProjectTreeUseCase(repo, validAuth(), membership(true)).Execute(ctx, GetProjectTreeQuery{ProjectID: "project-1", Cursor: "img", Limit: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, "img", listing.After)
+	assert.Equal(t, int64(10), listing.Limit)
+}
+
+func TestGetProjectTreeUseCase_ShouldRejectOversizedPages(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewGetProjectTreeUseCase(&FileRepositoryMock{}, validAuth(), membership(true)).Execute(ctx, GetProjectTreeQuery{ProjectID: "project-1", Limit: maxPageSize + 1})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestGetProjectTreeUseCase_ShouldListRootForNonMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FolderListing
+	repo := &FileRepositoryMock{
+		ListFolderFn: func(ctx context.Context, requested aggregate.FolderListing) (aggregate.Folder, error) {
+			listing = requested
+			return aggregate.Folder{}, nil
+		},
+	}
+
+	_, err := NewGetProjectTreeUseCase(repo, validAuth(), membership(false)).Execute(ctx, GetProjectTreeQuery{ProjectID: "project-1", Prefix: "/"})
+
+	require.NoError(t, err)
+	assert.Empty(t, listing.Prefix)
+	assert.Empty(t, listing.Reader.MemberOf)
+}
+
+func TestGetProjectTreeUseCase_ShouldRejectInvalidPrefix(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewGetProjectTreeUseCase(&FileRepositoryMock{}, validAuth(), membership(true)).Execute(ctx, GetProjectTreeQuery{ProjectID: "project-1", Prefix: "docs/../secrets"})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestGetProjectTreeUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewGetProjectTreeUseCase(&FileRepositoryMock{}, validAuth(), membership(true)).Execute(context.Background(), GetProjectTreeQuery{ProjectID: "project-1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
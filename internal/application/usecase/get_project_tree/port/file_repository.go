@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type FileRepository interface {
+	ListFolder(ctx context.Context, listing aggregate.FolderListing) (aggregate.Folder, error)
+}
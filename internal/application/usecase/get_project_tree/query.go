@@ -0,0 +1,11 @@
+package getprojecttree
+
+// GetProjectTreeQuery lists the folders and files directly below Prefix; an
+// empty Prefix lists the project's root. Cursor continues from a previous
+// page and Limit caps how many folders and files a page holds.
+type GetProjectTreeQuery struct {
+	ProjectID string
+	Prefix    string
+	Cursor    string
+	Limit     int64
+}
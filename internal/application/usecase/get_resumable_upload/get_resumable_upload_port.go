@@ -0,0 +1,10 @@
+package getresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IGetResumableUploadUseCase interface {
+	Execute(ctx context.Context, query GetResumableUploadQuery) (domain.UploadSession, error)
+}
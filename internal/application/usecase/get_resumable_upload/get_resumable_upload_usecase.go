@@ -0,0 +1,45 @@
+package getresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/get_resumable_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type GetResumableUploadUseCase struct {
+	sessionRepository port.UploadSessionRepository
+	authClient        auth.IAuthClient
+}
+
+func NewGetResumableUploadUseCase(sessionRepository port.UploadSessionRepository, authClient auth.IAuthClient) *GetResumableUploadUseCase {
+	return &GetResumableUploadUseCase{
+		sessionRepository: sessionRepository,
+		authClient:        authClient,
+	}
+}
+
+func (uc *GetResumableUploadUseCase) Execute(ctx context.Context, query GetResumableUploadQuery) (domain.UploadSession, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.UploadSession{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.UploadSession{}, authError
+	}
+
+	session, err := uc.sessionRepository.GetUploadSession(ctx, query.Id)
+	if err != nil {
+		return domain.UploadSession{}, err
+	}
+	if session.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.UploadSession{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	return session, nil
+}
@@ -0,0 +1,86 @@
+package getresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type UploadSessionRepositoryMock struct {
+	GetUploadSessionFn func(ctx context.Context, id string) (domain.UploadSession, error)
+}
+
+func (m *UploadSessionRepositoryMock) GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error) {
+	return m.GetUploadSessionFn(ctx, id)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func authClientFor(profileID int64) *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return &profileID, nil
+		},
+	}
+}
+
+func sessionRepository() *UploadSessionRepositoryMock {
+	return &UploadSessionRepositoryMock{
+		GetUploadSessionFn: func(ctx context.Context, id string) (domain.UploadSession, error) {
+			return domain.RehydrateUploadSession(id, "12", "multipart-1", 10, nil, 1, 4, time.Now(), time.Now().Add(time.Hour))
+		},
+	}
+}
+
+func TestGetResumableUploadUseCase_ShouldReturnOwnSession(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewGetResumableUploadUseCase(sessionRepository(), authClientFor(12))
+
+	session, err := uc.Execute(ctx, GetResumableUploadQuery{Id: "1"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), session.Offset())
+	assert.Equal(t, int64(10), session.Length())
+}
+
+func TestGetResumableUploadUseCase_ShouldRejectOtherProfiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewGetResumableUploadUseCase(sessionRepository(), authClientFor(99))
+
+	_, err := uc.Execute(ctx, GetResumableUploadQuery{Id: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestGetResumableUploadUseCase_ShouldFailWithoutToken(t *testing.T) {
+	uc := NewGetResumableUploadUseCase(sessionRepository(), authClientFor(12))
+
+	_, err := uc.Execute(context.Background(), GetResumableUploadQuery{Id: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestGetResumableUploadUseCase_ShouldReturnNotFound(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	repo := &UploadSessionRepositoryMock{
+		GetUploadSessionFn: func(ctx context.Context, id string) (domain.UploadSession, error) {
+			return domain.UploadSession{}, domain.ErrUploadSessionNotFound
+		},
+	}
+	uc := NewGetResumableUploadUseCase(repo, authClientFor(12))
+
+	_, err := uc.Execute(ctx, GetResumableUploadQuery{Id: "1"})
+
+	assert.ErrorIs(t, err, domain.ErrUploadSessionNotFound)
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type UploadSessionRepository interface {
+	GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error)
+}
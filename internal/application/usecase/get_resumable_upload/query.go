@@ -0,0 +1,5 @@
+package getresumableupload
+
+type GetResumableUploadQuery struct {
+	Id string
+}
@@ -0,0 +1,10 @@
+package getstorageusage
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IGetStorageUsageUseCase interface {
+	Execute(ctx context.Context) (aggregate.StorageReport, error)
+}
@@ -0,0 +1,46 @@
+package getstorageusage
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/get_storage_usage/port"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type GetStorageUsageUseCase struct {
+	usage      port.StorageUsageRepository
+	authClient auth.IAuthClient
+	quota      policy.StorageQuota
+}
+
+func NewGetStorageUsageUseCase(usage port.StorageUsageRepository, authClient auth.IAuthClient, quota policy.StorageQuota) *GetStorageUsageUseCase {
+	return &GetStorageUsageUseCase{
+		usage:      usage,
+		authClient: authClient,
+		quota:      quota,
+	}
+}
+
+func (uc *GetStorageUsageUseCase) Execute(ctx context.Context) (aggregate.StorageReport, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.StorageReport{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.StorageReport{}, authError
+	}
+
+	ownerID := strconv.FormatInt(*profileId, 10)
+	usage, err := uc.usage.GetUsage(ctx, ownerID)
+	if err != nil {
+		return aggregate.StorageReport{}, err
+	}
+	return aggregate.StorageReport{Usage: usage, Limit: uc.quota.LimitFor(ownerID)}, nil
+}
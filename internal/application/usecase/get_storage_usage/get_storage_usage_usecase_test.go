@@ -0,0 +1,77 @@
+package getstorageusage
+
+import (
+	"context"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type StorageUsageRepositoryMock struct {
+	GetUsageFn func(ctx context.Context, ownerID string) (domain.StorageUsage, error)
+}
+
+func (m *StorageUsageRepositoryMock) GetUsage(ctx context.Context, ownerID string) (domain.StorageUsage, error) {
+	return m.GetUsageFn(ctx, ownerID)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func TestGetStorageUsageUseCase_ShouldReportCallerUsageAndLimit(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requestedOwner string
+	usage := &StorageUsageRepositoryMock{
+		GetUsageFn: func(ctx context.Context, ownerID string) (domain.StorageUsage, error) {
+			requestedOwner = ownerID
+			return domain.RehydrateStorageUsage(ownerID, 40, 2)
+		},
+	}
+	quota := policy.StorageQuota{DefaultLimit: 100, ProfileLimits: map[string]int64{"12": 500}}
+
+	report, err := NewGetStorageUsageUseCase(usage, validAuth(), quota).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "12", requestedOwner)
+	assert.Equal(t, int64(40), report.Usage.UsedBytes())
+	assert.Equal(t, int64(2), report.Usage.FileCount())
+	assert.Equal(t, int64(500), report.Limit)
+}
+
+func TestGetStorageUsageUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewGetStorageUsageUseCase(&StorageUsageRepositoryMock{}, validAuth(), policy.StorageQuota{}).Execute(context.Background())
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestGetStorageUsageUseCase_ShouldReturnRepositoryError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	usage := &StorageUsageRepositoryMock{
+		GetUsageFn: func(ctx context.Context, ownerID string) (domain.StorageUsage, error) {
+			return domain.StorageUsage{}, errors.New("mongo down")
+		},
+	}
+
+	_, err := NewGetStorageUsageUseCase(usage, validAuth(), policy.StorageQuota{}).Execute(ctx)
+
+	assert.EqualError(t, err, "mongo down")
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type StorageUsageRepository interface {
+	GetUsage(ctx context.Context, ownerID string) (domain.StorageUsage, error)
+}
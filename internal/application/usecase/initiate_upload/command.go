@@ -0,0 +1,9 @@
+package initiateupload
+
+type InitiateUploadCommand struct {
+	ProjectID  *string
+	FileName   string
+	MimeType   string
+	Size       int64
+	Visibility string
+}
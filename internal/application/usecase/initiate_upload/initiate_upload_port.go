@@ -0,0 +1,10 @@
+package initiateupload
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IInitiateUploadUseCase interface {
+	Execute(ctx context.Context, command InitiateUploadCommand) (aggregate.PresignedUpload, error)
+}
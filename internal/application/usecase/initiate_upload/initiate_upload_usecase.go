@@ -0,0 +1,91 @@
+package initiateupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/initiate_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strconv"
+	"time"
+)
+
+type InitiateUploadUseCase struct {
+	fileRepository port.FileRepository
+	storage        port.Storage
+	generator      port.IdGenerator
+	authClient     auth.IAuthClient
+	uploadPolicy   policy.UploadPolicy
+	uploadExpiry   time.Duration
+}
+
+func NewInitiateUploadUseCase(repo port.FileRepository, storage port.Storage, generator port.IdGenerator, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, uploadExpiry time.Duration) *InitiateUploadUseCase {
+	return &InitiateUploadUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      generator,
+		authClient:     authClient,
+		uploadPolicy:   uploadPolicy,
+		uploadExpiry:   uploadExpiry,
+	}
+}
+
+func (uc *InitiateUploadUseCase) Execute(ctx context.Context, command InitiateUploadCommand) (aggregate.PresignedUpload, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.PresignedUpload{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.PresignedUpload{}, authError
+	}
+
+	if command.Size <= 0 {
+		return aggregate.PresignedUpload{}, apperror.New(apperror.ErrValidation, "size must be positive")
+	}
+
+	if err := uc.uploadPolicy.Check(policy.UploadCandidate{
+		ProjectID:  command.ProjectID,
+		FileName:   command.FileName,
+		MimeType:   command.MimeType,
+		Size:       command.Size,
+		Visibility: domain.Visibility(command.Visibility),
+	}); err != nil {
+		return aggregate.PresignedUpload{}, err
+	}
+
+	file, domainErr := domain.NewFile(uc.generator.Generate(), strconv.FormatInt(*profileId, 10), command.ProjectID, command.FileName, command.MimeType, command.Size, domain.Visibility(command.Visibility))
+	if domainErr != nil {
+		return aggregate.PresignedUpload{}, domainErr
+	}
+
+	file, saveError := uc.fileRepository.Save(ctx, file)
+	if saveError != nil {
+		return aggregate.PresignedUpload{}, saveError
+	}
+
+	expiresAt := time.Now().Add(uc.uploadExpiry)
+	uploadURL, presignErr := uc.storage.PresignUpload(ctx, file, uc.uploadExpiry)
+	if presignErr != nil {
+		if err := file.MarkAsFailed(); err != nil {
+			return aggregate.PresignedUpload{}, errors.Join(presignErr, err)
+		}
+		if err := uc.fileRepository.Update(ctx, file); err != nil {
+			return aggregate.PresignedUpload{}, errors.Join(presignErr, err)
+		}
+		return aggregate.PresignedUpload{}, presignErr
+	}
+
+	return aggregate.PresignedUpload{
+		File:      file,
+		URL:       uploadURL,
+		Headers:   map[string]string{"Content-Type": file.MimeType()},
+		ExpiresAt: expiresAt,
+	}, nil
+}
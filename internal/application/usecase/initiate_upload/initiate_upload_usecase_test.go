@@ -0,0 +1,188 @@
+package initiateupload
+
+import (
+	"context"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FileRepositoryMock struct {
+	SaveFn   func(ctx context.Context, file domain.File) (domain.File, error)
+	UpdateFn func(ctx context.Context, file domain.File) error
+}
+
+func (m *FileRepositoryMock) Save(ctx context.Context, file domain.File) (domain.File, error) {
+	return m.SaveFn(ctx, file)
+}
+
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	return m.UpdateFn(ctx, file)
+}
+
+type StorageMock struct {
+	PresignUploadFn func(ctx context.Context, file domain.File, expiry time.Duration) (string, error)
+}
+
+func (m *StorageMock) PresignUpload(ctx context.Context, file domain.File, expiry time.Duration) (string, error) {
+	return m.PresignUploadFn(ctx, file, expiry)
+}
+
+type IdGeneratorMock struct{}
+
+func (gen *IdGeneratorMock) Generate() string {
+	return "1"
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuthClient() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func validCommand() InitiateUploadCommand {
+	return InitiateUploadCommand{
+		FileName:   "video.mp4",
+		MimeType:   "video/mp4",
+		Size:       1024,
+		Visibility: "PUBLIC",
+	}
+}
+
+func TestInitiateUploadUseCase_Success(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var savedStatus domain.Status
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			savedStatus = file.Status()
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			t.Fatal("update should not be called")
+			return nil
+		},
+	}
+	storage := &StorageMock{
+		PresignUploadFn: func(ctx context.Context, file domain.File, expiry time.Duration) (string, error) {
+			assert.Equal(t, 15*time.Minute, expiry)
+			return "http://minio/bucket/12/1/video.mp4?X-Amz-Signature=abc", nil
+		},
+	}
+
+	uc := NewInitiateUploadUseCase(repo, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, 15*time.Minute)
+	result, err := uc.Execute(ctx, validCommand())
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusPending, savedStatus)
+	assert.Equal(t, "1", result.File.ID())
+	assert.Equal(t, "12", result.File.OwnerID())
+	assert.Equal(t, domain.StatusPending, result.File.Status())
+	assert.Equal(t, "http://minio/bucket/12/1/video.mp4?X-Amz-Signature=abc", result.URL)
+	assert.Equal(t, "video/mp4", result.Headers["Content-Type"])
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), result.ExpiresAt, 5*time.Second)
+}
+
+func TestInitiateUploadUseCase_NoToken(t *testing.T) {
+	uc := NewInitiateUploadUseCase(&FileRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, time.Minute)
+
+	_, err := uc.Execute(context.Background(), validCommand())
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestInitiateUploadUseCase_AuthError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	authClient := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return nil, errors.New("invalid token")
+		},
+	}
+	uc := NewInitiateUploadUseCase(&FileRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, authClient, policy.UploadPolicy{}, time.Minute)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.EqualError(t, err, "invalid token")
+}
+
+func TestInitiateUploadUseCase_InvalidSize(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewInitiateUploadUseCase(&FileRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, time.Minute)
+	command := validCommand()
+	command.Size = 0
+
+	_, err := uc.Execute(ctx, command)
+
+	assert.EqualError(t, err, "size must be positive")
+}
+
+func TestInitiateUploadUseCase_InvalidFile(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	uc := NewInitiateUploadUseCase(&FileRepositoryMock{}, &StorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, time.Minute)
+	command := validCommand()
+	command.Visibility = "SECRET"
+
+	_, err := uc.Execute(ctx, command)
+
+	assert.Error(t, err)
+}
+
+func TestInitiateUploadUseCase_SaveError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return domain.File{}, errors.New("db error")
+		},
+	}
+	storage := &StorageMock{
+		PresignUploadFn: func(ctx context.Context, file domain.File, expiry time.Duration) (string, error) {
+			t.Fatal("presign should not be called")
+			return "", nil
+		},
+	}
+	uc := NewInitiateUploadUseCase(repo, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, time.Minute)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.EqualError(t, err, "db error")
+}
+
+func TestInitiateUploadUseCase_PresignErrorMarksFileAsFailed(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var updatedStatus domain.Status
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatus = file.Status()
+			return nil
+		},
+	}
+	storage := &StorageMock{
+		PresignUploadFn: func(ctx context.Context, file domain.File, expiry time.Duration) (string, error) {
+			return "", errors.New("presign error")
+		},
+	}
+	uc := NewInitiateUploadUseCase(repo, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, time.Minute)
+
+	_, err := uc.Execute(ctx, validCommand())
+
+	assert.EqualError(t, err, "presign error")
+	assert.Equal(t, domain.StatusFailed, updatedStatus)
+}
@@ -0,0 +1,5 @@
+package port
+
+type IdGenerator interface {
+	Generate() string
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type Storage interface {
+	PresignUpload(ctx context.Context, file domain.File, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,10 @@
+package listfileversions
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IListFileVersionsUseCase interface {
+	Execute(ctx context.Context, query ListFileVersionsQuery) (domain.File, error)
+}
@@ -0,0 +1,56 @@
+package listfileversions
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/list_file_versions/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+)
+
+type ListFileVersionsUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewListFileVersionsUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *ListFileVersionsUseCase {
+	return &ListFileVersionsUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *ListFileVersionsUseCase) Execute(ctx context.Context, query ListFileVersionsQuery) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, query.FileId)
+	if err != nil {
+		return domain.File{}, err
+	}
+	if file.Status() != domain.StatusAvailable {
+		return domain.File{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+	allowed, err := policy.CanReadFile(uc.membershipClient, token.(string), *profileId, file)
+	if err != nil {
+		return domain.File{}, err
+	}
+	if !allowed {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	return file, nil
+}
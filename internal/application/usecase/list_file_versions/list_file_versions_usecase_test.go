@@ -0,0 +1,130 @@
+package listfileversions
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	GetFileFn func(ctx context.Context, id string) (domain.File, error)
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.GetFileFn(ctx, id)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func authAs(id int64) *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return &id, nil
+		},
+	}
+}
+
+func repositoryWith(visibility domain.Visibility) *FileRepositoryMock {
+	return &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			file, err := domain.RehydrateFile(id, "12", nil, "a.txt", "text/plain", 1, "key/v1", visibility, domain.StatusAvailable, time.Now())
+			if err != nil {
+				return domain.File{}, err
+			}
+			err = file.AddVersion("key/v2", "text/plain", 2, "")
+			return file, err
+		},
+	}
+}
+
+func TestListFileVersionsUseCase_ShouldReturnHistoryToOwner(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	file, err := NewListFileVersionsUseCase(repositoryWith(domain.VisibilityPrivate), authAs(12), &MembershipClientMock{}).Execute(ctx, ListFileVersionsQuery{FileId: "1"})
+
+	require.NoError(t, err)
+	assert.Len(t, file.Versions(), 2)
+	assert.Equal(t, 2, file.Version())
+}
+
+func TestListFileVersionsUseCase_ShouldReturnHistoryOfPublicFile(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	file, err := NewListFileVersionsUseCase(repositoryWith(domain.VisibilityPublic), authAs(99), &MembershipClientMock{}).Execute(ctx, ListFileVersionsQuery{FileId: "1"})
+
+	require.NoError(t, err)
+	assert.Len(t, file.Versions(), 2)
+}
+
+func TestListFileVersionsUseCase_ShouldFailForPrivateFileOfOtherOwner(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewListFileVersionsUseCase(repositoryWith(domain.VisibilityPrivate), authAs(99), &MembershipClientMock{}).Execute(ctx, ListFileVersionsQuery{FileId: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestListFileVersionsUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewListFileVersionsUseCase(repositoryWith(domain.VisibilityPublic), authAs(12), &MembershipClientMock{}).Execute(context.Background(), ListFileVersionsQuery{FileId: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestListFileVersionsUseCase_ShouldReturnRepositoryError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	repo := &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.File{}, errors.New("db error")
+		},
+	}
+
+	_, err := NewListFileVersionsUseCase(repo, authAs(12), &MembershipClientMock{}).Execute(ctx, ListFileVersionsQuery{FileId: "1"})
+
+	assert.EqualError(t, err, "db error")
+}
+
+func TestListFileVersionsUseCase_ShouldReturnHistoryToProjectMember(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	repo := &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.RehydrateFile(id, "12", &projectID, "a.txt", "text/plain", 1, "key/v1", domain.VisibilityProject, domain.StatusAvailable, time.Now())
+		},
+	}
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, pid string, profileID int64) (bool, error) {
+			return profileID == 99, nil
+		},
+	}
+
+	file, err := NewListFileVersionsUseCase(repo, authAs(99), membership).Execute(ctx, ListFileVersionsQuery{FileId: "1"})
+
+	require.NoError(t, err)
+	assert.Len(t, file.Versions(), 1)
+}
@@ -0,0 +1,5 @@
+package listfileversions
+
+type ListFileVersionsQuery struct {
+	FileId string
+}
@@ -0,0 +1,10 @@
+package listfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IListFilesUseCase interface {
+	Execute(ctx context.Context, query ListFilesQuery) (aggregate.FilePage, error)
+}
@@ -0,0 +1,156 @@
+package listfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/list_files/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	"strconv"
+)
+
+const (
+	defaultPageSize = 50
+	maxPageSize     = 100
+)
+
+type ListFilesUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewListFilesUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *ListFilesUseCase {
+	return &ListFilesUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *ListFilesUseCase) Execute(ctx context.Context, query ListFilesQuery) (aggregate.FilePage, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return aggregate.FilePage{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return aggregate.FilePage{}, authError
+	}
+
+	listing, err := newListing(query)
+	if err != nil {
+		return aggregate.FilePage{}, err
+	}
+
+	requesterID := strconv.FormatInt(*profileId, 10)
+	if listing.Filter.OwnerID == "" {
+		listing.Filter.OwnerID = requesterID
+	}
+	if listing.Filter.OwnerID != requesterID {
+		if listing.Filter.Status != domain.StatusAvailable {
+			return aggregate.FilePage{}, apperror.New(apperror.ErrForbidden, "only available files of other profiles can be listed")
+		}
+		reader, err := policy.ReaderFor(uc.membershipClient, token.(string), *profileId, query.ProjectID)
+		if err != nil {
+			return aggregate.FilePage{}, err
+		}
+		listing.Filter.ReadableBy = &reader
+	}
+
+	files, err := uc.repository.ListFiles(ctx, listing)
+	if err != nil {
+		return aggregate.FilePage{}, err
+	}
+	if int64(len(files)) < listing.Limit {
+		return aggregate.FilePage{Files: files}, nil
+	}
+
+	files = files[:listing.Limit-1]
+	next := aggregate.NewFileCursor(files[len(files)-1], listing.Sort, listing.Descending)
+	return aggregate.FilePage{Files: files, NextCursor: next.Encode()}, nil
+}
+
+// newListing validates query and asks for one file more than the page size,
+// which tells whether another page follows.
+func newListing(query ListFilesQuery) (aggregate.FileListing, error) {
+	listing := aggregate.FileListing{
+		Filter: aggregate.FileFilter{
+			OwnerID:     query.OwnerID,
+			ProjectID:   query.ProjectID,
+			Visibility:  domain.Visibility(query.Visibility),
+			Status:      domain.Status(query.Status),
+			MimePrefix:  query.MimePrefix,
+			Attributes:  query.Attributes,
+			CreatedFrom: query.CreatedFrom,
+			CreatedTo:   query.CreatedTo,
+		},
+		Sort:  aggregate.FileSort(query.Sort),
+		Limit: query.Limit,
+	}
+
+	if listing.Filter.Visibility != "" && !listing.Filter.Visibility.IsValid() {
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "invalid visibility %q", query.Visibility)
+	}
+	if listing.Filter.Status == "" {
+		listing.Filter.Status = domain.StatusAvailable
+	}
+	if !listing.Filter.Status.IsValid() {
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "invalid status %q", query.Status)
+	}
+	tags, err := domain.NormalizeTags(query.Tags)
+	if err != nil {
+		return aggregate.FileListing{}, err
+	}
+	if len(tags) > 0 {
+		listing.Filter.Tags = tags
+	}
+	if err := domain.ValidateAttributes(query.Attributes); err != nil {
+		return aggregate.FileListing{}, err
+	}
+	if !query.CreatedFrom.IsZero() && !query.CreatedTo.IsZero() && !query.CreatedFrom.Before(query.CreatedTo) {
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "created_from must be before created_to")
+	}
+
+	if listing.Sort == "" {
+		listing.Sort = aggregate.SortByCreatedAt
+	}
+	if !listing.Sort.IsValid() {
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "cannot sort by %q", query.Sort)
+	}
+	switch query.Order {
+	case "":
+		listing.Descending = listing.Sort == aggregate.SortByCreatedAt
+	case "asc":
+	case "desc":
+		listing.Descending = true
+	default:
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "order must be asc or desc")
+	}
+
+	if listing.Limit == 0 {
+		listing.Limit = defaultPageSize
+	}
+	if listing.Limit < 0 || listing.Limit > maxPageSize {
+		return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "limit must be between 1 and %d", maxPageSize)
+	}
+	listing.Limit++
+
+	if query.Cursor != "" {
+		cursor, err := aggregate.DecodeFileCursor(query.Cursor)
+		if err != nil {
+			return aggregate.FileListing{}, err
+		}
+		if cursor.Sort != listing.Sort || cursor.Descending != listing.Descending {
+			return aggregate.FileListing{}, apperror.New(apperror.ErrValidation, "cursor does not match the requested sort")
+		}
+		listing.After = &cursor
+	}
+	return listing, nil
+}
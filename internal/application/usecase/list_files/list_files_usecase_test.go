@@ -0,0 +1,224 @@
+package listfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	ListFilesFn func(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error)
+}
+
+func (m *FileRepositoryMock) ListFiles(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error) {
+	return m.ListFilesFn(ctx, listing)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func listedFiles(t *testing.T, count int) []domain.File {
+	files := make([]domain.File, 0, count)
+	for i := range count {
+		file, err := domain.RehydrateFile(fmt.Sprintf("file-%d", i), "12", nil, fmt.Sprintf("f%d.txt", i), "text/plain", int64(i), "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+	return files
+}
+
+func TestListFilesUseCase_ShouldListCallerFilesWithDefaults(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FileListing
+	repo := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return listedFiles(t, 2), nil
+		},
+	}
+
+	page, err := NewListFilesUseCase(repo, validAuth(), &MembershipClientMock{}).Execute(ctx, ListFilesQuery{})
+
+	require.NoError(t, err)
+	assert.Len(t, page.Files, 2)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, "12", listing.Filter.OwnerID)
+	assert.Equal(t, domain.StatusAvailable, listing.Filter.Status)
+	assert.Nil(t, listing.Filter.ReadableBy)
+	assert.Equal(t, aggregate.SortByCreatedAt, listing.Sort)
+	assert.True(t, listing.Descending)
+	assert.Equal(t, int64(defaultPageSize+1), listing.Limit)
+}
+
+func TestListFilesUseCase_ShouldFilterByTagsAndAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FileListing
+	repo := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return nil, nil
+		},
+	}
+
+	_, err := NewListFilesUseCase(repo, validAuth(), &MembershipClientMock{}).Execute(ctx, ListFilesQuery{
+		Tags:       []string{"Diagram", "diagram", "arch"},
+		Attributes: map[string]string{"kind": "avatar"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"diagram", "arch"}, listing.Filter.Tags)
+	assert.Equal(t, map[string]string{"kind": "avatar"}, listing.Filter.Attributes)
+}
+
+func TestListFilesUseCase_ShouldReturnCursorForNextPage(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listings []aggregate.FileListing
+	repo := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listings = append(listings, requested)
+			return listedFiles(t, int(requested.Limit)), nil
+		},
+	}
+	uc := NewListFilesUseCase(repo, validAuth(), &MembershipClientMock{})
+
+	page, err := uc.Execute(ctx, ListFilesQuery{Sort: "size", Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, page.Files, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	_, err = uc.Execute(ctx, ListFilesQuery{Sort: "size", Limit: 2, Cursor: page.NextCursor})
+	require.NoError(t, err)
+
+	after := listings[1].After
+	require.NotNil(t, after)
+	assert.Equal(t, "file-1", after.ID)
+	assert.Equal(t, int64(1), after.Size)
+	assert.False(t, after.Descending)
+}
+
+func TestListFilesUseCase_ShouldRejectCursorFromAnotherSort(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	cursor := aggregate.NewFileCursor(listedFiles(t, 1)[0], aggregate.SortByName, false).Encode()
+
+	_, err := NewListFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{}).Execute(ctx, ListFilesQuery{Cursor: cursor})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestListFilesUseCase_ShouldRejectInvalidQuery(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	uc := NewListFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{})
+	now := time.Now()
+
+	for name, query := range map[string]ListFilesQuery{
+		"sort":       {Sort: "owner"},
+		"order":      {Order: "up"},
+		"visibility": {Visibility: "SECRET"},
+		"status":     {Status: "GONE"},
+		"limit":      {Limit: maxPageSize + 1},
+		"range":      {CreatedFrom: now, CreatedTo: now.Add(-time.Hour)},
+		"cursor":     {Cursor: "not-a-cursor"},
+		"tag":        {Tags: []string{" "}},
+		"attribute":  {Attributes: map[string]string{"$where": "1"}},
+	} {
+		_, err := uc.Execute(ctx, query)
+		assert.ErrorIs(t, err, apperror.ErrValidation, name)
+	}
+}
+
+func TestListFilesUseCase_ShouldLimitOtherOwnersToReadableFiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	var listing aggregate.FileListing
+	repo := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return nil, nil
+		},
+	}
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, project string, profileID int64) (bool, error) {
+			return project == projectID && profileID == 12, nil
+		},
+	}
+
+	_, err := NewListFilesUseCase(repo, validAuth(), membership).Execute(ctx, ListFilesQuery{OwnerID: "99", ProjectID: &projectID})
+
+	require.NoError(t, err)
+	assert.Equal(t, "99", listing.Filter.OwnerID)
+	require.NotNil(t, listing.Filter.ReadableBy)
+	assert.Equal(t, "12", listing.Filter.ReadableBy.ProfileID)
+	assert.Equal(t, []string{projectID}, listing.Filter.ReadableBy.MemberOf)
+}
+
+func TestListFilesUseCase_ShouldIncludeCallerProjectsWithoutProjectFilter(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var listing aggregate.FileListing
+	repo := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return nil, nil
+		},
+	}
+	membership := &MembershipClientMock{
+		ListProjectsFn: func(token string, profileID int64) ([]string, error) {
+			return []string{"project-1", "project-2"}, nil
+		},
+	}
+
+	_, err := NewListFilesUseCase(repo, validAuth(), membership).Execute(ctx, ListFilesQuery{OwnerID: "99"})
+
+	require.NoError(t, err)
+	require.NotNil(t, listing.Filter.ReadableBy)
+	assert.Nil(t, listing.Filter.ProjectID)
+	assert.Equal(t, []string{"project-1", "project-2"}, listing.Filter.ReadableBy.MemberOf)
+}
+
+func TestListFilesUseCase_ShouldForbidOtherOwnersUnavailableFiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewListFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{}).Execute(ctx, ListFilesQuery{OwnerID: "99", Status: "DELETED"})
+
+	assert.ErrorIs(t, err, apperror.ErrForbidden)
+}
+
+func TestListFilesUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewListFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{}).Execute(context.Background(), ListFilesQuery{})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	ListFiles(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error)
+}
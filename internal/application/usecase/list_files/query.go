@@ -0,0 +1,22 @@
+package listfiles
+
+import "time"
+
+// ListFilesQuery lists the files of OwnerID, or of the caller when empty.
+// Sort defaults to created_at; Order is "asc" or "desc" and defaults to
+// newest first for created_at and ascending otherwise.
+type ListFilesQuery struct {
+	OwnerID     string
+	ProjectID   *string
+	Visibility  string
+	Status      string
+	MimePrefix  string
+	Tags        []string
+	Attributes  map[string]string
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	Sort        string
+	Order       string
+	Cursor      string
+	Limit       int64
+}
@@ -0,0 +1,10 @@
+package listsharelinks
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+)
+
+type IListShareLinksUseCase interface {
+	Execute(ctx context.Context, query ListShareLinksQuery) ([]aggregate.SignedShareLink, error)
+}
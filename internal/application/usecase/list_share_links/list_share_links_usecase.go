@@ -0,0 +1,62 @@
+package listsharelinks
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/usecase/list_share_links/port"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type ListShareLinksUseCase struct {
+	fileRepository      port.FileRepository
+	shareLinkRepository port.ShareLinkRepository
+	signer              port.TokenSigner
+	authClient          auth.IAuthClient
+}
+
+func NewListShareLinksUseCase(fileRepository port.FileRepository, shareLinkRepository port.ShareLinkRepository, signer port.TokenSigner, authClient auth.IAuthClient) *ListShareLinksUseCase {
+	return &ListShareLinksUseCase{
+		fileRepository:      fileRepository,
+		shareLinkRepository: shareLinkRepository,
+		signer:              signer,
+		authClient:          authClient,
+	}
+}
+
+func (uc *ListShareLinksUseCase) Execute(ctx context.Context, query ListShareLinksQuery) ([]aggregate.SignedShareLink, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return nil, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return nil, authError
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, query.FileId)
+	if err != nil {
+		return nil, err
+	}
+	if !file.CanBeManagedBy(strconv.FormatInt(*profileId, 10)) {
+		return nil, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	links, err := uc.shareLinkRepository.ListByFile(ctx, file.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	signed := make([]aggregate.SignedShareLink, 0, len(links))
+	for _, link := range links {
+		signed = append(signed, aggregate.SignedShareLink{
+			Link:  link,
+			Token: uc.signer.Sign(link.ID(), link.ExpiresAt()),
+		})
+	}
+	return signed, nil
+}
@@ -0,0 +1,88 @@
+package listsharelinks
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	GetFileFn func(ctx context.Context, id string) (domain.File, error)
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.GetFileFn(ctx, id)
+}
+
+type ShareLinkRepositoryMock struct {
+	ListByFileFn func(ctx context.Context, fileID string) ([]domain.ShareLink, error)
+}
+
+func (m *ShareLinkRepositoryMock) ListByFile(ctx context.Context, fileID string) ([]domain.ShareLink, error) {
+	return m.ListByFileFn(ctx, fileID)
+}
+
+type SignerMock struct{}
+
+func (SignerMock) Sign(linkID string, expiresAt time.Time) string {
+	return "signed-" + linkID
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func authAs(id int64) *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return &id, nil
+		},
+	}
+}
+
+func fileRepository() *FileRepositoryMock {
+	return &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.RehydrateFile(id, "12", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		},
+	}
+}
+
+func TestListShareLinksUseCase_ShouldReturnSignedLinksToOwner(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	links := &ShareLinkRepositoryMock{
+		ListByFileFn: func(ctx context.Context, fileID string) ([]domain.ShareLink, error) {
+			link, err := domain.NewShareLink("link-1", fileID, "12", time.Now().Add(time.Hour), 0, "")
+			return []domain.ShareLink{link}, err
+		},
+	}
+
+	result, err := NewListShareLinksUseCase(fileRepository(), links, SignerMock{}, authAs(12)).Execute(ctx, ListShareLinksQuery{FileId: "1"})
+
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "signed-link-1", result[0].Token)
+}
+
+func TestListShareLinksUseCase_ShouldRejectOtherProfiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewListShareLinksUseCase(fileRepository(), &ShareLinkRepositoryMock{}, SignerMock{}, authAs(99)).Execute(ctx, ListShareLinksQuery{FileId: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+}
+
+func TestListShareLinksUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewListShareLinksUseCase(fileRepository(), &ShareLinkRepositoryMock{}, SignerMock{}, authAs(12)).Execute(context.Background(), ListShareLinksQuery{FileId: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ShareLinkRepository interface {
+	ListByFile(ctx context.Context, fileID string) ([]domain.ShareLink, error)
+}
@@ -0,0 +1,7 @@
+package port
+
+import "time"
+
+type TokenSigner interface {
+	Sign(linkID string, expiresAt time.Time) string
+}
@@ -0,0 +1,5 @@
+package listsharelinks
+
+type ListShareLinksQuery struct {
+	FileId string
+}
@@ -0,0 +1,10 @@
+package listsharedwithme
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IListSharedWithMeUseCase interface {
+	Execute(ctx context.Context) ([]domain.File, error)
+}
@@ -0,0 +1,38 @@
+package listsharedwithme
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/list_shared_with_me/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type ListSharedWithMeUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewListSharedWithMeUseCase(repository port.FileRepository, authClient auth.IAuthClient) *ListSharedWithMeUseCase {
+	return &ListSharedWithMeUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *ListSharedWithMeUseCase) Execute(ctx context.Context) ([]domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return nil, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return nil, authError
+	}
+
+	return uc.repository.ListSharedWith(ctx, strconv.FormatInt(*profileId, 10))
+}
@@ -0,0 +1,79 @@
+package listsharedwithme
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	ListSharedWithFn func(ctx context.Context, profileID string) ([]domain.File, error)
+}
+
+func (m *FileRepositoryMock) ListSharedWith(ctx context.Context, profileID string) ([]domain.File, error) {
+	return m.ListSharedWithFn(ctx, profileID)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func TestListSharedWithMeUseCase_ShouldListFilesSharedWithCaller(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requestedProfile string
+	repo := &FileRepositoryMock{
+		ListSharedWithFn: func(ctx context.Context, profileID string) ([]domain.File, error) {
+			requestedProfile = profileID
+			file, err := domain.RehydrateFile("1", "99", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+			if err != nil {
+				return nil, err
+			}
+			err = file.ShareWith("99", profileID, domain.PermissionRead)
+			return []domain.File{file}, err
+		},
+	}
+
+	files, err := NewListSharedWithMeUseCase(repo, validAuth()).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "12", requestedProfile)
+	assert.Len(t, files, 1)
+}
+
+func TestListSharedWithMeUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewListSharedWithMeUseCase(&FileRepositoryMock{}, validAuth()).Execute(context.Background())
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestListSharedWithMeUseCase_ShouldReturnAuthError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	authClient := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return nil, errors.New("unauthorized")
+		},
+	}
+
+	_, err := NewListSharedWithMeUseCase(&FileRepositoryMock{}, authClient).Execute(ctx)
+
+	assert.EqualError(t, err, "unauthorized")
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	ListSharedWith(ctx context.Context, profileID string) ([]domain.File, error)
+}
@@ -0,0 +1,10 @@
+package listtrash
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IListTrashUseCase interface {
+	Execute(ctx context.Context) ([]domain.File, error)
+}
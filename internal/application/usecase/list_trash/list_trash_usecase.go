@@ -0,0 +1,38 @@
+package listtrash
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/list_trash/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type ListTrashUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewListTrashUseCase(repository port.FileRepository, authClient auth.IAuthClient) *ListTrashUseCase {
+	return &ListTrashUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *ListTrashUseCase) Execute(ctx context.Context) ([]domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return nil, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return nil, authError
+	}
+
+	return uc.repository.ListDeletedByOwner(ctx, strconv.FormatInt(*profileId, 10))
+}
@@ -0,0 +1,77 @@
+package listtrash
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	ListDeletedByOwnerFn func(ctx context.Context, ownerID string) ([]domain.File, error)
+}
+
+func (m *FileRepositoryMock) ListDeletedByOwner(ctx context.Context, ownerID string) ([]domain.File, error) {
+	return m.ListDeletedByOwnerFn(ctx, ownerID)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func TestListTrashUseCase_ShouldListCallerDeletedFiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var requestedOwner string
+	repo := &FileRepositoryMock{
+		ListDeletedByOwnerFn: func(ctx context.Context, ownerID string) ([]domain.File, error) {
+			requestedOwner = ownerID
+			file, err := domain.RehydrateFile("1", ownerID, nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusDeleted, time.Now(), domain.WithDeletedAt(time.Now()))
+			return []domain.File{file}, err
+		},
+	}
+
+	files, err := NewListTrashUseCase(repo, validAuth()).Execute(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, "12", requestedOwner)
+	assert.Len(t, files, 1)
+}
+
+func TestListTrashUseCase_ShouldFailWithoutToken(t *testing.T) {
+	repo := &FileRepositoryMock{}
+
+	_, err := NewListTrashUseCase(repo, validAuth()).Execute(context.Background())
+
+	assert.EqualError(t, err, "token cannot be null")
+}
+
+func TestListTrashUseCase_ShouldReturnAuthError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	authClient := &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return nil, errors.New("unauthorized")
+		},
+	}
+
+	_, err := NewListTrashUseCase(&FileRepositoryMock{}, authClient).Execute(ctx)
+
+	assert.EqualError(t, err, "unauthorized")
+}
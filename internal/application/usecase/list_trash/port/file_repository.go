@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	ListDeletedByOwner(ctx context.Context, ownerID string) ([]domain.File, error)
+}
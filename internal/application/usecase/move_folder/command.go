@@ -0,0 +1,9 @@
+package movefolder
+
+// MoveFolderCommand moves the caller's files below From to the same relative
+// paths below To, which renames the folder when both share a parent.
+type MoveFolderCommand struct {
+	ProjectID string
+	From      string
+	To        string
+}
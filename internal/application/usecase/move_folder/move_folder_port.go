@@ -0,0 +1,7 @@
+package movefolder
+
+import "context"
+
+type IMoveFolderUseCase interface {
+	Execute(ctx context.Context, command MoveFolderCommand) (int64, error)
+}
@@ -0,0 +1,58 @@
+package movefolder
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/move_folder/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+	"strings"
+)
+
+type MoveFolderUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewMoveFolderUseCase(repository port.FileRepository, authClient auth.IAuthClient) *MoveFolderUseCase {
+	return &MoveFolderUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *MoveFolderUseCase) Execute(ctx context.Context, command MoveFolderCommand) (int64, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return 0, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return 0, authError
+	}
+
+	from, err := domain.NormalizePath(command.From)
+	if err != nil {
+		return 0, err
+	}
+	to, err := domain.NormalizePath(command.To)
+	if err != nil {
+		return 0, err
+	}
+	if to == from || strings.HasPrefix(to, from+"/") {
+		return 0, apperror.New(apperror.ErrValidation, "cannot move %s into itself", from)
+	}
+
+	moved, err := uc.repository.MoveFolder(ctx, strconv.FormatInt(*profileId, 10), command.ProjectID, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if moved == 0 {
+		return 0, apperror.New(apperror.ErrNotFound, "folder not found")
+	}
+	return moved, nil
+}
@@ -0,0 +1,97 @@
+package movefolder
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	MoveFolderFn func(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error)
+}
+
+func (m *FileRepositoryMock) MoveFolder(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error) {
+	return m.MoveFolderFn(ctx, ownerID, projectID, from, to)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func TestMoveFolderUseCase_ShouldMoveCallerFiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var owner, from, to string
+	repo := &FileRepositoryMock{
+		MoveFolderFn: func(ctx context.Context, ownerID string, projectID string, requestedFrom string, requestedTo string) (int64, error) {
+			owner, from, to = ownerID, requestedFrom, requestedTo
+			return 3, nil
+		},
+	}
+
+	moved, err := NewMoveFolderUseCase(repo, validAuth()).Execute(ctx, MoveFolderCommand{ProjectID: "project-1", From: "/docs/img/", To: "assets"})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), moved)
+	assert.Equal(t, "12", owner)
+	assert.Equal(t, "docs/img", from)
+	assert.Equal(t, "assets", to)
+}
+
+func TestMoveFolderUseCase_ShouldRejectMoveIntoItself(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	uc := NewMoveFolderUseCase(&FileRepositoryMock{}, validAuth())
+
+	for _, to := range []string{"docs", "docs/img"} {
+		_, err := uc.Execute(ctx, MoveFolderCommand{ProjectID: "project-1", From: "docs", To: to})
+		assert.ErrorIs(t, err, apperror.ErrValidation, to)
+	}
+}
+
+func TestMoveFolderUseCase_ShouldRejectExistingTarget(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	repo := &FileRepositoryMock{
+		MoveFolderFn: func(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error) {
+			return 0, apperror.New(apperror.ErrConflict, "%s already exists", to)
+		},
+	}
+
+	_, err := NewMoveFolderUseCase(repo, validAuth()).Execute(ctx, MoveFolderCommand{ProjectID: "project-1", From: "docs", To: "assets"})
+
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+}
+
+func TestMoveFolderUseCase_ShouldFailForMissingFolder(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	repo := &FileRepositoryMock{
+		MoveFolderFn: func(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error) {
+			return 0, nil
+		},
+	}
+
+	_, err := NewMoveFolderUseCase(repo, validAuth()).Execute(ctx, MoveFolderCommand{ProjectID: "project-1", From: "docs", To: "assets"})
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+}
+
+func TestMoveFolderUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewMoveFolderUseCase(&FileRepositoryMock{}, validAuth()).Execute(context.Background(), MoveFolderCommand{From: "a", To: "b"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
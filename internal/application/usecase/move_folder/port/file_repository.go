@@ -0,0 +1,9 @@
+package port
+
+import "context"
+
+type FileRepository interface {
+	// MoveFolder fails with a conflict when the destination already holds
+	// files, leaving the folder where it was.
+	MoveFolder(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error)
+}
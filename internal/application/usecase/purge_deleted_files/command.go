@@ -0,0 +1,8 @@
+package purgedeletedfiles
+
+import "time"
+
+type PurgeDeletedFilesCommand struct {
+	DeletedBefore time.Time
+	Limit         int64
+}
@@ -0,0 +1,7 @@
+package port
+
+import "context"
+
+type BlobRepository interface {
+	Release(ctx context.Context, checksum string) (bool, error)
+}
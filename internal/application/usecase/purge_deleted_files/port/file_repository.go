@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type FileRepository interface {
+	ListDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int64) ([]domain.File, error)
+	DeleteFile(ctx context.Context, id string) error
+}
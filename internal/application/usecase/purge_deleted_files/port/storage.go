@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type Storage interface {
+	DeleteFile(ctx context.Context, file domain.File) error
+	DeleteObject(ctx context.Context, storageKey string) error
+}
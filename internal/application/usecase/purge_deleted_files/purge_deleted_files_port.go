@@ -0,0 +1,7 @@
+package purgedeletedfiles
+
+import "context"
+
+type IPurgeDeletedFilesUseCase interface {
+	Execute(ctx context.Context, command PurgeDeletedFilesCommand) (int, error)
+}
@@ -0,0 +1,62 @@
+package purgedeletedfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/reclaim"
+	"devconnectstorage/internal/application/usecase/purge_deleted_files/port"
+	"errors"
+	"fmt"
+)
+
+type PurgeDeletedFilesUseCase struct {
+	repository port.FileRepository
+	storage    port.Storage
+	blobs      port.BlobRepository
+	usage      port.StorageUsageRepository
+}
+
+func NewPurgeDeletedFilesUseCase(repository port.FileRepository, storage port.Storage, blobs port.BlobRepository, usage port.StorageUsageRepository) *PurgeDeletedFilesUseCase {
+	return &PurgeDeletedFilesUseCase{
+		repository: repository,
+		storage:    storage,
+		blobs:      blobs,
+		usage:      usage,
+	}
+}
+
+func (uc *PurgeDeletedFilesUseCase) Execute(ctx context.Context, command PurgeDeletedFilesCommand) (int, error) {
+	if command.Limit <= 0 {
+		return 0, apperror.New(apperror.ErrValidation, "limit must be positive")
+	}
+
+	files, err := uc.repository.ListDeletedBefore(ctx, command.DeletedBefore, command.Limit)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+	var purgeErrors []error
+	for _, file := range files {
+		if file.StorageKey() != "" {
+			if err := uc.storage.DeleteFile(ctx, file); err != nil {
+				purgeErrors = append(purgeErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+				continue
+			}
+		}
+		if err := uc.repository.DeleteFile(ctx, file.ID()); err != nil {
+			purgeErrors = append(purgeErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+			continue
+		}
+		if err := uc.reclaimer().Reclaim(ctx, file); err != nil {
+			purgeErrors = append(purgeErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+		}
+		purged++
+	}
+
+	return purged, errors.Join(purgeErrors...)
+}
+
+func (uc *PurgeDeletedFilesUseCase) reclaimer() reclaim.Reclaimer {
+	return reclaim.NewReclaimer(uc.storage, uc.blobs, uc.usage)
+}
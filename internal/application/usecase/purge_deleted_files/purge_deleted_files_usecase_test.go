@@ -0,0 +1,163 @@
+package purgedeletedfiles
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) ListDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int64) ([]domain.File, error) {
+	args := m.Called(ctx, deletedBefore, limit)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) DeleteFile(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type StorageMock struct {
+	mock.Mock
+}
+
+func (m *StorageMock) DeleteFile(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	args := m.Called(ctx, storageKey)
+	return args.Error(0)
+}
+
+type BlobRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *BlobRepositoryMock) Release(ctx context.Context, checksum string) (bool, error) {
+	args := m.Called(ctx, checksum)
+	return args.Bool(0), args.Error(1)
+}
+
+type StorageUsageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	args := m.Called(ctx, ownerID, bytes, files)
+	return args.Error(0)
+}
+
+func releasingUsage() *StorageUsageRepositoryMock {
+	usage := new(StorageUsageRepositoryMock)
+	usage.On("Release", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	return usage
+}
+
+const blobChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func contentAddressedFile(t *testing.T, status domain.Status, options ...domain.RehydrateOption) domain.File {
+	version, err := domain.RehydrateFileVersion(1, "blobs/2c/"+blobChecksum, "text/plain", 5, blobChecksum, true, time.Now())
+	assert.NoError(t, err)
+	options = append(options, domain.WithChecksum(blobChecksum), domain.WithVersions(1, []domain.FileVersion{version}))
+	file, err := domain.RehydrateFile("1", "123", nil, "hello.txt", "text/plain", 5, "blobs/2c/"+blobChecksum, domain.VisibilityPrivate, status, time.Now(), options...)
+	assert.NoError(t, err)
+	return file
+}
+
+func deletedFile(id string) domain.File {
+	file, _ := domain.RehydrateFile(id, "123", nil, "path", "text/plain", 32, "storage/"+id, domain.VisibilityPrivate, domain.StatusDeleted, time.Now(), domain.WithDeletedAt(time.Now()))
+	return file
+}
+
+func TestPurgeDeletedFilesUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	cutoff := time.Now()
+
+	t.Run("Success", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		files := []domain.File{deletedFile("1"), deletedFile("2")}
+
+		repo.On("ListDeletedBefore", ctx, cutoff, int64(10)).Return(files, nil)
+		storage.On("DeleteFile", ctx, mock.Anything).Return(nil)
+		repo.On("DeleteFile", ctx, "1").Return(nil)
+		repo.On("DeleteFile", ctx, "2").Return(nil)
+
+		usage := new(StorageUsageRepositoryMock)
+		usage.On("Release", ctx, "123", int64(32), int64(1)).Return(nil).Twice()
+
+		purged, err := NewPurgeDeletedFilesUseCase(repo, storage, new(BlobRepositoryMock), usage).Execute(ctx, PurgeDeletedFilesCommand{DeletedBefore: cutoff, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, purged)
+		usage.AssertExpectations(t)
+		storage.AssertNumberOfCalls(t, "DeleteFile", 2)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Continues When One File Fails", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		first, second := deletedFile("1"), deletedFile("2")
+
+		repo.On("ListDeletedBefore", ctx, cutoff, int64(10)).Return([]domain.File{first, second}, nil)
+		storage.On("DeleteFile", ctx, first).Return(errors.New("storage error"))
+		storage.On("DeleteFile", ctx, second).Return(nil)
+		repo.On("DeleteFile", ctx, "2").Return(nil)
+
+		purged, err := NewPurgeDeletedFilesUseCase(repo, storage, new(BlobRepositoryMock), releasingUsage()).Execute(ctx, PurgeDeletedFilesCommand{DeletedBefore: cutoff, Limit: 10})
+
+		assert.Error(t, err)
+		assert.Equal(t, 1, purged)
+		repo.AssertNotCalled(t, "DeleteFile", ctx, "1")
+	})
+
+	t.Run("Releases Content-Addressed Blobs", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		blobs := new(BlobRepositoryMock)
+		file := contentAddressedFile(t, domain.StatusDeleted, domain.WithDeletedAt(time.Now()))
+
+		repo.On("ListDeletedBefore", ctx, cutoff, int64(10)).Return([]domain.File{file}, nil)
+		storage.On("DeleteFile", ctx, file).Return(nil)
+		repo.On("DeleteFile", ctx, "1").Return(nil)
+		blobs.On("Release", ctx, blobChecksum).Return(true, nil)
+		storage.On("DeleteObject", ctx, "blobs/2c/"+blobChecksum).Return(nil)
+
+		purged, err := NewPurgeDeletedFilesUseCase(repo, storage, blobs, releasingUsage()).Execute(ctx, PurgeDeletedFilesCommand{DeletedBefore: cutoff, Limit: 10})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, purged)
+		blobs.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Error On List", func(t *testing.T) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		expectedErr := errors.New("db error")
+
+		repo.On("ListDeletedBefore", ctx, cutoff, int64(10)).Return([]domain.File{}, expectedErr)
+
+		_, err := NewPurgeDeletedFilesUseCase(repo, storage, new(BlobRepositoryMock), releasingUsage()).Execute(ctx, PurgeDeletedFilesCommand{DeletedBefore: cutoff, Limit: 10})
+
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("Error Invalid Limit", func(t *testing.T) {
+		_, err := NewPurgeDeletedFilesUseCase(new(RepositoryMock), new(StorageMock), new(BlobRepositoryMock), releasingUsage()).Execute(ctx, PurgeDeletedFilesCommand{DeletedBefore: cutoff})
+
+		assert.EqualError(t, err, "limit must be positive")
+	})
+}
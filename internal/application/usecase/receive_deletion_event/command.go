@@ -0,0 +1,15 @@
+package receivedeletionevent
+
+const (
+	EventProjectDeleted = "ProjectDeleted"
+	EventProfileDeleted = "ProfileDeleted"
+)
+
+// ReceiveDeletionEventCommand carries an event from the main service.
+// ProjectID is set for ProjectDeleted and ProfileID for ProfileDeleted.
+type ReceiveDeletionEventCommand struct {
+	EventID   string
+	Type      string
+	ProjectID string
+	ProfileID string
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type CleanupJobRepository interface {
+	Save(ctx context.Context, job domain.CleanupJob) error
+	GetJob(ctx context.Context, id string) (domain.CleanupJob, error)
+}
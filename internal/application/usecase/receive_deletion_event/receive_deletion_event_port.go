@@ -0,0 +1,10 @@
+package receivedeletionevent
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IReceiveDeletionEventUseCase interface {
+	Execute(ctx context.Context, command ReceiveDeletionEventCommand) (domain.CleanupJob, error)
+}
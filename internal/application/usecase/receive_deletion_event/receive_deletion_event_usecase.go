@@ -0,0 +1,52 @@
+package receivedeletionevent
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/receive_deletion_event/port"
+	"devconnectstorage/internal/domain"
+	"errors"
+)
+
+type ReceiveDeletionEventUseCase struct {
+	jobs port.CleanupJobRepository
+}
+
+func NewReceiveDeletionEventUseCase(jobs port.CleanupJobRepository) *ReceiveDeletionEventUseCase {
+	return &ReceiveDeletionEventUseCase{
+		jobs: jobs,
+	}
+}
+
+// Execute schedules the cleanup an event asks for. Events are delivered at
+// least once, so a repeated event returns the job the first delivery created.
+func (uc *ReceiveDeletionEventUseCase) Execute(ctx context.Context, command ReceiveDeletionEventCommand) (domain.CleanupJob, error) {
+	var scope domain.CleanupScope
+	var subjectID string
+	switch command.Type {
+	case EventProjectDeleted:
+		scope, subjectID = domain.CleanupScopeProject, command.ProjectID
+	case EventProfileDeleted:
+		scope, subjectID = domain.CleanupScopeOwner, command.ProfileID
+	default:
+		return domain.CleanupJob{}, apperror.New(apperror.ErrValidation, "unsupported event type %q", command.Type)
+	}
+
+	job, err := domain.NewCleanupJob(command.EventID, scope, subjectID)
+	if err != nil {
+		return domain.CleanupJob{}, err
+	}
+
+	err = uc.jobs.Save(ctx, job)
+	if !errors.Is(err, apperror.ErrConflict) {
+		return job, err
+	}
+	existing, err := uc.jobs.GetJob(ctx, command.EventID)
+	if err != nil {
+		return domain.CleanupJob{}, err
+	}
+	if !existing.Matches(scope, subjectID) {
+		return domain.CleanupJob{}, apperror.New(apperror.ErrConflict, "event %s was already received with a different payload", command.EventID)
+	}
+	return existing, nil
+}
@@ -0,0 +1,81 @@
+package receivedeletionevent
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CleanupJobRepositoryMock struct {
+	SaveFn   func(ctx context.Context, job domain.CleanupJob) error
+	GetJobFn func(ctx context.Context, id string) (domain.CleanupJob, error)
+}
+
+func (m *CleanupJobRepositoryMock) Save(ctx context.Context, job domain.CleanupJob) error {
+	return m.SaveFn(ctx, job)
+}
+
+func (m *CleanupJobRepositoryMock) GetJob(ctx context.Context, id string) (domain.CleanupJob, error) {
+	return m.GetJobFn(ctx, id)
+}
+
+func TestReceiveDeletionEventUseCase_ShouldScheduleCleanup(t *testing.T) {
+	var saved []domain.CleanupJob
+	repo := &CleanupJobRepositoryMock{
+		SaveFn: func(ctx context.Context, job domain.CleanupJob) error {
+			saved = append(saved, job)
+			return nil
+		},
+	}
+	uc := NewReceiveDeletionEventUseCase(repo)
+
+	_, err := uc.Execute(context.Background(), ReceiveDeletionEventCommand{EventID: "event-1", Type: EventProjectDeleted, ProjectID: "project-1"})
+	require.NoError(t, err)
+	_, err = uc.Execute(context.Background(), ReceiveDeletionEventCommand{EventID: "event-2", Type: EventProfileDeleted, ProfileID: "12"})
+	require.NoError(t, err)
+
+	require.Len(t, saved, 2)
+	assert.True(t, saved[0].Matches(domain.CleanupScopeProject, "project-1"))
+	assert.True(t, saved[1].Matches(domain.CleanupScopeOwner, "12"))
+	assert.Equal(t, domain.CleanupStatusPending, saved[1].Status())
+}
+
+func TestReceiveDeletionEventUseCase_ShouldReturnExistingJobForRedeliveredEvent(t *testing.T) {
+	existing, err := domain.NewCleanupJob("event-1", domain.CleanupScopeProject, "project-1")
+	require.NoError(t, err)
+	require.NoError(t, existing.RecordBatch(40, nil))
+	repo := &CleanupJobRepositoryMock{
+		SaveFn: func(ctx context.Context, job domain.CleanupJob) error {
+			return apperror.New(apperror.ErrConflict, "duplicate")
+		},
+		GetJobFn: func(ctx context.Context, id string) (domain.CleanupJob, error) {
+			return existing, nil
+		},
+	}
+	uc := NewReceiveDeletionEventUseCase(repo)
+
+	job, err := uc.Execute(context.Background(), ReceiveDeletionEventCommand{EventID: "event-1", Type: EventProjectDeleted, ProjectID: "project-1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(40), job.TrashedCount())
+
+	_, err = uc.Execute(context.Background(), ReceiveDeletionEventCommand{EventID: "event-1", Type: EventProjectDeleted, ProjectID: "project-2"})
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+}
+
+func TestReceiveDeletionEventUseCase_ShouldRejectInvalidEvents(t *testing.T) {
+	uc := NewReceiveDeletionEventUseCase(&CleanupJobRepositoryMock{})
+
+	for name, command := range map[string]ReceiveDeletionEventCommand{
+		"type":       {EventID: "event-1", Type: "ProjectArchived", ProjectID: "project-1"},
+		"id":         {Type: EventProjectDeleted, ProjectID: "project-1"},
+		"project id": {EventID: "event-1", Type: EventProjectDeleted, ProfileID: "12"},
+		"profile id": {EventID: "event-1", Type: EventProfileDeleted},
+	} {
+		_, err := uc.Execute(context.Background(), command)
+		assert.ErrorIs(t, err, apperror.ErrValidation, name)
+	}
+}
@@ -0,0 +1,10 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	FindByPath(ctx context.Context, projectID string, path string) ([]domain.File, error)
+}
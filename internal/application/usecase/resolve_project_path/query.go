@@ -0,0 +1,10 @@
+package resolveprojectpath
+
+// ResolveProjectPathQuery finds the file at Path in a project. Paths are only
+// unique per owner, so OwnerID picks one when several owners use the same
+// path.
+type ResolveProjectPathQuery struct {
+	ProjectID string
+	Path      string
+	OwnerID   string
+}
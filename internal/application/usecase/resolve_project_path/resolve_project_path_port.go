@@ -0,0 +1,10 @@
+package resolveprojectpath
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IResolveProjectPathUseCase interface {
+	Execute(ctx context.Context, query ResolveProjectPathQuery) (domain.File, error)
+}
@@ -0,0 +1,71 @@
+package resolveprojectpath
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/resolve_project_path/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+)
+
+type ResolveProjectPathUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewResolveProjectPathUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *ResolveProjectPathUseCase {
+	return &ResolveProjectPathUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *ResolveProjectPathUseCase) Execute(ctx context.Context, query ResolveProjectPathQuery) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	path, err := domain.NormalizePath(query.Path)
+	if err != nil {
+		return domain.File{}, err
+	}
+	files, err := uc.repository.FindByPath(ctx, query.ProjectID, path)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	var readable []domain.File
+	for _, file := range files {
+		if query.OwnerID != "" && file.OwnerID() != query.OwnerID {
+			continue
+		}
+		allowed, accessError := policy.CanReadFile(uc.membershipClient, token.(string), *profileId, file)
+		if accessError != nil {
+			return domain.File{}, accessError
+		}
+		if allowed {
+			readable = append(readable, file)
+		}
+	}
+
+	switch len(readable) {
+	case 0:
+		return domain.File{}, apperror.New(apperror.ErrNotFound, "file not found")
+	case 1:
+		return readable[0], nil
+	default:
+		return domain.File{}, apperror.New(apperror.ErrConflict, "%d owners have a file at %s, pass owner_id to pick one", len(readable), path)
+	}
+}
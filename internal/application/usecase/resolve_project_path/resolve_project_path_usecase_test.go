@@ -0,0 +1,120 @@
+package resolveprojectpath
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	FindByPathFn func(ctx context.Context, projectID string, path string) ([]domain.File, error)
+}
+
+func (m *FileRepositoryMock) FindByPath(ctx context.Context, projectID string, path string) ([]domain.File, error) {
+	return m.FindByPathFn(ctx, projectID, path)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func membership(member bool) *MembershipClientMock {
+	return &MembershipClientMock{
+		IsMemberFn: func(token string, projectID string, profileID int64) (bool, error) {
+			return member, nil
+		},
+	}
+}
+
+func projectFile(t *testing.T, id string, ownerID string) domain.File {
+	projectID := "project-1"
+	file, err := domain.RehydrateFile(id, ownerID, &projectID, "arch.png", "image/png", 5, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now(), domain.WithPath("docs/img/arch.png"))
+	require.NoError(t, err)
+	return file
+}
+
+func repositoryWith(files ...domain.File) *FileRepositoryMock {
+	return &FileRepositoryMock{
+		FindByPathFn: func(ctx context.Context, projectID string, path string) ([]domain.File, error) {
+			if projectID != "project-1" || path != "docs/img/arch.png" {
+				return nil, nil
+			}
+			return files, nil
+		},
+	}
+}
+
+func TestResolveProjectPathUseCase_ShouldReturnReadableFile(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	file, err := NewResolveProjectPathUseCase(repositoryWith(projectFile(t, "file-1", "99")), validAuth(), membership(true)).Execute(ctx, ResolveProjectPathQuery{ProjectID: "project-1", Path: "/docs/img/arch.png"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "file-1", file.ID())
+}
+
+func TestResolveProjectPathUseCase_ShouldHideUnreadableFiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewResolveProjectPathUseCase(repositoryWith(projectFile(t, "file-1", "99")), validAuth(), membership(false)).Execute(ctx, ResolveProjectPathQuery{ProjectID: "project-1", Path: "docs/img/arch.png"})
+
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+}
+
+func TestResolveProjectPathUseCase_ShouldRequireOwnerWhenAmbiguous(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	uc := NewResolveProjectPathUseCase(repositoryWith(projectFile(t, "file-1", "99"), projectFile(t, "file-2", "12")), validAuth(), membership(true))
+
+	_, err := uc.Execute(ctx, ResolveProjectPathQuery{ProjectID: "project-1", Path: "docs/img/arch.png"})
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+
+	file, err := uc.Execute(ctx, ResolveProjectPathQuery{ProjectID: "project-1", Path: "docs/img/arch.png", OwnerID: "12"})
+	require.NoError(t, err)
+	assert.Equal(t, "file-2", file.ID())
+}
+
+func TestResolveProjectPathUseCase_ShouldRejectInvalidPath(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	_, err := NewResolveProjectPathUseCase(&FileRepositoryMock{}, validAuth(), membership(true)).Execute(ctx, ResolveProjectPathQuery{ProjectID: "project-1", Path: "../etc/passwd"})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestResolveProjectPathUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewResolveProjectPathUseCase(&FileRepositoryMock{}, validAuth(), membership(true)).Execute(context.Background(), ResolveProjectPathQuery{ProjectID: "project-1", Path: "a"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
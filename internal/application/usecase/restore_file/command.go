@@ -0,0 +1,5 @@
+package restorefile
+
+type RestoreFileCommand struct {
+	Id string
+}
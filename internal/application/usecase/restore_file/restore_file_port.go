@@ -0,0 +1,10 @@
+package restorefile
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IRestoreFileUseCase interface {
+	Execute(ctx context.Context, command RestoreFileCommand) (domain.File, error)
+}
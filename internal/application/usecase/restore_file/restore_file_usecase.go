@@ -0,0 +1,54 @@
+package restorefile
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/restore_file/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type RestoreFileUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewRestoreFileUseCase(repository port.FileRepository, authClient auth.IAuthClient) *RestoreFileUseCase {
+	return &RestoreFileUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *RestoreFileUseCase) Execute(ctx context.Context, command RestoreFileCommand) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.Id)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if file.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	if err := file.Restore(); err != nil {
+		return domain.File{}, err
+	}
+
+	if err := uc.repository.Update(ctx, file); err != nil {
+		return domain.File{}, err
+	}
+	return file, nil
+}
@@ -0,0 +1,123 @@
+package restorefile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestRestoreFileUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*RepositoryMock, *AuthClientMock, *RestoreFileUseCase) {
+		repo := new(RepositoryMock)
+		authCli := new(AuthClientMock)
+		uc := NewRestoreFileUseCase(repo, authCli)
+		return repo, authCli, uc
+	}
+
+	deletedFile := func(ownerID string) domain.File {
+		file, _ := domain.RehydrateFile("1", ownerID, nil, "path", "text/plain", 32, "storage/key", domain.VisibilityPrivate, domain.StatusDeleted, time.Now(), domain.WithDeletedAt(time.Now()))
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(deletedFile("123"), nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.Status() == domain.StatusAvailable && file.DeletedAt() == nil
+		})).Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, RestoreFileCommand{Id: "file-id"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.StatusAvailable, file.Status())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, uc := setup()
+		_, err := uc.Execute(context.Background(), RestoreFileCommand{Id: "file-id"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unauthorized Owner", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(deletedFile("123"), nil)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileCommand{Id: "file-id"})
+
+		assert.EqualError(t, err, "unauthorized")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error File Not In Trash", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		file, _ := domain.RehydrateFile("1", "123", nil, "path", "text/plain", 32, "storage/key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(file, nil)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileCommand{Id: "file-id"})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Update", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "file-id").Return(deletedFile("123"), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileCommand{Id: "file-id"})
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,6 @@
+package restorefileversion
+
+type RestoreFileVersionCommand struct {
+	FileId  string
+	Version int
+}
@@ -0,0 +1,10 @@
+package restorefileversion
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IRestoreFileVersionUseCase interface {
+	Execute(ctx context.Context, command RestoreFileVersionCommand) (domain.File, error)
+}
@@ -0,0 +1,54 @@
+package restorefileversion
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/restore_file_version/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type RestoreFileVersionUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewRestoreFileVersionUseCase(repository port.FileRepository, authClient auth.IAuthClient) *RestoreFileVersionUseCase {
+	return &RestoreFileVersionUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *RestoreFileVersionUseCase) Execute(ctx context.Context, command RestoreFileVersionCommand) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if file.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	if err := file.RestoreVersion(command.Version); err != nil {
+		return domain.File{}, err
+	}
+
+	if err := uc.repository.Update(ctx, file); err != nil {
+		return domain.File{}, err
+	}
+	return file, nil
+}
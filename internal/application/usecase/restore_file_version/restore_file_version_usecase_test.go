@@ -0,0 +1,123 @@
+package restorefileversion
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestRestoreFileVersionUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*RepositoryMock, *AuthClientMock, *RestoreFileVersionUseCase) {
+		repo := new(RepositoryMock)
+		authCli := new(AuthClientMock)
+		uc := NewRestoreFileVersionUseCase(repo, authCli)
+		return repo, authCli, uc
+	}
+
+	versionedFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "key/v1", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		_ = file.AddVersion("key/v2", "text/plain", 2, "")
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(versionedFile(), nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.Version() == 1 && file.StorageKey() == "key/v1"
+		})).Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, RestoreFileVersionCommand{FileId: "1", Version: 1})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, file.Version())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, uc := setup()
+		_, err := uc.Execute(context.Background(), RestoreFileVersionCommand{FileId: "1", Version: 1})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unauthorized Owner", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(versionedFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileVersionCommand{FileId: "1", Version: 1})
+
+		assert.EqualError(t, err, "unauthorized")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Unknown Version", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(versionedFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileVersionCommand{FileId: "1", Version: 5})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Update", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(versionedFile(), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, RestoreFileVersionCommand{FileId: "1", Version: 1})
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,6 @@
+package revokefileshare
+
+type RevokeFileShareCommand struct {
+	FileId    string
+	ProfileID string
+}
@@ -0,0 +1,7 @@
+package revokefileshare
+
+import "context"
+
+type IRevokeFileShareUseCase interface {
+	Execute(ctx context.Context, command RevokeFileShareCommand) error
+}
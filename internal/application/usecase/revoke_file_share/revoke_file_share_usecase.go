@@ -0,0 +1,46 @@
+package revokefileshare
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/revoke_file_share/port"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type RevokeFileShareUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewRevokeFileShareUseCase(repository port.FileRepository, authClient auth.IAuthClient) *RevokeFileShareUseCase {
+	return &RevokeFileShareUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *RevokeFileShareUseCase) Execute(ctx context.Context, command RevokeFileShareCommand) error {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return err
+	}
+
+	if err := file.RevokeShare(strconv.FormatInt(*profileId, 10), command.ProfileID); err != nil {
+		return err
+	}
+
+	return uc.repository.Update(ctx, file)
+}
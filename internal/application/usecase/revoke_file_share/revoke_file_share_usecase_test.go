@@ -0,0 +1,108 @@
+package revokefileshare
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestRevokeFileShareUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*RepositoryMock, *AuthClientMock, *RevokeFileShareUseCase) {
+		repo := new(RepositoryMock)
+		authCli := new(AuthClientMock)
+		uc := NewRevokeFileShareUseCase(repo, authCli)
+		return repo, authCli, uc
+	}
+
+	sharedFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		_ = file.ShareWith("123", "456", domain.PermissionRead)
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(sharedFile(), nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return len(file.Shares()) == 0
+		})).Return(nil)
+
+		err := uc.Execute(ctxWithToken, RevokeFileShareCommand{FileId: "1", ProfileID: "456"})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, uc := setup()
+		err := uc.Execute(context.Background(), RevokeFileShareCommand{FileId: "1", ProfileID: "456"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unknown Share", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(sharedFile(), nil)
+
+		err := uc.Execute(ctxWithToken, RevokeFileShareCommand{FileId: "1", ProfileID: "789"})
+
+		assert.EqualError(t, err, "share not found")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On GetFile", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(domain.File{}, expectedErr)
+
+		err := uc.Execute(ctxWithToken, RevokeFileShareCommand{FileId: "1", ProfileID: "456"})
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
@@ -0,0 +1,6 @@
+package revokesharelink
+
+type RevokeShareLinkCommand struct {
+	FileId string
+	LinkId string
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ShareLinkRepository interface {
+	GetShareLink(ctx context.Context, id string) (domain.ShareLink, error)
+	Update(ctx context.Context, link domain.ShareLink) error
+}
@@ -0,0 +1,7 @@
+package revokesharelink
+
+import "context"
+
+type IRevokeShareLinkUseCase interface {
+	Execute(ctx context.Context, command RevokeShareLinkCommand) error
+}
@@ -0,0 +1,58 @@
+package revokesharelink
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/revoke_share_link/port"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type RevokeShareLinkUseCase struct {
+	fileRepository      port.FileRepository
+	shareLinkRepository port.ShareLinkRepository
+	authClient          auth.IAuthClient
+}
+
+func NewRevokeShareLinkUseCase(fileRepository port.FileRepository, shareLinkRepository port.ShareLinkRepository, authClient auth.IAuthClient) *RevokeShareLinkUseCase {
+	return &RevokeShareLinkUseCase{
+		fileRepository:      fileRepository,
+		shareLinkRepository: shareLinkRepository,
+		authClient:          authClient,
+	}
+}
+
+func (uc *RevokeShareLinkUseCase) Execute(ctx context.Context, command RevokeShareLinkCommand) error {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return authError
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return err
+	}
+	if !file.CanBeManagedBy(strconv.FormatInt(*profileId, 10)) {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	link, err := uc.shareLinkRepository.GetShareLink(ctx, command.LinkId)
+	if err != nil {
+		return err
+	}
+	if link.FileID() != file.ID() {
+		return apperror.New(apperror.ErrNotFound, "share link not found")
+	}
+
+	if err := link.Revoke(); err != nil {
+		return err
+	}
+	return uc.shareLinkRepository.Update(ctx, link)
+}
@@ -0,0 +1,122 @@
+package revokesharelink
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type FileRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type ShareLinkRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *ShareLinkRepositoryMock) GetShareLink(ctx context.Context, id string) (domain.ShareLink, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.ShareLink), args.Error(1)
+}
+
+func (m *ShareLinkRepositoryMock) Update(ctx context.Context, link domain.ShareLink) error {
+	args := m.Called(ctx, link)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestRevokeShareLinkUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*FileRepositoryMock, *ShareLinkRepositoryMock, *AuthClientMock, *RevokeShareLinkUseCase) {
+		files := new(FileRepositoryMock)
+		links := new(ShareLinkRepositoryMock)
+		authCli := new(AuthClientMock)
+		uc := NewRevokeShareLinkUseCase(files, links, authCli)
+		return files, links, authCli, uc
+	}
+
+	availableFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		return file
+	}
+	linkFor := func(fileID string) domain.ShareLink {
+		link, _ := domain.NewShareLink("link-1", fileID, "123", time.Now().Add(time.Hour), 0, "")
+		return link
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		files, links, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		links.On("GetShareLink", ctxWithToken, "link-1").Return(linkFor("1"), nil)
+		links.On("Update", ctxWithToken, mock.MatchedBy(func(link domain.ShareLink) bool {
+			return link.RevokedAt() != nil
+		})).Return(nil)
+
+		err := uc.Execute(ctxWithToken, RevokeShareLinkCommand{FileId: "1", LinkId: "link-1"})
+
+		assert.NoError(t, err)
+		links.AssertExpectations(t)
+	})
+
+	t.Run("Error Link Of Another File", func(t *testing.T) {
+		files, links, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		links.On("GetShareLink", ctxWithToken, "link-1").Return(linkFor("2"), nil)
+
+		err := uc.Execute(ctxWithToken, RevokeShareLinkCommand{FileId: "1", LinkId: "link-1"})
+
+		assert.EqualError(t, err, "share link not found")
+		links.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Unauthorized", func(t *testing.T) {
+		files, links, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		files.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		err := uc.Execute(ctxWithToken, RevokeShareLinkCommand{FileId: "1", LinkId: "link-1"})
+
+		assert.EqualError(t, err, "unauthorized")
+		links.AssertNotCalled(t, "GetShareLink", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, _, uc := setup()
+		err := uc.Execute(context.Background(), RevokeShareLinkCommand{FileId: "1", LinkId: "link-1"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+}
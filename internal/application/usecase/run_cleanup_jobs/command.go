@@ -0,0 +1,8 @@
+package runcleanupjobs
+
+// RunCleanupJobsCommand advances up to JobLimit pending jobs by one batch of
+// at most BatchSize files each.
+type RunCleanupJobsCommand struct {
+	JobLimit  int64
+	BatchSize int64
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type CleanupJobRepository interface {
+	ListPending(ctx context.Context, limit int64) ([]domain.CleanupJob, error)
+	Update(ctx context.Context, job domain.CleanupJob) error
+}
@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	ListFiles(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error)
+	Update(ctx context.Context, file domain.File) error
+}
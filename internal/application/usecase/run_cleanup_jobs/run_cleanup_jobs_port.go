@@ -0,0 +1,7 @@
+package runcleanupjobs
+
+import "context"
+
+type IRunCleanupJobsUseCase interface {
+	Execute(ctx context.Context, command RunCleanupJobsCommand) (int64, error)
+}
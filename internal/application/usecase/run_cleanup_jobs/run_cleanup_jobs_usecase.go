@@ -0,0 +1,91 @@
+package runcleanupjobs
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/usecase/run_cleanup_jobs/port"
+	"devconnectstorage/internal/domain"
+	"errors"
+	"fmt"
+)
+
+type RunCleanupJobsUseCase struct {
+	jobs  port.CleanupJobRepository
+	files port.FileRepository
+}
+
+func NewRunCleanupJobsUseCase(jobs port.CleanupJobRepository, files port.FileRepository) *RunCleanupJobsUseCase {
+	return &RunCleanupJobsUseCase{
+		jobs:  jobs,
+		files: files,
+	}
+}
+
+// Execute moves the files of deleted projects and profiles to the trash,
+// where the trash purge removes their content once retention passes. Trashed
+// files drop out of the next listing, so a job simply repeats until a batch
+// comes back short, and rerunning a finished batch is harmless.
+func (uc *RunCleanupJobsUseCase) Execute(ctx context.Context, command RunCleanupJobsCommand) (int64, error) {
+	if command.JobLimit <= 0 || command.BatchSize <= 0 {
+		return 0, apperror.New(apperror.ErrValidation, "jobLimit and batchSize must be positive")
+	}
+
+	jobs, err := uc.jobs.ListPending(ctx, command.JobLimit)
+	if err != nil {
+		return 0, err
+	}
+
+	var trashed int64
+	var runErrors []error
+	for _, job := range jobs {
+		count, err := uc.runBatch(ctx, &job, command.BatchSize)
+		trashed += count
+		if err != nil {
+			runErrors = append(runErrors, fmt.Errorf("cleanup job %s: %w", job.ID(), err))
+		}
+	}
+	return trashed, errors.Join(runErrors...)
+}
+
+func (uc *RunCleanupJobsUseCase) runBatch(ctx context.Context, job *domain.CleanupJob, batchSize int64) (int64, error) {
+	filter := aggregate.FileFilter{Status: domain.StatusAvailable}
+	if job.Scope() == domain.CleanupScopeProject {
+		projectID := job.SubjectID()
+		filter.ProjectID = &projectID
+	} else {
+		filter.OwnerID = job.SubjectID()
+	}
+	files, err := uc.files.ListFiles(ctx, aggregate.FileListing{Filter: filter, Sort: aggregate.SortByCreatedAt, Limit: batchSize})
+	if err != nil {
+		return 0, err
+	}
+
+	var trashed int64
+	var batchErrors []error
+	for _, file := range files {
+		if err := file.MarkAsDeleted(); err != nil {
+			batchErrors = append(batchErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+			continue
+		}
+		if err := uc.files.Update(ctx, file); err != nil {
+			batchErrors = append(batchErrors, fmt.Errorf("file %s: %w", file.ID(), err))
+			continue
+		}
+		trashed++
+	}
+
+	batchError := errors.Join(batchErrors...)
+	if err := job.RecordBatch(trashed, batchError); err != nil {
+		return trashed, err
+	}
+	if batchError == nil && int64(len(files)) < batchSize {
+		if err := job.Complete(); err != nil {
+			return trashed, err
+		}
+	}
+	if err := uc.jobs.Update(ctx, *job); err != nil {
+		return trashed, errors.Join(batchError, err)
+	}
+	return trashed, batchError
+}
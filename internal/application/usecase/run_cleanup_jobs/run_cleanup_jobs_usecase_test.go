@@ -0,0 +1,159 @@
+package runcleanupjobs
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type CleanupJobRepositoryMock struct {
+	ListPendingFn func(ctx context.Context, limit int64) ([]domain.CleanupJob, error)
+	UpdateFn      func(ctx context.Context, job domain.CleanupJob) error
+}
+
+func (m *CleanupJobRepositoryMock) ListPending(ctx context.Context, limit int64) ([]domain.CleanupJob, error) {
+	return m.ListPendingFn(ctx, limit)
+}
+
+func (m *CleanupJobRepositoryMock) Update(ctx context.Context, job domain.CleanupJob) error {
+	return m.UpdateFn(ctx, job)
+}
+
+type FileRepositoryMock struct {
+	ListFilesFn func(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error)
+	UpdateFn    func(ctx context.Context, file domain.File) error
+}
+
+func (m *FileRepositoryMock) ListFiles(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error) {
+	return m.ListFilesFn(ctx, listing)
+}
+
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	return m.UpdateFn(ctx, file)
+}
+
+func pendingJobs(jobs ...domain.CleanupJob) *CleanupJobRepositoryMock {
+	return &CleanupJobRepositoryMock{
+		ListPendingFn: func(ctx context.Context, limit int64) ([]domain.CleanupJob, error) {
+			return jobs, nil
+		},
+	}
+}
+
+func cleanupJob(t *testing.T, id string, scope domain.CleanupScope, subjectID string) domain.CleanupJob {
+	job, err := domain.NewCleanupJob(id, scope, subjectID)
+	require.NoError(t, err)
+	return job
+}
+
+func availableFiles(t *testing.T, count int) []domain.File {
+	files := make([]domain.File, 0, count)
+	for i := range count {
+		file, err := domain.RehydrateFile(fmt.Sprintf("file-%d", i), "12", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+	return files
+}
+
+func TestRunCleanupJobsUseCase_ShouldTrashBatchAndKeepJobPending(t *testing.T) {
+	jobs := pendingJobs(cleanupJob(t, "event-1", domain.CleanupScopeProject, "project-1"))
+	var updatedJob domain.CleanupJob
+	jobs.UpdateFn = func(ctx context.Context, job domain.CleanupJob) error {
+		updatedJob = job
+		return nil
+	}
+	var listing aggregate.FileListing
+	var trashed []domain.File
+	files := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return availableFiles(t, 2), nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			trashed = append(trashed, file)
+			return nil
+		},
+	}
+
+	count, err := NewRunCleanupJobsUseCase(jobs, files).Execute(context.Background(), RunCleanupJobsCommand{JobLimit: 5, BatchSize: 2})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	require.NotNil(t, listing.Filter.ProjectID)
+	assert.Equal(t, "project-1", *listing.Filter.ProjectID)
+	assert.Empty(t, listing.Filter.OwnerID)
+	assert.Equal(t, domain.StatusAvailable, listing.Filter.Status)
+	assert.Equal(t, int64(2), listing.Limit)
+	require.Len(t, trashed, 2)
+	assert.Equal(t, domain.StatusDeleted, trashed[0].Status())
+	assert.Equal(t, domain.CleanupStatusPending, updatedJob.Status())
+	assert.Equal(t, int64(2), updatedJob.TrashedCount())
+}
+
+func TestRunCleanupJobsUseCase_ShouldCompleteJobOnShortBatch(t *testing.T) {
+	jobs := pendingJobs(cleanupJob(t, "event-1", domain.CleanupScopeOwner, "12"))
+	var updatedJob domain.CleanupJob
+	jobs.UpdateFn = func(ctx context.Context, job domain.CleanupJob) error {
+		updatedJob = job
+		return nil
+	}
+	var listing aggregate.FileListing
+	files := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			listing = requested
+			return availableFiles(t, 1), nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return nil
+		},
+	}
+
+	_, err := NewRunCleanupJobsUseCase(jobs, files).Execute(context.Background(), RunCleanupJobsCommand{JobLimit: 5, BatchSize: 10})
+
+	require.NoError(t, err)
+	assert.Equal(t, "12", listing.Filter.OwnerID)
+	assert.Nil(t, listing.Filter.ProjectID)
+	assert.Equal(t, domain.CleanupStatusCompleted, updatedJob.Status())
+}
+
+func TestRunCleanupJobsUseCase_ShouldKeepJobPendingWhenFilesFail(t *testing.T) {
+	jobs := pendingJobs(cleanupJob(t, "event-1", domain.CleanupScopeOwner, "12"))
+	var updatedJob domain.CleanupJob
+	jobs.UpdateFn = func(ctx context.Context, job domain.CleanupJob) error {
+		updatedJob = job
+		return nil
+	}
+	files := &FileRepositoryMock{
+		ListFilesFn: func(ctx context.Context, requested aggregate.FileListing) ([]domain.File, error) {
+			return availableFiles(t, 2), nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			if file.ID() == "file-0" {
+				return errors.New("mongo down")
+			}
+			return nil
+		},
+	}
+
+	count, err := NewRunCleanupJobsUseCase(jobs, files).Execute(context.Background(), RunCleanupJobsCommand{JobLimit: 5, BatchSize: 10})
+
+	assert.ErrorContains(t, err, "mongo down")
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, domain.CleanupStatusPending, updatedJob.Status())
+	assert.Contains(t, updatedJob.LastError(), "file-0")
+}
+
+func TestRunCleanupJobsUseCase_ShouldRejectInvalidLimits(t *testing.T) {
+	_, err := NewRunCleanupJobsUseCase(&CleanupJobRepositoryMock{}, &FileRepositoryMock{}).Execute(context.Background(), RunCleanupJobsCommand{JobLimit: 1})
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
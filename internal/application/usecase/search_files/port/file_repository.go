@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	SearchFiles(ctx context.Context, search aggregate.FileSearch) ([]domain.File, error)
+}
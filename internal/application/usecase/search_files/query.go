@@ -0,0 +1,10 @@
+package searchfiles
+
+// SearchFilesQuery matches Text against file names, tags and descriptions.
+// Project files the caller does not own are only found when ProjectID names
+// a project the caller belongs to.
+type SearchFilesQuery struct {
+	Text      string
+	ProjectID *string
+	Limit     int64
+}
@@ -0,0 +1,10 @@
+package searchfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type ISearchFilesUseCase interface {
+	Execute(ctx context.Context, query SearchFilesQuery) ([]domain.File, error)
+}
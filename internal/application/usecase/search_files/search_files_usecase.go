@@ -0,0 +1,74 @@
+package searchfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/search_files/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"devconnectstorage/internal/infraestructure/outbound/project"
+	"strings"
+)
+
+const (
+	defaultResultCount = 20
+	maxResultCount     = 100
+	maxTextLength      = 200
+)
+
+type SearchFilesUseCase struct {
+	repository       port.FileRepository
+	authClient       auth.IAuthClient
+	membershipClient project.IProjectMembershipClient
+}
+
+func NewSearchFilesUseCase(repository port.FileRepository, authClient auth.IAuthClient, membershipClient project.IProjectMembershipClient) *SearchFilesUseCase {
+	return &SearchFilesUseCase{
+		repository:       repository,
+		authClient:       authClient,
+		membershipClient: membershipClient,
+	}
+}
+
+func (uc *SearchFilesUseCase) Execute(ctx context.Context, query SearchFilesQuery) ([]domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return nil, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return nil, authError
+	}
+
+	text := strings.TrimSpace(query.Text)
+	if text == "" {
+		return nil, apperror.New(apperror.ErrValidation, "search text cannot be empty")
+	}
+	if len(text) > maxTextLength {
+		return nil, apperror.New(apperror.ErrValidation, "search text cannot exceed %d characters", maxTextLength)
+	}
+	limit := query.Limit
+	if limit == 0 {
+		limit = defaultResultCount
+	}
+	if limit < 0 || limit > maxResultCount {
+		return nil, apperror.New(apperror.ErrValidation, "limit must be between 1 and %d", maxResultCount)
+	}
+
+	reader, err := policy.ReaderFor(uc.membershipClient, token.(string), *profileId, query.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	search := aggregate.FileSearch{
+		Text:      text,
+		ProjectID: query.ProjectID,
+		Reader:    reader,
+		Limit:     limit,
+	}
+	return uc.repository.SearchFiles(ctx, search)
+}
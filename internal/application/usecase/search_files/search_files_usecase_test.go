@@ -0,0 +1,136 @@
+package searchfiles
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type FileRepositoryMock struct {
+	SearchFilesFn func(ctx context.Context, search aggregate.FileSearch) ([]domain.File, error)
+}
+
+func (m *FileRepositoryMock) SearchFiles(ctx context.Context, search aggregate.FileSearch) ([]domain.File, error) {
+	return m.SearchFilesFn(ctx, search)
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+type MembershipClientMock struct {
+	IsMemberFn     func(token string, projectID string, profileID int64) (bool, error)
+	ListProjectsFn func(token string, profileID int64) ([]string, error)
+}
+
+func (m *MembershipClientMock) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	return m.IsMemberFn(token, projectID, profileID)
+}
+
+func (m *MembershipClientMock) ListProjects(token string, profileID int64) ([]string, error) {
+	return m.ListProjectsFn(token, profileID)
+}
+
+func validAuth() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
+func TestSearchFilesUseCase_ShouldSearchAsCaller(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var search aggregate.FileSearch
+	repo := &FileRepositoryMock{
+		SearchFilesFn: func(ctx context.Context, requested aggregate.FileSearch) ([]domain.File, error) {
+			search = requested
+			return nil, nil
+		},
+	}
+
+	membership := &MembershipClientMock{
+		ListProjectsFn: func(token string, profileID int64) ([]string, error) {
+			return []string{"project-1", "project-2"}, nil
+		},
+	}
+
+	_, err := NewSearchFilesUseCase(repo, validAuth(), membership).Execute(ctx, SearchFilesQuery{Text: "  architecture diagram "})
+
+	require.NoError(t, err)
+	assert.Equal(t, "architecture diagram", search.Text)
+	assert.Equal(t, "12", search.Reader.ProfileID)
+	assert.Nil(t, search.ProjectID)
+	assert.Equal(t, []string{"project-1", "project-2"}, search.Reader.MemberOf)
+	assert.Equal(t, int64(defaultResultCount), search.Limit)
+}
+
+func TestSearchFilesUseCase_ShouldIncludeProjectFilesForMembers(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	var search aggregate.FileSearch
+	repo := &FileRepositoryMock{
+		SearchFilesFn: func(ctx context.Context, requested aggregate.FileSearch) ([]domain.File, error) {
+			search = requested
+			return nil, nil
+		},
+	}
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, project string, profileID int64) (bool, error) {
+			return true, nil
+		},
+	}
+
+	_, err := NewSearchFilesUseCase(repo, validAuth(), membership).Execute(ctx, SearchFilesQuery{Text: "diagram", ProjectID: &projectID})
+
+	require.NoError(t, err)
+	assert.Equal(t, &projectID, search.ProjectID)
+	assert.Equal(t, []string{projectID}, search.Reader.MemberOf)
+}
+
+func TestSearchFilesUseCase_ShouldReturnMembershipError(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	projectID := "project-1"
+	membership := &MembershipClientMock{
+		IsMemberFn: func(token string, project string, profileID int64) (bool, error) {
+			return false, errors.New("project service down")
+		},
+	}
+
+	_, err := NewSearchFilesUseCase(&FileRepositoryMock{}, validAuth(), membership).Execute(ctx, SearchFilesQuery{Text: "diagram", ProjectID: &projectID})
+
+	assert.EqualError(t, err, "project service down")
+}
+
+func TestSearchFilesUseCase_ShouldRejectInvalidQuery(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	uc := NewSearchFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{})
+
+	for name, query := range map[string]SearchFilesQuery{
+		"empty":    {Text: "   "},
+		"too long": {Text: strings.Repeat("a", maxTextLength+1)},
+		"limit":    {Text: "diagram", Limit: maxResultCount + 1},
+	} {
+		_, err := uc.Execute(ctx, query)
+		assert.ErrorIs(t, err, apperror.ErrValidation, name)
+	}
+}
+
+func TestSearchFilesUseCase_ShouldFailWithoutToken(t *testing.T) {
+	_, err := NewSearchFilesUseCase(&FileRepositoryMock{}, validAuth(), &MembershipClientMock{}).Execute(context.Background(), SearchFilesQuery{Text: "diagram"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
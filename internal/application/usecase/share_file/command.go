@@ -0,0 +1,7 @@
+package sharefile
+
+type ShareFileCommand struct {
+	FileId     string
+	ProfileID  string
+	Permission string
+}
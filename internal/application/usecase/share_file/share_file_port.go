@@ -0,0 +1,10 @@
+package sharefile
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IShareFileUseCase interface {
+	Execute(ctx context.Context, command ShareFileCommand) (domain.File, error)
+}
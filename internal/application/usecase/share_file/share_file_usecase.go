@@ -0,0 +1,50 @@
+package sharefile
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/share_file/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type ShareFileUseCase struct {
+	repository port.FileRepository
+	authClient auth.IAuthClient
+}
+
+func NewShareFileUseCase(repository port.FileRepository, authClient auth.IAuthClient) *ShareFileUseCase {
+	return &ShareFileUseCase{
+		repository: repository,
+		authClient: authClient,
+	}
+}
+
+func (uc *ShareFileUseCase) Execute(ctx context.Context, command ShareFileCommand) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if err := file.ShareWith(strconv.FormatInt(*profileId, 10), command.ProfileID, domain.Permission(command.Permission)); err != nil {
+		return domain.File{}, err
+	}
+
+	if err := uc.repository.Update(ctx, file); err != nil {
+		return domain.File{}, err
+	}
+	return file, nil
+}
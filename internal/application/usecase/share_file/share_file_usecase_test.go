@@ -0,0 +1,123 @@
+package sharefile
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestShareFileUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*RepositoryMock, *AuthClientMock, *ShareFileUseCase) {
+		repo := new(RepositoryMock)
+		authCli := new(AuthClientMock)
+		uc := NewShareFileUseCase(repo, authCli)
+		return repo, authCli, uc
+	}
+
+	availableFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			permission, shared := file.PermissionFor("456")
+			return shared && permission == domain.PermissionRead
+		})).Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, ShareFileCommand{FileId: "1", ProfileID: "456", Permission: "READ"})
+
+		assert.NoError(t, err)
+		assert.Len(t, file.Shares(), 1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, uc := setup()
+		_, err := uc.Execute(context.Background(), ShareFileCommand{FileId: "1", ProfileID: "456", Permission: "READ"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unauthorized", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var otherID int64 = 789
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, ShareFileCommand{FileId: "1", ProfileID: "456", Permission: "READ"})
+
+		assert.EqualError(t, err, "unauthorized")
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Invalid Permission", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, ShareFileCommand{FileId: "1", ProfileID: "456", Permission: "WRITE"})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Update", func(t *testing.T) {
+		repo, authCli, uc := setup()
+		var ownerID int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, ShareFileCommand{FileId: "1", ProfileID: "456", Permission: "MANAGE"})
+
+		assert.Equal(t, expectedErr, err)
+	})
+}
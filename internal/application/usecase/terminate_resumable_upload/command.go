@@ -0,0 +1,5 @@
+package terminateresumableupload
+
+type TerminateResumableUploadCommand struct {
+	Id string
+}
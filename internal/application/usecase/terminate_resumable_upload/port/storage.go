@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type Storage interface {
+	AbortMultipartUpload(ctx context.Context, file domain.File, uploadID string) error
+	DeleteUploadTail(ctx context.Context, file domain.File) error
+}
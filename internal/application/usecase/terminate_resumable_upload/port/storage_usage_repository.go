@@ -0,0 +1,7 @@
+package port
+
+import "context"
+
+type StorageUsageRepository interface {
+	Release(ctx context.Context, ownerID string, bytes int64, files int64) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type UploadSessionRepository interface {
+	GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error)
+	Delete(ctx context.Context, id string) error
+}
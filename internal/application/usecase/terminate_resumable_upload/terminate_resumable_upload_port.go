@@ -0,0 +1,7 @@
+package terminateresumableupload
+
+import "context"
+
+type ITerminateResumableUploadUseCase interface {
+	Execute(ctx context.Context, command TerminateResumableUploadCommand) error
+}
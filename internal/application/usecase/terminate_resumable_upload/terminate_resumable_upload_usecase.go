@@ -0,0 +1,71 @@
+package terminateresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/terminate_resumable_upload/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"strconv"
+)
+
+type TerminateResumableUploadUseCase struct {
+	fileRepository    port.FileRepository
+	sessionRepository port.UploadSessionRepository
+	storage           port.Storage
+	authClient        auth.IAuthClient
+	usage             port.StorageUsageRepository
+}
+
+func NewTerminateResumableUploadUseCase(fileRepository port.FileRepository, sessionRepository port.UploadSessionRepository, storage port.Storage, authClient auth.IAuthClient, usage port.StorageUsageRepository) *TerminateResumableUploadUseCase {
+	return &TerminateResumableUploadUseCase{
+		fileRepository:    fileRepository,
+		sessionRepository: sessionRepository,
+		storage:           storage,
+		authClient:        authClient,
+		usage:             usage,
+	}
+}
+
+func (uc *TerminateResumableUploadUseCase) Execute(ctx context.Context, command TerminateResumableUploadCommand) error {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return authError
+	}
+
+	session, err := uc.sessionRepository.GetUploadSession(ctx, command.Id)
+	if err != nil {
+		return err
+	}
+	if session.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+
+	file, err := uc.fileRepository.GetFile(ctx, session.ID())
+	if err != nil {
+		return err
+	}
+	if file.Status() != domain.StatusPending {
+		return apperror.New(apperror.ErrConflict, "upload cannot be terminated from %s", file.Status())
+	}
+
+	if err := uc.storage.AbortMultipartUpload(ctx, file, session.MultipartID()); err != nil {
+		return err
+	}
+	_ = uc.storage.DeleteUploadTail(ctx, file)
+
+	if err := uc.sessionRepository.Delete(ctx, session.ID()); err != nil {
+		return err
+	}
+	if err := uc.fileRepository.DeleteFile(ctx, file.ID()); err != nil {
+		return err
+	}
+	return uc.usage.Release(ctx, file.OwnerID(), session.Length(), 1)
+}
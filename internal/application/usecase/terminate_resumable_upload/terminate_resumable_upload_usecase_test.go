@@ -0,0 +1,172 @@
+package terminateresumableupload
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FileRepositoryMock struct {
+	GetFileFn    func(ctx context.Context, id string) (domain.File, error)
+	DeleteFileFn func(ctx context.Context, id string) error
+}
+
+func (m *FileRepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	return m.GetFileFn(ctx, id)
+}
+
+func (m *FileRepositoryMock) DeleteFile(ctx context.Context, id string) error {
+	return m.DeleteFileFn(ctx, id)
+}
+
+type UploadSessionRepositoryMock struct {
+	GetUploadSessionFn func(ctx context.Context, id string) (domain.UploadSession, error)
+	DeleteFn           func(ctx context.Context, id string) error
+}
+
+func (m *UploadSessionRepositoryMock) GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error) {
+	return m.GetUploadSessionFn(ctx, id)
+}
+
+func (m *UploadSessionRepositoryMock) Delete(ctx context.Context, id string) error {
+	return m.DeleteFn(ctx, id)
+}
+
+type StorageMock struct {
+	AbortMultipartUploadFn func(ctx context.Context, file domain.File, uploadID string) error
+	DeleteUploadTailFn     func(ctx context.Context, file domain.File) error
+}
+
+func (m *StorageMock) AbortMultipartUpload(ctx context.Context, file domain.File, uploadID string) error {
+	return m.AbortMultipartUploadFn(ctx, file, uploadID)
+}
+
+func (m *StorageMock) DeleteUploadTail(ctx context.Context, file domain.File) error {
+	return m.DeleteUploadTailFn(ctx, file)
+}
+
+type StorageUsageRepositoryMock struct {
+	released []string
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	m.released = append(m.released, fmt.Sprintf("%s:%d:%d", ownerID, bytes, files))
+	return nil
+}
+
+type AuthClientMock struct {
+	GetProfileFn func(token string) (*int64, error)
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	return m.GetProfileFn(token)
+}
+
+func authClientFor(profileID int64) *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			return &profileID, nil
+		},
+	}
+}
+
+func sessionRepository(deleted *[]string) *UploadSessionRepositoryMock {
+	return &UploadSessionRepositoryMock{
+		GetUploadSessionFn: func(ctx context.Context, id string) (domain.UploadSession, error) {
+			return domain.RehydrateUploadSession(id, "12", "multipart-1", 10, nil, 0, 0, time.Now(), time.Now().Add(time.Hour))
+		},
+		DeleteFn: func(ctx context.Context, id string) error {
+			*deleted = append(*deleted, "session:"+id)
+			return nil
+		},
+	}
+}
+
+func fileRepository(status domain.Status, deleted *[]string) *FileRepositoryMock {
+	return &FileRepositoryMock{
+		GetFileFn: func(ctx context.Context, id string) (domain.File, error) {
+			return domain.RehydrateFile(id, "12", nil, "demo.mp4", "video/mp4", 10, "", domain.VisibilityPrivate, status, time.Now())
+		},
+		DeleteFileFn: func(ctx context.Context, id string) error {
+			*deleted = append(*deleted, "file:"+id)
+			return nil
+		},
+	}
+}
+
+func TestTerminateResumableUploadUseCase_ShouldReleaseEverything(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var deleted []string
+	var abortedUploadID string
+	storage := &StorageMock{
+		AbortMultipartUploadFn: func(ctx context.Context, file domain.File, uploadID string) error {
+			abortedUploadID = uploadID
+			return nil
+		},
+		DeleteUploadTailFn: func(ctx context.Context, file domain.File) error {
+			deleted = append(deleted, "tail:"+file.ID())
+			return nil
+		},
+	}
+	usage := &StorageUsageRepositoryMock{}
+	uc := NewTerminateResumableUploadUseCase(fileRepository(domain.StatusPending, &deleted), sessionRepository(&deleted), storage, authClientFor(12), usage)
+
+	err := uc.Execute(ctx, TerminateResumableUploadCommand{Id: "1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart-1", abortedUploadID)
+	assert.Equal(t, []string{"tail:1", "session:1", "file:1"}, deleted)
+	assert.Equal(t, []string{"12:10:1"}, usage.released)
+}
+
+func TestTerminateResumableUploadUseCase_ShouldRejectOtherProfiles(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var deleted []string
+	uc := NewTerminateResumableUploadUseCase(fileRepository(domain.StatusPending, &deleted), sessionRepository(&deleted), &StorageMock{}, authClientFor(99), &StorageUsageRepositoryMock{})
+
+	err := uc.Execute(ctx, TerminateResumableUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "unauthorized")
+	assert.Empty(t, deleted)
+}
+
+func TestTerminateResumableUploadUseCase_ShouldNotTerminateFinishedUpload(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var deleted []string
+	uc := NewTerminateResumableUploadUseCase(fileRepository(domain.StatusAvailable, &deleted), sessionRepository(&deleted), &StorageMock{}, authClientFor(12), &StorageUsageRepositoryMock{})
+
+	err := uc.Execute(ctx, TerminateResumableUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "upload cannot be terminated from AVAILABLE")
+	assert.Empty(t, deleted)
+}
+
+func TestTerminateResumableUploadUseCase_ShouldKeepRecordsWhenAbortFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "token")
+	var deleted []string
+	storage := &StorageMock{
+		AbortMultipartUploadFn: func(ctx context.Context, file domain.File, uploadID string) error {
+			return errors.New("storage error")
+		},
+	}
+	uc := NewTerminateResumableUploadUseCase(fileRepository(domain.StatusPending, &deleted), sessionRepository(&deleted), storage, authClientFor(12), &StorageUsageRepositoryMock{})
+
+	err := uc.Execute(ctx, TerminateResumableUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "storage error")
+	assert.Empty(t, deleted)
+}
+
+func TestTerminateResumableUploadUseCase_ShouldFailWithoutToken(t *testing.T) {
+	uc := NewTerminateResumableUploadUseCase(&FileRepositoryMock{}, &UploadSessionRepositoryMock{}, &StorageMock{}, authClientFor(12), &StorageUsageRepositoryMock{})
+
+	err := uc.Execute(context.Background(), TerminateResumableUploadCommand{Id: "1"})
+
+	assert.EqualError(t, err, "token cannot be null")
+}
@@ -0,0 +1,14 @@
+package updatefilemetadata
+
+// UpdateFileMetadataCommand leaves nil fields unchanged. Tags and Attributes
+// replace the current ones when set, so an empty value clears them. An empty
+// Path takes the file out of its project's folder tree.
+type UpdateFileMetadataCommand struct {
+	Id         string
+	FileName   *string
+	Visibility *string
+	ProjectID  *string
+	Tags       []string
+	Attributes map[string]string
+	Path       *string
+}
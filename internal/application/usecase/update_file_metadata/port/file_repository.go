@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type FileRepository interface {
+	GetFile(ctx context.Context, id string) (domain.File, error)
+	Update(ctx context.Context, file domain.File) error
+}
@@ -0,0 +1,11 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type Storage interface {
+	CopyObject(ctx context.Context, sourceKey string, file domain.File, version int) (string, error)
+	DeleteObject(ctx context.Context, storageKey string) error
+}
@@ -0,0 +1,10 @@
+package updatefilemetadata
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IUpdateFileMetadataUseCase interface {
+	Execute(ctx context.Context, command UpdateFileMetadataCommand) (domain.File, error)
+}
@@ -0,0 +1,134 @@
+package updatefilemetadata
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/usecase/update_file_metadata/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strconv"
+)
+
+type UpdateFileMetadataUseCase struct {
+	repository port.FileRepository
+	storage    port.Storage
+	authClient auth.IAuthClient
+}
+
+func NewUpdateFileMetadataUseCase(repository port.FileRepository, storage port.Storage, authClient auth.IAuthClient) *UpdateFileMetadataUseCase {
+	return &UpdateFileMetadataUseCase{
+		repository: repository,
+		storage:    storage,
+		authClient: authClient,
+	}
+}
+
+func (uc *UpdateFileMetadataUseCase) Execute(ctx context.Context, command UpdateFileMetadataCommand) (domain.File, error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.Id)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	requesterID := strconv.FormatInt(*profileId, 10)
+	if command.FileName != nil {
+		if err := file.Rename(requesterID, *command.FileName); err != nil {
+			return domain.File{}, err
+		}
+	}
+	if command.Visibility != nil {
+		if err := file.ChangeVisibility(requesterID, domain.Visibility(*command.Visibility)); err != nil {
+			return domain.File{}, err
+		}
+	}
+	if command.Tags != nil {
+		if err := file.ChangeTags(requesterID, command.Tags); err != nil {
+			return domain.File{}, err
+		}
+	}
+	if command.Attributes != nil {
+		if err := file.ChangeAttributes(requesterID, command.Attributes); err != nil {
+			return domain.File{}, err
+		}
+	}
+	// A path is cleared before and set after the project changes, since only
+	// files in a project may have one.
+	if command.Path != nil && *command.Path == "" {
+		if err := file.ChangePath(requesterID, ""); err != nil {
+			return domain.File{}, err
+		}
+	}
+	if command.ProjectID != nil {
+		var projectID *string
+		if *command.ProjectID != "" {
+			projectID = command.ProjectID
+		}
+		if err := file.MoveToProject(requesterID, projectID); err != nil {
+			return domain.File{}, err
+		}
+	}
+	if command.Path != nil && *command.Path != "" {
+		if err := file.ChangePath(requesterID, *command.Path); err != nil {
+			return domain.File{}, err
+		}
+	}
+
+	previousKeys, err := uc.relocate(ctx, &file)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if err := uc.repository.Update(ctx, file); err != nil {
+		for _, version := range file.Versions() {
+			if _, moved := previousKeys[version.StorageKey()]; !moved {
+				continue
+			}
+			if deleteError := uc.storage.DeleteObject(ctx, version.StorageKey()); deleteError != nil {
+				err = errors.Join(err, deleteError)
+			}
+		}
+		return domain.File{}, err
+	}
+
+	for _, previousKey := range previousKeys {
+		_ = uc.storage.DeleteObject(ctx, previousKey)
+	}
+
+	return file, nil
+}
+
+func (uc *UpdateFileMetadataUseCase) relocate(ctx context.Context, file *domain.File) (map[string]string, error) {
+	previousKeys := make(map[string]string)
+	for _, version := range file.Versions() {
+		if version.ContentAddressed() {
+			continue
+		}
+		newKey, err := uc.storage.CopyObject(ctx, version.StorageKey(), *file, version.Number())
+		if err != nil {
+			for copiedKey := range previousKeys {
+				_ = uc.storage.DeleteObject(ctx, copiedKey)
+			}
+			return nil, err
+		}
+		if newKey == version.StorageKey() {
+			continue
+		}
+		if err := file.RelocateVersion(version.Number(), newKey); err != nil {
+			return nil, err
+		}
+		previousKeys[newKey] = version.StorageKey()
+	}
+	return previousKeys, nil
+}
@@ -0,0 +1,296 @@
+package updatefilemetadata
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type StorageMock struct {
+	mock.Mock
+}
+
+func (m *StorageMock) CopyObject(ctx context.Context, sourceKey string, file domain.File, version int) (string, error) {
+	args := m.Called(ctx, sourceKey, file, version)
+	return args.String(0), args.Error(1)
+}
+
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	args := m.Called(ctx, storageKey)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestUpdateFileMetadataUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+
+	setup := func() (*RepositoryMock, *StorageMock, *AuthClientMock, *UpdateFileMetadataUseCase) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		authCli := new(AuthClientMock)
+		uc := NewUpdateFileMetadataUseCase(repo, storage, authCli)
+		return repo, storage, authCli, uc
+	}
+
+	availableFile := func() domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "123/1/a.txt", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+		return file
+	}
+
+	stringPtr := func(s string) *string { return &s }
+
+	t.Run("Success Changing Visibility Keeps Object", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("123/1/a.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.Visibility() == domain.VisibilityPublic && file.StorageKey() == "123/1/a.txt"
+		})).Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", Visibility: stringPtr("PUBLIC")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.VisibilityPublic, file.Visibility())
+		storage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success Replacing Tags And Attributes", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		file, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "123/1/a.txt", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithTags([]string{"old"}))
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(file, nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("123/1/a.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return len(file.Tags()) == 2 && file.Attributes()["kind"] == "avatar"
+		})).Return(nil)
+
+		updated, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{
+			Id:         "1",
+			Tags:       []string{"Avatar", "profile"},
+			Attributes: map[string]string{"kind": "avatar"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"avatar", "profile"}, updated.Tags())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Error Invalid Tags", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", Tags: []string{""}})
+
+		assert.ErrorIs(t, err, apperror.ErrValidation)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Placing File In New Project", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("123/1/a.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.Path() == "docs/a.txt"
+		})).Return(nil)
+
+		updated, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", ProjectID: stringPtr("project-1"), Path: stringPtr("/docs/a.txt")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "docs/a.txt", updated.Path())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success Clearing Path And Project", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		projectID := "project-1"
+		file, _ := domain.RehydrateFile("1", "123", &projectID, "a.txt", "text/plain", 1, "123/1/a.txt", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithPath("docs/a.txt"))
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(file, nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("123/1/a.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.Path() == "" && file.ProjectID() == nil
+		})).Return(nil)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", ProjectID: stringPtr(""), Path: stringPtr("")})
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Success Rename Moves Object", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.MatchedBy(func(file domain.File) bool {
+			return file.FileName() == "b.txt"
+		}), 1).Return("123/1/b.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.FileName() == "b.txt" && file.StorageKey() == "123/1/b.txt"
+		})).Return(nil)
+		storage.On("DeleteObject", ctxWithToken, "123/1/a.txt").Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", FileName: stringPtr("b.txt")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "123/1/b.txt", file.StorageKey())
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Success Rename Leaves Content-Addressed Blob In Place", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+		version, _ := domain.RehydrateFileVersion(1, "blobs/2c/"+checksum, "text/plain", 1, checksum, true, time.Now())
+		existing, _ := domain.RehydrateFile("1", "123", nil, "a.txt", "text/plain", 1, "blobs/2c/"+checksum, domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithChecksum(checksum), domain.WithVersions(1, []domain.FileVersion{version}))
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existing, nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil)
+
+		file, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", FileName: stringPtr("b.txt")})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "blobs/2c/"+checksum, file.StorageKey())
+		storage.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		storage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Success Detach Project", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		projectID := "p1"
+		file, _ := domain.RehydrateFile("1", "123", &projectID, "a.txt", "text/plain", 1, "123/1/p1/a.txt", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(file, nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/p1/a.txt", mock.Anything, 1).Return("123/1/a.txt", nil)
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool {
+			return file.ProjectID() == nil
+		})).Return(nil)
+		storage.On("DeleteObject", ctxWithToken, "123/1/p1/a.txt").Return(nil)
+
+		updated, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", ProjectID: stringPtr("")})
+
+		assert.NoError(t, err)
+		assert.Nil(t, updated.ProjectID())
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, _, uc := setup()
+		_, err := uc.Execute(context.Background(), UpdateFileMetadataCommand{Id: "1"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unauthorized Owner", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", FileName: stringPtr("b.txt")})
+
+		assert.EqualError(t, err, "unauthorized")
+		storage.AssertNotCalled(t, "CopyObject", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error Invalid Visibility", func(t *testing.T) {
+		repo, _, authCli, uc := setup()
+		var ownerID int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", Visibility: stringPtr("SECRET")})
+
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Copy", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		expectedErr := errors.New("copy failed")
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("", expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", FileName: stringPtr("b.txt")})
+
+		assert.Equal(t, expectedErr, err)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Update Removes Copies", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerID int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(availableFile(), nil)
+		storage.On("CopyObject", ctxWithToken, "123/1/a.txt", mock.Anything, 1).Return("123/1/b.txt", nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr)
+		storage.On("DeleteObject", ctxWithToken, "123/1/b.txt").Return(nil)
+
+		_, err := uc.Execute(ctxWithToken, UpdateFileMetadataCommand{Id: "1", FileName: stringPtr("b.txt")})
+
+		assert.ErrorIs(t, err, expectedErr)
+		storage.AssertCalled(t, "DeleteObject", ctxWithToken, "123/1/b.txt")
+		storage.AssertNotCalled(t, "DeleteObject", ctxWithToken, "123/1/a.txt")
+	})
+}
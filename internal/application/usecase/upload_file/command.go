@@ -3,10 +3,14 @@ package uploadfile
 import "io"
 
 type UploadFileCommand struct {
-	ProjectID  *string
-	FileName   string
-	MimeType   string
-	Size       int64
-	Visibility string
-	Content    io.Reader
+	ProjectID        *string
+	FileName         string
+	MimeType         string
+	Size             int64
+	Visibility       string
+	Content          io.Reader
+	ExpectedChecksum string
+	Tags             []string
+	Attributes       map[string]string
+	Path             string
 }
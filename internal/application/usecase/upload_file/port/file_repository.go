@@ -7,4 +7,6 @@ import (
 
 type FileRepository interface {
 	Save(ctx context.Context, file domain.File) (domain.File, error)
+	Update(ctx context.Context, file domain.File) error
+	HasContent(ctx context.Context, ownerID string, checksum string) (bool, error)
 }
@@ -8,5 +8,6 @@ import (
 
 type Storage interface {
 	SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error)
-	DeleteFile(ctx context.Context, file domain.File) error
+	DeleteObject(ctx context.Context, storageKey string) error
+	PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error)
 }
@@ -2,6 +2,11 @@ package uploadfile
 
 import (
 	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/checksum"
+	"devconnectstorage/internal/application/ingest"
+	"devconnectstorage/internal/application/mimesniff"
+	"devconnectstorage/internal/application/policy"
 	"devconnectstorage/internal/application/usecase/upload_file/port"
 	"devconnectstorage/internal/domain"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
@@ -14,23 +19,33 @@ type UploadFileUseCase struct {
 	storage        port.Storage
 	generator      port.IdGenerator
 	authClient     auth.IAuthClient
+	uploadPolicy   policy.UploadPolicy
+	usage          port.StorageUsageRepository
+	quota          policy.StorageQuota
+	blobs          port.BlobRepository
 }
 
-func NewUploadFileUseCase(repo port.FileRepository, storage port.Storage, generator port.IdGenerator, authClient auth.IAuthClient) *UploadFileUseCase {
+// NewUploadFileUseCase stores content under per-file keys; passing a blob
+// repository switches to content-addressed storage shared between files.
+func NewUploadFileUseCase(repo port.FileRepository, storage port.Storage, generator port.IdGenerator, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, usage port.StorageUsageRepository, quota policy.StorageQuota, blobs port.BlobRepository) *UploadFileUseCase {
 	return &UploadFileUseCase{
 		fileRepository: repo,
 		storage:        storage,
 		generator:      generator,
 		authClient:     authClient,
+		uploadPolicy:   uploadPolicy,
+		usage:          usage,
+		quota:          quota,
+		blobs:          blobs,
 	}
 }
 
-func (uc UploadFileUseCase) Execute(ctx context.Context, saveCommand UploadFileCommand) (domain.File, error) {
+func (uc UploadFileUseCase) Execute(ctx context.Context, saveCommand UploadFileCommand) (_ domain.File, err error) {
 
 	token := ctx.Value(auth.AuthTokenKey)
 
 	if token == nil {
-		return domain.File{}, errors.New("token cannot be null")
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
 	}
 
 	profileId, authError := uc.authClient.GetProfile(token.(string))
@@ -39,30 +54,152 @@ func (uc UploadFileUseCase) Execute(ctx context.Context, saveCommand UploadFileC
 		return domain.File{}, authError
 	}
 
-	file, domainErr := domain.NewFile(uc.generator.Generate(), strconv.FormatInt(*profileId, 10), saveCommand.ProjectID, saveCommand.FileName, saveCommand.MimeType, saveCommand.Size, domain.Visibility(saveCommand.Visibility))
+	ownerID := strconv.FormatInt(*profileId, 10)
+	var reserved int64
+	defer func() {
+		if err != nil && reserved > 0 {
+			err = uc.releaseQuota(ctx, ownerID, reserved, err)
+		}
+	}()
+
+	detection, upload, err := mimesniff.Detect(saveCommand.Content)
+	if err != nil {
+		return domain.File{}, err
+	}
+	mimeType, err := uc.uploadPolicy.ResolveMimeType(saveCommand.ProjectID, saveCommand.MimeType, detection)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	candidate := policy.UploadCandidate{
+		ProjectID:  saveCommand.ProjectID,
+		FileName:   saveCommand.FileName,
+		MimeType:   mimeType,
+		Size:       saveCommand.Size,
+		Visibility: domain.Visibility(saveCommand.Visibility),
+	}
+	if err := uc.uploadPolicy.Check(candidate); err != nil {
+		return domain.File{}, err
+	}
+	if candidate.Size != domain.UnknownSize {
+		if err := uc.reserveQuota(ctx, ownerID, candidate.Size); err != nil {
+			return domain.File{}, err
+		}
+		reserved = candidate.Size
+	}
+
+	file, domainErr := domain.NewFile(uc.generator.Generate(), ownerID, saveCommand.ProjectID, saveCommand.FileName, mimeType, saveCommand.Size, domain.Visibility(saveCommand.Visibility))
 	if domainErr != nil {
 		return domain.File{}, domainErr
 	}
+	if err := file.RecordDetectedMimeType(detection.MimeType()); err != nil {
+		return domain.File{}, err
+	}
+	if err := file.Annotate(saveCommand.Tags, saveCommand.Attributes); err != nil {
+		return domain.File{}, err
+	}
+	if err := file.PlaceAt(saveCommand.Path); err != nil {
+		return domain.File{}, err
+	}
 
-	storageKey, storageErr := uc.storage.SaveFile(ctx, saveCommand.Content, file)
+	file, saveError := uc.fileRepository.Save(ctx, file)
+	if saveError != nil {
+		return domain.File{}, saveError
+	}
 
-	if storageErr != nil {
-		return domain.File{}, storageErr
+	if uc.blobs != nil && saveCommand.ExpectedChecksum != "" && file.Size() != domain.UnknownSize {
+		blob, found, err := uc.instantBlob(ctx, ownerID, saveCommand.ExpectedChecksum)
+		if err != nil {
+			return domain.File{}, uc.markAsFailed(ctx, file, err)
+		}
+		if found {
+			if err := uc.ingester().Link(ctx, &file, blob); err != nil {
+				return domain.File{}, err
+			}
+			return file, nil
+		}
 	}
 
-	if err := file.MarkAsAvailable(storageKey); err != nil {
-		return domain.File{}, err
+	if file.Size() == domain.UnknownSize {
+		upload = policy.LimitReader(upload, uc.uploadPolicy.MaxSize(candidate))
 	}
 
-	file, saveError := uc.fileRepository.Save(ctx, file)
+	content := checksum.NewReader(upload)
+	storageKey, storageErr := uc.storage.SaveFile(ctx, content, file)
+	if storageErr != nil {
+		return domain.File{}, uc.markAsFailed(ctx, file, storageErr)
+	}
 
-	if saveError != nil {
-		deleteError := uc.storage.DeleteFile(ctx, file)
-		if deleteError != nil {
-			return domain.File{}, deleteError
+	if err := content.Verify(saveCommand.ExpectedChecksum); err != nil {
+		return domain.File{}, uc.discard(ctx, file, storageKey, err)
+	}
+
+	if file.Size() == domain.UnknownSize {
+		if err := file.RecordStoredSize(content.Size()); err != nil {
+			return domain.File{}, uc.discard(ctx, file, storageKey, err)
 		}
-		return domain.File{}, saveError
+		if err := uc.reserveQuota(ctx, ownerID, content.Size()); err != nil {
+			return domain.File{}, uc.discard(ctx, file, storageKey, err)
+		}
+		reserved = content.Size()
+	}
+
+	if err := file.RecordChecksum(content.Sum()); err != nil {
+		return domain.File{}, uc.discard(ctx, file, storageKey, err)
+	}
+
+	if err := uc.ingester().Publish(ctx, &file, storageKey); err != nil {
+		return domain.File{}, err
 	}
 
 	return file, nil
 }
+
+// instantBlob finds the blob an upload may link without sending its content.
+// A checksum alone proves nothing, so only content the owner already stores
+// qualifies; anything else is streamed and verified.
+func (uc UploadFileUseCase) instantBlob(ctx context.Context, ownerID string, checksum string) (domain.Blob, bool, error) {
+	owned, err := uc.fileRepository.HasContent(ctx, ownerID, checksum)
+	if err != nil || !owned {
+		return domain.Blob{}, false, err
+	}
+	blob, err := uc.blobs.GetBlob(ctx, checksum)
+	if errors.Is(err, domain.ErrBlobNotFound) {
+		return domain.Blob{}, false, nil
+	}
+	return blob, err == nil, err
+}
+
+// reserveQuota charges bytes and one file to ownerID's usage before content
+// is kept, failing when the upload would exceed the owner's quota.
+func (uc UploadFileUseCase) reserveQuota(ctx context.Context, ownerID string, bytes int64) error {
+	if uc.usage == nil {
+		return nil
+	}
+	_, err := uc.usage.Reserve(ctx, ownerID, bytes, 1, uc.quota.LimitFor(ownerID))
+	return err
+}
+
+func (uc UploadFileUseCase) releaseQuota(ctx context.Context, ownerID string, bytes int64, cause error) error {
+	if uc.usage == nil {
+		return cause
+	}
+	if err := uc.usage.Release(ctx, ownerID, bytes, 1); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
+
+func (uc UploadFileUseCase) discard(ctx context.Context, file domain.File, storageKey string, cause error) error {
+	return uc.ingester().Discard(ctx, &file, storageKey, cause)
+}
+
+func (uc UploadFileUseCase) markAsFailed(ctx context.Context, file domain.File, cause error) error {
+	return uc.ingester().MarkAsFailed(ctx, &file, cause)
+}
+
+// ingester finishes uploads the same way presigned and resumable uploads are
+// finished once their content is in storage.
+func (uc UploadFileUseCase) ingester() ingest.Ingester {
+	return ingest.NewIngester(uc.fileRepository, uc.storage, uc.uploadPolicy, uc.blobs)
+}
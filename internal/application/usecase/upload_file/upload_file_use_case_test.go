@@ -3,34 +3,86 @@ package uploadfile
 import (
 	"bytes"
 	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
 	"devconnectstorage/internal/domain"
 	"devconnectstorage/internal/infraestructure/outbound/auth"
 	"errors"
 	"io"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
 type FileRepositoryMock struct {
-	SaveFn func(ctx context.Context, file domain.File) (domain.File, error)
+	SaveFn       func(ctx context.Context, file domain.File) (domain.File, error)
+	UpdateFn     func(ctx context.Context, file domain.File) error
+	HasContentFn func(ctx context.Context, ownerID string, checksum string) (bool, error)
 }
 
 func (m *FileRepositoryMock) Save(ctx context.Context, file domain.File) (domain.File, error) {
 	return m.SaveFn(ctx, file)
 }
 
+func (m *FileRepositoryMock) Update(ctx context.Context, file domain.File) error {
+	return m.UpdateFn(ctx, file)
+}
+
+func (m *FileRepositoryMock) HasContent(ctx context.Context, ownerID string, checksum string) (bool, error) {
+	if m.HasContentFn == nil {
+		return false, nil
+	}
+	return m.HasContentFn(ctx, ownerID, checksum)
+}
+
 type FileStorageMock struct {
-	SaveFileFn   func(ctx context.Context, content io.Reader, file domain.File) (string, error)
-	DeleteFileFn func(ctx context.Context, file domain.File) error
+	SaveFileFn     func(ctx context.Context, content io.Reader, file domain.File) (string, error)
+	DeleteObjectFn func(ctx context.Context, storageKey string) error
+	PromoteBlobFn  func(ctx context.Context, sourceKey string, checksum string) (string, error)
+}
+
+func (m *FileStorageMock) PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error) {
+	return m.PromoteBlobFn(ctx, sourceKey, checksum)
 }
 
 func (m *FileStorageMock) SaveFile(ctx context.Context, content io.Reader, file domain.File) (string, error) {
 	return m.SaveFileFn(ctx, content, file)
 }
 
-func (m *FileStorageMock) DeleteFile(ctx context.Context, file domain.File) error {
-	return m.DeleteFileFn(ctx, file)
+func (m *FileStorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	return m.DeleteObjectFn(ctx, storageKey)
+}
+
+type BlobRepositoryMock struct {
+	GetBlobFn func(ctx context.Context, checksum string) (domain.Blob, error)
+	AcquireFn func(ctx context.Context, blob domain.Blob) (domain.Blob, error)
+	ReleaseFn func(ctx context.Context, checksum string) (bool, error)
+}
+
+func (m *BlobRepositoryMock) GetBlob(ctx context.Context, checksum string) (domain.Blob, error) {
+	return m.GetBlobFn(ctx, checksum)
+}
+
+func (m *BlobRepositoryMock) Acquire(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+	return m.AcquireFn(ctx, blob)
+}
+
+func (m *BlobRepositoryMock) Release(ctx context.Context, checksum string) (bool, error) {
+	return m.ReleaseFn(ctx, checksum)
+}
+
+type StorageUsageRepositoryMock struct {
+	ReserveFn func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error)
+	ReleaseFn func(ctx context.Context, ownerID string, bytes int64, files int64) error
+}
+
+func (m *StorageUsageRepositoryMock) Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+	return m.ReserveFn(ctx, ownerID, bytes, files, limit)
+}
+
+func (m *StorageUsageRepositoryMock) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	return m.ReleaseFn(ctx, ownerID, bytes, files)
 }
 
 type IdGeneratorMock struct{}
@@ -47,34 +99,177 @@ func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
 	return m.GetProfileFn(token)
 }
 
+func validAuthClient() *AuthClientMock {
+	return &AuthClientMock{
+		GetProfileFn: func(token string) (*int64, error) {
+			var result int64 = 12
+			return &result, nil
+		},
+	}
+}
+
 func TestUploadFileUseCase_Success(t *testing.T) {
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
-	repoCalled := false
+	var savedStatus domain.Status
+	var updatedStatuses []domain.Status
 	storageCalled := false
-	deleteStorageCalled := false
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
-			repoCalled = true
+			savedStatus = file.Status()
 			return file, nil
 		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatuses = append(updatedStatuses, file.Status())
+			return nil
+		},
 	}
 
 	storage := &FileStorageMock{
 		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
 			storageCalled = true
+			assert.Equal(t, domain.StatusPending, savedStatus, "metadata must be persisted before bytes are written")
 			return "key1", nil
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+	}
+
+	cmd := UploadFileCommand{
+		FileName:   "file.png",
+		MimeType:   "image/png",
+		Size:       123,
+		Visibility: "PRIVATE",
+		Content:    bytes.NewReader([]byte("file content")),
+	}
+
+	file, err := uc.Execute(ctx, cmd)
+
+	assert.NoError(t, err)
+	assert.True(t, storageCalled)
+	assert.Equal(t, domain.StatusPending, savedStatus)
+	assert.Equal(t, []domain.Status{domain.StatusAvailable}, updatedStatuses)
+	assert.True(t, file.Status() == "AVAILABLE")
+	assert.Equal(t, "key1", file.StorageKey())
+}
+
+func TestUploadFileUseCase_Execute_RejectsUploadOverQuotaBeforeStreaming(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var reservedLimit int64
+
+	usage := &StorageUsageRepositoryMock{
+		ReserveFn: func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+			reservedLimit = limit
+			current, _ := domain.RehydrateStorageUsage(ownerID, 90, 3)
+			return domain.StorageUsage{}, current.CheckCapacity(bytes, limit)
+		},
+	}
+	quota := policy.StorageQuota{DefaultLimit: 1000, ProfileLimits: map[string]int64{"12": 100}}
+	uc := NewUploadFileUseCase(&FileRepositoryMock{}, &FileStorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, usage, quota, nil)
+
+	cmd := helloCommand("")
+	cmd.Size = 50
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.ErrorIs(t, err, apperror.ErrInsufficientStorage)
+	assert.Equal(t, int64(100), reservedLimit)
+}
+
+func TestUploadFileUseCase_Execute_ReleasesQuotaWhenStorageFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var reserved, released int64
+
+	usage := &StorageUsageRepositoryMock{
+		ReserveFn: func(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+			reserved += bytes
+			return domain.RehydrateStorageUsage(ownerID, bytes, files)
+		},
+		ReleaseFn: func(ctx context.Context, ownerID string, bytes int64, files int64) error {
+			assert.Equal(t, "12", ownerID)
+			assert.Equal(t, int64(1), files)
+			released += bytes
 			return nil
 		},
 	}
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			return "", errors.New("storage down")
+		},
+	}
+	uc := NewUploadFileUseCase(acceptingRepository(), storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, usage, policy.StorageQuota{}, nil)
 
-	auth := &AuthClientMock{
-		GetProfileFn: func(token string) (*int64, error) {
-			var result int64 = 12
-			return &result, nil
+	_, err := uc.Execute(ctx, helloCommand(""))
+
+	assert.EqualError(t, err, "storage down")
+	assert.Equal(t, int64(5), reserved)
+	assert.Equal(t, int64(5), released)
+}
+
+func TestUploadFileUseCase_Execute_SavesTagsAndAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var saved domain.File
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			saved = file
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return nil
+		},
+	}
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "key1", err
+		},
+	}
+	uc := NewUploadFileUseCase(repo, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, nil)
+
+	cmd := helloCommand("")
+	cmd.Tags = []string{"Docs", "docs"}
+	cmd.Attributes = map[string]string{"description": "greeting"}
+	file, err := uc.Execute(ctx, cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, saved.Tags())
+	assert.Equal(t, map[string]string{"description": "greeting"}, file.Attributes())
+}
+
+func TestUploadFileUseCase_Execute_RejectsInvalidAttributes(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	uc := NewUploadFileUseCase(&FileRepositoryMock{}, &FileStorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, nil)
+
+	cmd := helloCommand("")
+	cmd.Attributes = map[string]string{"Not Valid": "x"}
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.ErrorIs(t, err, apperror.ErrValidation)
+}
+
+func TestUploadFileUseCase_Execute_RecordsSizeOfUnknownLengthUpload(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var declaredSize int64
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return nil
+		},
+	}
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			declaredSize = file.Size()
+			_, err := io.ReadAll(content)
+			return "key1", err
 		},
 	}
 
@@ -82,13 +277,13 @@ func TestUploadFileUseCase_Success(t *testing.T) {
 		fileRepository: repo,
 		storage:        storage,
 		generator:      &IdGeneratorMock{},
-		authClient:     auth,
+		authClient:     validAuthClient(),
 	}
 
 	cmd := UploadFileCommand{
 		FileName:   "file.png",
 		MimeType:   "image/png",
-		Size:       123,
+		Size:       domain.UnknownSize,
 		Visibility: "PRIVATE",
 		Content:    bytes.NewReader([]byte("file content")),
 	}
@@ -96,39 +291,129 @@ func TestUploadFileUseCase_Success(t *testing.T) {
 	file, err := uc.Execute(ctx, cmd)
 
 	assert.NoError(t, err)
-	assert.True(t, repoCalled)
-	assert.True(t, storageCalled)
-	assert.False(t, deleteStorageCalled)
-	assert.True(t, file.Status() == "AVAILABLE")
+	assert.Equal(t, domain.UnknownSize, declaredSize)
+	assert.Equal(t, int64(len("file content")), file.Size())
+	assert.Equal(t, domain.StatusAvailable, file.Status())
 }
 
-func TestUploadFileUseCase_Execute_ErrorOnRepositorySave(t *testing.T) {
+func TestUploadFileUseCase_Execute_RejectsUploadBlockedByPolicy(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
 	repoCalled := false
-	storageCalled := false
-	deleteStorageCalled := false
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			repoCalled = true
+			return file, nil
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        &FileStorageMock{},
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+		uploadPolicy: policy.UploadPolicy{UploadRules: policy.UploadRules{
+			BlockedExtensions: []string{"exe"},
+		}},
+	}
+
+	cmd := helloCommand("")
+	cmd.FileName = "setup.exe"
+
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.ErrorIs(t, err, apperror.ErrUnsupportedMedia)
+	assert.False(t, repoCalled)
+}
+
+func TestUploadFileUseCase_Execute_StoresSniffedTypeForMislabeledContent(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var stored []byte
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			var err error
+			stored, err = io.ReadAll(content)
+			return "key1", err
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: acceptingRepository(),
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+	}
+
+	body := "<html><script>alert(1)</script></html>"
+	cmd := helloCommand("")
+	cmd.FileName = "avatar.png"
+	cmd.MimeType = "image/png"
+	cmd.Size = int64(len(body))
+	cmd.Content = bytes.NewReader([]byte(body))
+
+	file, err := uc.Execute(ctx, cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, body, string(stored))
+	assert.Equal(t, "text/html", file.MimeType())
+	assert.Equal(t, "text/html", file.DetectedMimeType())
+}
+
+func TestUploadFileUseCase_Execute_StopsUnknownLengthUploadAtPolicyLimit(t *testing.T) {
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var updated domain.File
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
-			return domain.File{}, errors.New("db error")
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updated = file
+			return nil
 		},
 	}
 
 	storage := &FileStorageMock{
 		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
-			storageCalled = true
-			return "key1", nil
+			_, err := io.ReadAll(content)
+			return "", err
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+		uploadPolicy:   policy.UploadPolicy{UploadRules: policy.UploadRules{MaxSize: 4}},
+	}
+
+	cmd := helloCommand("")
+	cmd.Size = domain.UnknownSize
+
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.ErrorIs(t, err, apperror.ErrPayloadTooLarge)
+	assert.Equal(t, domain.StatusFailed, updated.Status())
+}
+
+func TestUploadFileUseCase_Execute_RecordsChecksumOfStoredContent(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
+		UpdateFn: func(ctx context.Context, file domain.File) error {
 			return nil
 		},
 	}
 
-	auth := &AuthClientMock{
-		GetProfileFn: func(token string) (*int64, error) {
-			var result int64 = 12
-			return &result, nil
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "key1", err
 		},
 	}
 
@@ -136,7 +421,101 @@ func TestUploadFileUseCase_Execute_ErrorOnRepositorySave(t *testing.T) {
 		fileRepository: repo,
 		storage:        storage,
 		generator:      &IdGeneratorMock{},
-		authClient:     auth,
+		authClient:     validAuthClient(),
+	}
+
+	cmd := UploadFileCommand{
+		FileName:         "file.txt",
+		MimeType:         "text/plain",
+		Size:             5,
+		Visibility:       "PRIVATE",
+		Content:          bytes.NewReader([]byte("hello")),
+		ExpectedChecksum: "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824",
+	}
+
+	file, err := uc.Execute(ctx, cmd)
+
+	assert.NoError(t, err)
+	assert.Equal(t, cmd.ExpectedChecksum, file.Checksum())
+	assert.Equal(t, cmd.ExpectedChecksum, file.Versions()[0].Checksum())
+}
+
+func TestUploadFileUseCase_Execute_RollsBackOnChecksumMismatch(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var updatedStatuses []domain.Status
+	var deletedKey string
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatuses = append(updatedStatuses, file.Status())
+			return nil
+		},
+	}
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "key1", err
+		},
+		DeleteObjectFn: func(ctx context.Context, storageKey string) error {
+			deletedKey = storageKey
+			return nil
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+	}
+
+	cmd := UploadFileCommand{
+		FileName:         "file.txt",
+		MimeType:         "text/plain",
+		Size:             5,
+		Visibility:       "PRIVATE",
+		Content:          bytes.NewReader([]byte("hello")),
+		ExpectedChecksum: "0000000000000000000000000000000000000000000000000000000000000000",
+	}
+
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.ErrorContains(t, err, "checksum mismatch")
+	assert.Equal(t, "key1", deletedKey)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updatedStatuses)
+}
+
+func TestUploadFileUseCase_Execute_ErrorOnRepositorySave(t *testing.T) {
+	storageCalled := false
+	updateCalled := false
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return domain.File{}, errors.New("db error")
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updateCalled = true
+			return nil
+		},
+	}
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			storageCalled = true
+			return "key1", nil
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
 	}
 
 	cmd := UploadFileCommand{
@@ -149,20 +528,20 @@ func TestUploadFileUseCase_Execute_ErrorOnRepositorySave(t *testing.T) {
 
 	_, err := uc.Execute(ctx, cmd)
 
-	assert.Error(t, err)
-	assert.True(t, storageCalled)
-	assert.False(t, repoCalled)
-	assert.True(t, deleteStorageCalled)
+	assert.EqualError(t, err, "db error")
+	assert.False(t, storageCalled)
+	assert.False(t, updateCalled)
 }
 
 func TestUploadFileUseCase_Execute_ErrorOnCreateFileDomain(t *testing.T) {
 	repoCalled := false
 	storageCalled := false
-	deleteStorageCalled := false
+
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			repoCalled = true
 			return domain.File{}, nil
 		},
 	}
@@ -172,24 +551,13 @@ func TestUploadFileUseCase_Execute_ErrorOnCreateFileDomain(t *testing.T) {
 			storageCalled = true
 			return "key1", nil
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
-			return nil
-		},
-	}
-
-	auth := &AuthClientMock{
-		GetProfileFn: func(token string) (*int64, error) {
-			var result int64 = 12
-			return &result, nil
-		},
 	}
 
 	uc := UploadFileUseCase{
 		fileRepository: repo,
 		storage:        storage,
 		generator:      &IdGeneratorMock{},
-		authClient:     auth,
+		authClient:     validAuthClient(),
 	}
 
 	cmd := UploadFileCommand{
@@ -205,18 +573,20 @@ func TestUploadFileUseCase_Execute_ErrorOnCreateFileDomain(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, storageCalled)
 	assert.False(t, repoCalled)
-	assert.False(t, deleteStorageCalled)
 }
 
 func TestUploadFileUseCase_Execute_ErrorOnMarkAvailableDomain(t *testing.T) {
-	repoCalled := false
 	storageCalled := false
-	deleteStorageCalled := false
+	var updatedStatuses []domain.Status
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
-			return domain.File{}, nil
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatuses = append(updatedStatuses, file.Status())
+			return nil
 		},
 	}
 
@@ -225,24 +595,13 @@ func TestUploadFileUseCase_Execute_ErrorOnMarkAvailableDomain(t *testing.T) {
 			storageCalled = true
 			return "", nil
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
-			return nil
-		},
-	}
-
-	auth := &AuthClientMock{
-		GetProfileFn: func(token string) (*int64, error) {
-			var result int64 = 12
-			return &result, nil
-		},
 	}
 
 	uc := UploadFileUseCase{
 		fileRepository: repo,
 		storage:        storage,
 		generator:      &IdGeneratorMock{},
-		authClient:     auth,
+		authClient:     validAuthClient(),
 	}
 
 	cmd := UploadFileCommand{
@@ -257,21 +616,22 @@ func TestUploadFileUseCase_Execute_ErrorOnMarkAvailableDomain(t *testing.T) {
 
 	assert.Error(t, err)
 	assert.True(t, storageCalled)
-	assert.False(t, repoCalled)
-	assert.False(t, deleteStorageCalled)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updatedStatuses)
 }
 
 func TestUploadFileUseCase_Execute_ErrorOnStorageSave(t *testing.T) {
-	repoCalled := false
 	storageCalled := false
-	deleteStorageCalled := false
+	var updatedStatuses []domain.Status
 	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
-			storageCalled = true
 			return file, nil
 		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			updatedStatuses = append(updatedStatuses, file.Status())
+			return nil
+		},
 	}
 
 	storage := &FileStorageMock{
@@ -279,16 +639,46 @@ func TestUploadFileUseCase_Execute_ErrorOnStorageSave(t *testing.T) {
 			storageCalled = true
 			return "", errors.New("Storage error")
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
-			return nil
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+	}
+
+	cmd := UploadFileCommand{
+		FileName:   "file.pdf",
+		MimeType:   "application/pdf",
+		Size:       1234,
+		Visibility: string(domain.VisibilityPrivate),
+		Content:    bytes.NewReader([]byte("file content")),
+	}
+
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.EqualError(t, err, "Storage error")
+	assert.True(t, storageCalled)
+	assert.Equal(t, []domain.Status{domain.StatusFailed}, updatedStatuses)
+}
+
+func TestUploadFileUseCase_Execute_KeepsStorageErrorWhenMarkingFailedFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	storageErr := errors.New("Storage error")
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return errors.New("db error")
 		},
 	}
 
-	auth := &AuthClientMock{
-		GetProfileFn: func(token string) (*int64, error) {
-			var result int64 = 12
-			return &result, nil
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			return "", storageErr
 		},
 	}
 
@@ -296,7 +686,7 @@ func TestUploadFileUseCase_Execute_ErrorOnStorageSave(t *testing.T) {
 		fileRepository: repo,
 		storage:        storage,
 		generator:      &IdGeneratorMock{},
-		authClient:     auth,
+		authClient:     validAuthClient(),
 	}
 
 	cmd := UploadFileCommand{
@@ -309,21 +699,56 @@ func TestUploadFileUseCase_Execute_ErrorOnStorageSave(t *testing.T) {
 
 	_, err := uc.Execute(ctx, cmd)
 
-	assert.Error(t, err)
-	assert.True(t, storageCalled)
-	assert.False(t, repoCalled)
-	assert.False(t, deleteStorageCalled)
+	assert.ErrorIs(t, err, storageErr)
+	assert.ErrorContains(t, err, "db error")
+}
+
+func TestUploadFileUseCase_Execute_ErrorOnFinalUpdate(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return errors.New("db error")
+		},
+	}
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			return "key1", nil
+		},
+	}
+
+	uc := UploadFileUseCase{
+		fileRepository: repo,
+		storage:        storage,
+		generator:      &IdGeneratorMock{},
+		authClient:     validAuthClient(),
+	}
+
+	cmd := UploadFileCommand{
+		FileName:   "file.pdf",
+		MimeType:   "application/pdf",
+		Size:       1234,
+		Visibility: string(domain.VisibilityPrivate),
+		Content:    bytes.NewReader([]byte("file content")),
+	}
+
+	_, err := uc.Execute(ctx, cmd)
+
+	assert.EqualError(t, err, "db error")
 }
 
 func TestUploadFileUseCase_Execute_ErrorOnContextGetValue(t *testing.T) {
 	repoCalled := false
 	storageCalled := false
-	deleteStorageCalled := false
 	ctx := context.Background()
 
 	repo := &FileRepositoryMock{
 		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
-			storageCalled = true
+			repoCalled = true
 			return file, nil
 		},
 	}
@@ -333,10 +758,6 @@ func TestUploadFileUseCase_Execute_ErrorOnContextGetValue(t *testing.T) {
 			storageCalled = true
 			return "", errors.New("Storage error")
 		},
-		DeleteFileFn: func(ctx context.Context, file domain.File) error {
-			deleteStorageCalled = true
-			return nil
-		},
 	}
 
 	auth := &AuthClientMock{
@@ -366,7 +787,6 @@ func TestUploadFileUseCase_Execute_ErrorOnContextGetValue(t *testing.T) {
 	assert.Error(t, err)
 	assert.False(t, storageCalled)
 	assert.False(t, repoCalled)
-	assert.False(t, deleteStorageCalled)
 	assert.Equal(t, "token cannot be null", err.Error())
 }
 
@@ -375,8 +795,244 @@ func TestShouldCreateUseCaseWhenPassRepoAndStorage(t *testing.T) {
 	fileStorageMock := &FileStorageMock{}
 	generatorMock := &IdGeneratorMock{}
 	auth := &AuthClientMock{}
-	uc := NewUploadFileUseCase(fileRepositoryMock, fileStorageMock, generatorMock, auth)
+	blobs := &BlobRepositoryMock{}
+	uc := NewUploadFileUseCase(fileRepositoryMock, fileStorageMock, generatorMock, auth, policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
 	assert.Equal(t, uc.fileRepository, fileRepositoryMock)
 	assert.Equal(t, uc.storage, fileStorageMock)
 	assert.Equal(t, uc.generator, generatorMock)
+	assert.Equal(t, uc.blobs, blobs)
+}
+
+const helloChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func acceptingRepository() *FileRepositoryMock {
+	return &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return nil
+		},
+	}
+}
+
+func helloCommand(expectedChecksum string) UploadFileCommand {
+	return UploadFileCommand{
+		FileName:         "hello.txt",
+		MimeType:         "text/plain",
+		Size:             5,
+		Visibility:       "PRIVATE",
+		Content:          bytes.NewReader([]byte("hello")),
+		ExpectedChecksum: expectedChecksum,
+	}
+}
+
+func TestUploadFileUseCase_Execute_PromotesNewContentToBlob(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	var deletedKeys []string
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "12/1/hello.txt", err
+		},
+		PromoteBlobFn: func(ctx context.Context, sourceKey string, checksum string) (string, error) {
+			assert.Equal(t, "12/1/hello.txt", sourceKey)
+			return "blobs/2c/" + checksum, nil
+		},
+		DeleteObjectFn: func(ctx context.Context, storageKey string) error {
+			deletedKeys = append(deletedKeys, storageKey)
+			return nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			return domain.Blob{}, domain.ErrBlobNotFound
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 1, blob.CreatedAt())
+		},
+	}
+
+	uc := NewUploadFileUseCase(acceptingRepository(), storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	file, err := uc.Execute(ctx, helloCommand(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "blobs/2c/"+helloChecksum, file.StorageKey())
+	assert.True(t, file.ContentAddressed())
+	assert.Equal(t, []string{"12/1/hello.txt"}, deletedKeys)
+}
+
+func TestUploadFileUseCase_Execute_ReusesExistingBlob(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	existing, _ := domain.RehydrateBlob(helloChecksum, "blobs/2c/"+helloChecksum, 5, 3, time.Now())
+	var deletedKeys []string
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "12/1/hello.txt", err
+		},
+		DeleteObjectFn: func(ctx context.Context, storageKey string) error {
+			deletedKeys = append(deletedKeys, storageKey)
+			return nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			return existing, nil
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 4, blob.CreatedAt())
+		},
+	}
+
+	uc := NewUploadFileUseCase(acceptingRepository(), storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	file, err := uc.Execute(ctx, helloCommand(""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing.StorageKey(), file.StorageKey())
+	assert.Equal(t, []string{"12/1/hello.txt"}, deletedKeys)
+}
+
+func TestUploadFileUseCase_Execute_InstantUploadSkipsStreaming(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	existing, _ := domain.RehydrateBlob(helloChecksum, "blobs/2c/"+helloChecksum, 5, 1, time.Now())
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			t.Fatal("content must not be streamed when the blob already exists")
+			return "", nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			assert.Equal(t, helloChecksum, checksum)
+			return existing, nil
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 2, blob.CreatedAt())
+		},
+	}
+
+	repo := acceptingRepository()
+	repo.HasContentFn = func(ctx context.Context, ownerID string, checksum string) (bool, error) {
+		assert.Equal(t, "12", ownerID)
+		return true, nil
+	}
+	uc := NewUploadFileUseCase(repo, storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	file, err := uc.Execute(ctx, helloCommand(helloChecksum))
+
+	assert.NoError(t, err)
+	assert.Equal(t, domain.StatusAvailable, file.Status())
+	assert.Equal(t, helloChecksum, file.Checksum())
+	assert.True(t, file.ContentAddressed())
+}
+
+func TestUploadFileUseCase_Execute_InstantUploadStreamsContentTheOwnerDoesNotHave(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	existing, _ := domain.RehydrateBlob(helloChecksum, "blobs/2c/"+helloChecksum, 5, 1, time.Now())
+	streamed := false
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			streamed = true
+			_, err := io.ReadAll(content)
+			return "12/1/hello.txt", err
+		},
+		DeleteObjectFn: func(ctx context.Context, storageKey string) error {
+			return nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			return existing, nil
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 2, blob.CreatedAt())
+		},
+	}
+
+	uc := NewUploadFileUseCase(acceptingRepository(), storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	file, err := uc.Execute(ctx, helloCommand(helloChecksum))
+
+	assert.NoError(t, err)
+	assert.True(t, streamed)
+	assert.Equal(t, existing.StorageKey(), file.StorageKey())
+}
+
+func TestUploadFileUseCase_Execute_RepromotesBlobReleasedConcurrently(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	existing, _ := domain.RehydrateBlob(helloChecksum, "blobs/2c/"+helloChecksum, 5, 1, time.Now())
+	promoted := false
+
+	storage := &FileStorageMock{
+		SaveFileFn: func(ctx context.Context, content io.Reader, file domain.File) (string, error) {
+			_, err := io.ReadAll(content)
+			return "12/1/hello.txt", err
+		},
+		PromoteBlobFn: func(ctx context.Context, sourceKey string, checksum string) (string, error) {
+			promoted = true
+			return "blobs/2c/" + checksum, nil
+		},
+		DeleteObjectFn: func(ctx context.Context, storageKey string) error {
+			return nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			return existing, nil
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 1, blob.CreatedAt())
+		},
+	}
+
+	uc := NewUploadFileUseCase(acceptingRepository(), storage, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	_, err := uc.Execute(ctx, helloCommand(""))
+
+	assert.NoError(t, err)
+	assert.True(t, promoted)
+}
+
+func TestUploadFileUseCase_Execute_ReleasesBlobWhenFinalUpdateFails(t *testing.T) {
+	ctx := context.WithValue(context.Background(), auth.AuthTokenKey, "sffa")
+	existing, _ := domain.RehydrateBlob(helloChecksum, "blobs/2c/"+helloChecksum, 5, 1, time.Now())
+	released := false
+
+	repo := &FileRepositoryMock{
+		SaveFn: func(ctx context.Context, file domain.File) (domain.File, error) {
+			return file, nil
+		},
+		UpdateFn: func(ctx context.Context, file domain.File) error {
+			return errors.New("db error")
+		},
+		HasContentFn: func(ctx context.Context, ownerID string, checksum string) (bool, error) {
+			return true, nil
+		},
+	}
+	blobs := &BlobRepositoryMock{
+		GetBlobFn: func(ctx context.Context, checksum string) (domain.Blob, error) {
+			return existing, nil
+		},
+		AcquireFn: func(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+			return domain.RehydrateBlob(blob.Checksum(), blob.StorageKey(), blob.Size(), 2, blob.CreatedAt())
+		},
+		ReleaseFn: func(ctx context.Context, checksum string) (bool, error) {
+			released = true
+			return false, nil
+		},
+	}
+
+	uc := NewUploadFileUseCase(repo, &FileStorageMock{}, &IdGeneratorMock{}, validAuthClient(), policy.UploadPolicy{}, nil, policy.StorageQuota{}, blobs)
+
+	_, err := uc.Execute(ctx, helloCommand(helloChecksum))
+
+	assert.EqualError(t, err, "db error")
+	assert.True(t, released)
 }
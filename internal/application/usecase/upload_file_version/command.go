@@ -0,0 +1,11 @@
+package uploadfileversion
+
+import "io"
+
+type UploadFileVersionCommand struct {
+	FileId           string
+	MimeType         string
+	Size             int64
+	Content          io.Reader
+	ExpectedChecksum string
+}
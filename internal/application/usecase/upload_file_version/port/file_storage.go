@@ -0,0 +1,12 @@
+package port
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"io"
+)
+
+type Storage interface {
+	SaveFileVersion(ctx context.Context, fileBytes io.Reader, file domain.File, version int, size int64) (string, error)
+	DeleteObject(ctx context.Context, storageKey string) error
+}
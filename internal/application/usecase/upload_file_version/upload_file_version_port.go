@@ -0,0 +1,10 @@
+package uploadfileversion
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+)
+
+type IUploadFileVersionUseCase interface {
+	Execute(ctx context.Context, command UploadFileVersionCommand) (domain.File, error)
+}
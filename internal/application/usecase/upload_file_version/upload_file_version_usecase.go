@@ -0,0 +1,157 @@
+package uploadfileversion
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/checksum"
+	"devconnectstorage/internal/application/mimesniff"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/application/usecase/upload_file_version/port"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"strconv"
+)
+
+// maxUpdateAttempts bounds how often a stored version is re-applied to a file
+// that changed while its content was uploading.
+const maxUpdateAttempts = 3
+
+type UploadFileVersionUseCase struct {
+	repository   port.FileRepository
+	storage      port.Storage
+	authClient   auth.IAuthClient
+	uploadPolicy policy.UploadPolicy
+	usage        port.StorageUsageRepository
+	quota        policy.StorageQuota
+}
+
+func NewUploadFileVersionUseCase(repository port.FileRepository, storage port.Storage, authClient auth.IAuthClient, uploadPolicy policy.UploadPolicy, usage port.StorageUsageRepository, quota policy.StorageQuota) *UploadFileVersionUseCase {
+	return &UploadFileVersionUseCase{
+		repository:   repository,
+		storage:      storage,
+		authClient:   authClient,
+		uploadPolicy: uploadPolicy,
+		usage:        usage,
+		quota:        quota,
+	}
+}
+
+func (uc *UploadFileVersionUseCase) Execute(ctx context.Context, command UploadFileVersionCommand) (_ domain.File, err error) {
+	token := ctx.Value(auth.AuthTokenKey)
+
+	if token == nil {
+		return domain.File{}, apperror.New(apperror.ErrUnauthenticated, "token cannot be null")
+	}
+
+	profileId, authError := uc.authClient.GetProfile(token.(string))
+
+	if authError != nil {
+		return domain.File{}, authError
+	}
+
+	file, err := uc.repository.GetFile(ctx, command.FileId)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if file.OwnerID() != strconv.FormatInt(*profileId, 10) {
+		return domain.File{}, apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if file.Status() != domain.StatusAvailable {
+		return domain.File{}, apperror.New(apperror.ErrNotFound, "file not found")
+	}
+
+	declared := command.MimeType
+	if declared == "" {
+		declared = file.MimeType()
+	}
+	detection, upload, err := mimesniff.Detect(command.Content)
+	if err != nil {
+		return domain.File{}, err
+	}
+	mimeType, err := uc.uploadPolicy.ResolveMimeType(file.ProjectID(), declared, detection)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if err := uc.uploadPolicy.Check(policy.UploadCandidate{
+		ProjectID:  file.ProjectID(),
+		FileName:   file.FileName(),
+		MimeType:   mimeType,
+		Size:       command.Size,
+		Visibility: file.Visibility(),
+	}); err != nil {
+		return domain.File{}, err
+	}
+
+	if uc.usage != nil {
+		if _, err := uc.usage.Reserve(ctx, file.OwnerID(), command.Size, 0, uc.quota.LimitFor(file.OwnerID())); err != nil {
+			return domain.File{}, err
+		}
+		defer func() {
+			if err == nil {
+				return
+			}
+			if releaseErr := uc.usage.Release(ctx, file.OwnerID(), command.Size, 0); releaseErr != nil {
+				err = errors.Join(err, releaseErr)
+			}
+		}()
+	}
+
+	number, err := file.ReserveVersion()
+	if err != nil {
+		return domain.File{}, err
+	}
+	if err := uc.repository.Update(ctx, file); err != nil {
+		return domain.File{}, err
+	}
+
+	content := checksum.NewReader(upload)
+	storageKey, err := uc.storage.SaveFileVersion(ctx, content, file, number, command.Size)
+	if err != nil {
+		return domain.File{}, err
+	}
+
+	if err := content.Verify(command.ExpectedChecksum); err != nil {
+		return domain.File{}, uc.discard(ctx, storageKey, err)
+	}
+
+	file, err = uc.addVersion(ctx, command.FileId, number, storageKey, mimeType, command.Size, content.Sum(), detection.MimeType())
+	if err != nil {
+		return domain.File{}, uc.discard(ctx, storageKey, err)
+	}
+	return file, nil
+}
+
+// addVersion records the stored content under its reserved number. Other
+// writes may land while the content uploads, so the file is reloaded and the
+// version re-added when the update loses a race; the number stays ours.
+func (uc *UploadFileVersionUseCase) addVersion(ctx context.Context, id string, number int, storageKey string, mimeType string, size int64, sum string, detectedMimeType string) (domain.File, error) {
+	for attempt := 1; ; attempt++ {
+		file, err := uc.repository.GetFile(ctx, id)
+		if err != nil {
+			return domain.File{}, err
+		}
+		if err := file.AddReservedVersion(number, storageKey, mimeType, size, sum); err != nil {
+			return domain.File{}, err
+		}
+		if err := file.RecordDetectedMimeType(detectedMimeType); err != nil {
+			return domain.File{}, err
+		}
+		err = uc.repository.Update(ctx, file)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, apperror.ErrConflict) || attempt == maxUpdateAttempts {
+			return domain.File{}, err
+		}
+	}
+}
+
+func (uc *UploadFileVersionUseCase) discard(ctx context.Context, storageKey string, cause error) error {
+	if deleteError := uc.storage.DeleteObject(ctx, storageKey); deleteError != nil {
+		return errors.Join(cause, deleteError)
+	}
+	return cause
+}
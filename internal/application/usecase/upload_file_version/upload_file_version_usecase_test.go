@@ -0,0 +1,238 @@
+package uploadfileversion
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/policy"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type RepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RepositoryMock) GetFile(ctx context.Context, id string) (domain.File, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *RepositoryMock) Update(ctx context.Context, file domain.File) error {
+	args := m.Called(ctx, file)
+	return args.Error(0)
+}
+
+type StorageMock struct {
+	mock.Mock
+}
+
+func (m *StorageMock) SaveFileVersion(ctx context.Context, fileBytes io.Reader, file domain.File, version int, size int64) (string, error) {
+	args := m.Called(ctx, fileBytes, file, version, size)
+	return args.String(0), args.Error(1)
+}
+
+func (m *StorageMock) DeleteObject(ctx context.Context, storageKey string) error {
+	args := m.Called(ctx, storageKey)
+	return args.Error(0)
+}
+
+type AuthClientMock struct {
+	mock.Mock
+}
+
+func (m *AuthClientMock) GetProfile(token string) (*int64, error) {
+	args := m.Called(token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*int64), args.Error(1)
+}
+
+func TestUploadFileVersionUseCase_Execute(t *testing.T) {
+	const validToken = "valid-token"
+
+	ctxWithToken := context.WithValue(context.Background(), auth.AuthTokenKey, validToken)
+	content := bytes.NewReader([]byte("new content"))
+
+	setup := func() (*RepositoryMock, *StorageMock, *AuthClientMock, *UploadFileVersionUseCase) {
+		repo := new(RepositoryMock)
+		storage := new(StorageMock)
+		authCli := new(AuthClientMock)
+		uc := NewUploadFileVersionUseCase(repo, storage, authCli, policy.UploadPolicy{}, nil, policy.StorageQuota{})
+		return repo, storage, authCli, uc
+	}
+
+	existingFile := func(ownerID string, status domain.Status) domain.File {
+		var options []domain.RehydrateOption
+		if status == domain.StatusDeleted {
+			options = append(options, domain.WithDeletedAt(time.Now()))
+		}
+		file, _ := domain.RehydrateFile("1", ownerID, nil, "readme.md", "text/markdown", 10, "key/v1", domain.VisibilityPrivate, status, time.Now(), options...)
+		return file
+	}
+
+	reservedFile := func(revision int64) domain.File {
+		file, _ := domain.RehydrateFile("1", "123", nil, "readme.md", "text/markdown", 10, "key/v1", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(),
+			domain.WithReservedVersion(2), domain.WithRevision(revision, time.Now()))
+		return file
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil).Once()
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool { return file.ReservedVersion() == 2 && file.Version() == 1 })).Return(nil).Once()
+		storage.On("SaveFileVersion", ctxWithToken, mock.Anything, mock.Anything, 2, int64(11)).Return("key/v2", nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(reservedFile(1), nil).Once()
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool { return file.Version() == 2 })).Return(nil).Once()
+
+		file, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, file.Version())
+		assert.Equal(t, "key/v2", file.StorageKey())
+		assert.Equal(t, "text/markdown", file.MimeType())
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Error When Another Upload Reserved The Version", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		conflict := apperror.New(apperror.ErrConflict, "file was modified concurrently, retry the request")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(conflict)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.ErrorIs(t, err, apperror.ErrConflict)
+		storage.AssertNotCalled(t, "SaveFileVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Retries When The File Changed During Upload", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		conflict := apperror.New(apperror.ErrConflict, "file was modified concurrently, retry the request")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil).Once()
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil).Once()
+		storage.On("SaveFileVersion", ctxWithToken, mock.Anything, mock.Anything, 2, int64(11)).Return("key/v2", nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(reservedFile(1), nil).Once()
+		repo.On("Update", ctxWithToken, mock.Anything).Return(conflict).Once()
+		repo.On("GetFile", ctxWithToken, "1").Return(reservedFile(2), nil).Once()
+		repo.On("Update", ctxWithToken, mock.MatchedBy(func(file domain.File) bool { return file.Revision() == 2 && file.Version() == 2 })).Return(nil).Once()
+
+		file, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "key/v2", file.StorageKey())
+		repo.AssertExpectations(t)
+		storage.AssertNotCalled(t, "DeleteObject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error No Token In Context", func(t *testing.T) {
+		_, _, _, uc := setup()
+		_, err := uc.Execute(context.Background(), UploadFileVersionCommand{FileId: "1"})
+
+		assert.EqualError(t, err, "token cannot be null")
+	})
+
+	t.Run("Error Unauthorized Owner", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var otherID int64 = 456
+
+		authCli.On("GetProfile", validToken).Return(&otherID, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.EqualError(t, err, "unauthorized")
+		storage.AssertNotCalled(t, "SaveFileVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error File In Trash", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusDeleted), nil)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.EqualError(t, err, "file not found")
+		storage.AssertNotCalled(t, "SaveFileVersion", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Error On Storage", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		expectedErr := errors.New("storage error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil).Once()
+		storage.On("SaveFileVersion", ctxWithToken, mock.Anything, mock.Anything, 2, int64(11)).Return("", expectedErr)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.Equal(t, expectedErr, err)
+		repo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("Error On Update Removes Stored Version", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+		expectedErr := errors.New("db error")
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil).Once()
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil).Once()
+		storage.On("SaveFileVersion", ctxWithToken, mock.Anything, mock.Anything, 2, int64(11)).Return("key/v2", nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(reservedFile(1), nil).Once()
+		repo.On("Update", ctxWithToken, mock.Anything).Return(expectedErr).Once()
+		storage.On("DeleteObject", ctxWithToken, "key/v2").Return(nil)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{FileId: "1", Size: 11, Content: content})
+
+		assert.Equal(t, expectedErr, err)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("Error On Checksum Mismatch Removes Stored Version", func(t *testing.T) {
+		repo, storage, authCli, uc := setup()
+		var ownerIDInt int64 = 123
+
+		authCli.On("GetProfile", validToken).Return(&ownerIDInt, nil)
+		repo.On("GetFile", ctxWithToken, "1").Return(existingFile("123", domain.StatusAvailable), nil)
+		repo.On("Update", ctxWithToken, mock.Anything).Return(nil).Once()
+		storage.On("SaveFileVersion", ctxWithToken, mock.Anything, mock.Anything, 2, int64(5)).
+			Run(func(args mock.Arguments) { _, _ = io.ReadAll(args.Get(1).(io.Reader)) }).
+			Return("key/v2", nil)
+		storage.On("DeleteObject", ctxWithToken, "key/v2").Return(nil)
+
+		_, err := uc.Execute(ctxWithToken, UploadFileVersionCommand{
+			FileId:           "1",
+			Size:             5,
+			Content:          bytes.NewReader([]byte("hello")),
+			ExpectedChecksum: "0000000000000000000000000000000000000000000000000000000000000000",
+		})
+
+		assert.ErrorContains(t, err, "checksum mismatch")
+		repo.AssertNumberOfCalls(t, "Update", 1)
+		storage.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+var ErrBlobNotFound = apperror.New(apperror.ErrNotFound, "blob not found")
+
+type Blob struct {
+	checksum   string
+	storageKey string
+	size       int64
+	references int64
+	createdAt  time.Time
+}
+
+func NewBlob(checksum string, storageKey string, size int64) (Blob, error) {
+	return RehydrateBlob(checksum, storageKey, size, 0, time.Now())
+}
+
+func RehydrateBlob(checksum string, storageKey string, size int64, references int64, createdAt time.Time) (Blob, error) {
+	if checksum == "" {
+		return Blob{}, apperror.New(apperror.ErrValidation, "checksum cannot be empty")
+	}
+	if err := validateChecksum(checksum); err != nil {
+		return Blob{}, err
+	}
+	if storageKey == "" {
+		return Blob{}, apperror.New(apperror.ErrValidation, "storageKey cannot be empty")
+	}
+	if size < 0 {
+		return Blob{}, apperror.New(apperror.ErrValidation, "size cannot be negative")
+	}
+	if references < 0 {
+		return Blob{}, apperror.New(apperror.ErrValidation, "references cannot be negative")
+	}
+	if createdAt.IsZero() {
+		return Blob{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	return Blob{
+		checksum:   checksum,
+		storageKey: storageKey,
+		size:       size,
+		references: references,
+		createdAt:  createdAt,
+	}, nil
+}
+
+func (b Blob) Checksum() string {
+	return b.checksum
+}
+
+func (b Blob) StorageKey() string {
+	return b.storageKey
+}
+
+func (b Blob) Size() int64 {
+	return b.size
+}
+
+func (b Blob) References() int64 {
+	return b.references
+}
+
+func (b Blob) CreatedAt() time.Time {
+	return b.createdAt
+}
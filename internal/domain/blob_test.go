@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+const testBlobChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func TestNewBlob_Success(t *testing.T) {
+	blob, err := NewBlob(testBlobChecksum, "blobs/2c/"+testBlobChecksum, 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if blob.References() != 0 || blob.Size() != 5 {
+		t.Errorf("blob fields mismatch")
+	}
+}
+
+func TestRehydrateBlob_Invalid(t *testing.T) {
+	if _, err := RehydrateBlob("", "key", 1, 0, time.Now()); err == nil {
+		t.Errorf("expected error for empty checksum")
+	}
+	if _, err := RehydrateBlob("abc", "key", 1, 0, time.Now()); err == nil {
+		t.Errorf("expected error for invalid checksum")
+	}
+	if _, err := RehydrateBlob(testBlobChecksum, "", 1, 0, time.Now()); err == nil {
+		t.Errorf("expected error for empty storageKey")
+	}
+	if _, err := RehydrateBlob(testBlobChecksum, "key", -1, 0, time.Now()); err == nil {
+		t.Errorf("expected error for negative size")
+	}
+	if _, err := RehydrateBlob(testBlobChecksum, "key", 1, -1, time.Now()); err == nil {
+		t.Errorf("expected error for negative references")
+	}
+	if _, err := RehydrateBlob(testBlobChecksum, "key", 1, 0, time.Time{}); err == nil {
+		t.Errorf("expected error for zero createdAt")
+	}
+}
+
+func TestMarkAsAvailableFromBlob_LinksFirstVersion(t *testing.T) {
+	blob, _ := NewBlob(testBlobChecksum, "blobs/2c/"+testBlobChecksum, 5)
+	file, _ := NewFile("1", "user-1", nil, "hello.txt", "text/plain", UnknownSize, VisibilityPrivate)
+
+	if err := file.MarkAsAvailableFromBlob(blob); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file.StorageKey() != blob.StorageKey() || file.Size() != 5 || file.Checksum() != testBlobChecksum {
+		t.Errorf("expected file to take the blob content")
+	}
+	if !file.ContentAddressed() {
+		t.Errorf("expected current version to be content-addressed")
+	}
+}
+
+func TestMarkAsAvailableFromBlob_RejectsMismatch(t *testing.T) {
+	blob, _ := NewBlob(testBlobChecksum, "blobs/2c/"+testBlobChecksum, 5)
+
+	wrongSize, _ := NewFile("1", "user-1", nil, "hello.txt", "text/plain", 6, VisibilityPrivate)
+	if err := wrongSize.MarkAsAvailableFromBlob(blob); err == nil {
+		t.Errorf("expected error for size mismatch")
+	}
+
+	wrongChecksum, _ := NewFile("1", "user-1", nil, "hello.txt", "text/plain", 5, VisibilityPrivate)
+	_ = wrongChecksum.RecordChecksum("486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7")
+	if err := wrongChecksum.MarkAsAvailableFromBlob(blob); err == nil {
+		t.Errorf("expected error for checksum mismatch")
+	}
+}
+
+func TestRehydrateFileVersion_ContentAddressedRequiresChecksum(t *testing.T) {
+	if _, err := RehydrateFileVersion(1, "blobs/key", "text/plain", 1, "", true, time.Now()); err == nil {
+		t.Errorf("expected error for content-addressed version without checksum")
+	}
+}
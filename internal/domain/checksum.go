@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"encoding/hex"
+)
+
+const checksumLength = 64
+
+func validateChecksum(checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+	decoded, err := hex.DecodeString(checksum)
+	if err != nil || len(checksum) != checksumLength || hex.EncodeToString(decoded) != checksum {
+		return apperror.New(apperror.ErrValidation, "checksum must be a lowercase hex sha-256 digest")
+	}
+	return nil
+}
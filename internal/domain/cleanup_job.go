@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+// CleanupScope tells which files a cleanup job removes: those of a deleted
+// project or those owned by a deleted profile.
+type CleanupScope string
+
+const (
+	CleanupScopeProject CleanupScope = "PROJECT"
+	CleanupScopeOwner   CleanupScope = "OWNER"
+)
+
+func (s CleanupScope) IsValid() bool {
+	return s == CleanupScopeProject || s == CleanupScopeOwner
+}
+
+type CleanupStatus string
+
+const (
+	CleanupStatusPending   CleanupStatus = "PENDING"
+	CleanupStatusCompleted CleanupStatus = "COMPLETED"
+)
+
+func (s CleanupStatus) IsValid() bool {
+	return s == CleanupStatusPending || s == CleanupStatusCompleted
+}
+
+// CleanupJob trashes the files left behind by a deletion in the main
+// service. Its ID is the ID of the event that caused it, so a redelivered
+// event maps to the same job.
+type CleanupJob struct {
+	id           string
+	scope        CleanupScope
+	subjectID    string
+	status       CleanupStatus
+	trashedCount int64
+	lastError    string
+	createdAt    time.Time
+	updatedAt    time.Time
+	completedAt  *time.Time
+}
+
+func NewCleanupJob(id string, scope CleanupScope, subjectID string) (CleanupJob, error) {
+	now := time.Now()
+	return RehydrateCleanupJob(id, scope, subjectID, CleanupStatusPending, 0, "", now, now, nil)
+}
+
+func RehydrateCleanupJob(id string, scope CleanupScope, subjectID string, status CleanupStatus, trashedCount int64, lastError string, createdAt time.Time, updatedAt time.Time, completedAt *time.Time) (CleanupJob, error) {
+	if id == "" {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "id cannot be empty")
+	}
+	if !scope.IsValid() {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "invalid cleanup scope %q", scope)
+	}
+	if subjectID == "" {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "subjectID cannot be empty")
+	}
+	if !status.IsValid() {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "invalid cleanup status %q", status)
+	}
+	if trashedCount < 0 {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "trashedCount cannot be negative")
+	}
+	if createdAt.IsZero() {
+		return CleanupJob{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	return CleanupJob{
+		id:           id,
+		scope:        scope,
+		subjectID:    subjectID,
+		status:       status,
+		trashedCount: trashedCount,
+		lastError:    lastError,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+		completedAt:  completedAt,
+	}, nil
+}
+
+func (j CleanupJob) ID() string {
+	return j.id
+}
+
+func (j CleanupJob) Scope() CleanupScope {
+	return j.scope
+}
+
+// SubjectID is the deleted project's ID or the deleted profile's ID,
+// depending on Scope.
+func (j CleanupJob) SubjectID() string {
+	return j.subjectID
+}
+
+func (j CleanupJob) Status() CleanupStatus {
+	return j.status
+}
+
+func (j CleanupJob) TrashedCount() int64 {
+	return j.trashedCount
+}
+
+// LastError describes why the latest batch left files behind; it is empty
+// once a batch succeeds.
+func (j CleanupJob) LastError() string {
+	return j.lastError
+}
+
+func (j CleanupJob) CreatedAt() time.Time {
+	return j.createdAt
+}
+
+func (j CleanupJob) UpdatedAt() time.Time {
+	return j.updatedAt
+}
+
+func (j CleanupJob) CompletedAt() *time.Time {
+	return j.completedAt
+}
+
+func (j CleanupJob) Matches(scope CleanupScope, subjectID string) bool {
+	return j.scope == scope && j.subjectID == subjectID
+}
+
+// RecordBatch adds the files trashed by one batch. A batch that failed keeps
+// the job pending so the next run retries the files it left behind.
+func (j *CleanupJob) RecordBatch(trashed int64, batchError error) error {
+	if j.status != CleanupStatusPending {
+		return apperror.New(apperror.ErrConflict, "cleanup job is already %s", j.status)
+	}
+	if trashed < 0 {
+		return apperror.New(apperror.ErrValidation, "trashed cannot be negative")
+	}
+	j.trashedCount += trashed
+	j.lastError = ""
+	if batchError != nil {
+		j.lastError = batchError.Error()
+	}
+	j.updatedAt = time.Now()
+	return nil
+}
+
+func (j *CleanupJob) Complete() error {
+	if j.status != CleanupStatusPending {
+		return apperror.New(apperror.ErrConflict, "cleanup job is already %s", j.status)
+	}
+	if j.lastError != "" {
+		return apperror.New(apperror.ErrConflict, "cleanup job has failures: %s", j.lastError)
+	}
+	now := time.Now()
+	j.status = CleanupStatusCompleted
+	j.updatedAt = now
+	j.completedAt = &now
+	return nil
+}
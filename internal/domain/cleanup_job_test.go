@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+)
+
+func TestNewCleanupJob_Invalid(t *testing.T) {
+	if _, err := NewCleanupJob("", CleanupScopeProject, "project-1"); err == nil {
+		t.Errorf("expected error for empty id")
+	}
+	if _, err := NewCleanupJob("event-1", "TEAM", "project-1"); err == nil {
+		t.Errorf("expected error for invalid scope")
+	}
+	if _, err := NewCleanupJob("event-1", CleanupScopeOwner, ""); err == nil {
+		t.Errorf("expected error for empty subjectID")
+	}
+}
+
+func TestCleanupJob_RecordBatchAndComplete(t *testing.T) {
+	job, err := NewCleanupJob("event-1", CleanupScopeProject, "project-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := job.RecordBatch(3, errors.New("storage down")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.LastError() != "storage down" {
+		t.Errorf("expected batch error to be kept, got %q", job.LastError())
+	}
+	if err := job.Complete(); !errors.Is(err, apperror.ErrConflict) {
+		t.Errorf("expected conflict completing a job with failures, got %v", err)
+	}
+
+	if err := job.RecordBatch(2, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := job.Complete(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if job.TrashedCount() != 5 || job.Status() != CleanupStatusCompleted || job.CompletedAt() == nil {
+		t.Errorf("unexpected job state: %+v", job)
+	}
+	if err := job.RecordBatch(1, nil); !errors.Is(err, apperror.ErrConflict) {
+		t.Errorf("expected conflict recording on a completed job, got %v", err)
+	}
+}
@@ -1,46 +1,134 @@
 package domain
 
 import (
-	"fmt"
+	"devconnectstorage/internal/apperror"
+	"strings"
 	"time"
 )
 
+const maxFileNameLength = 255
+
+const UnknownSize int64 = -1
+
 type File struct {
-	id         string
-	ownerID    string
-	projectID  *string
-	fileName   string
-	mimeType   string
-	size       int64
-	storageKey string
-	visibility Visibility
-	status     Status
-	createdAt  time.Time
+	id               string
+	ownerID          string
+	projectID        *string
+	fileName         string
+	mimeType         string
+	detectedMimeType string
+	size             int64
+	checksum         string
+	storageKey       string
+	visibility       Visibility
+	status           Status
+	createdAt        time.Time
+	deletedAt        *time.Time
+	version          int
+	versions         []FileVersion
+	shares           []FileShare
+	tags             []string
+	attributes       map[string]string
+	path             string
+	revision         int64
+	updatedAt        time.Time
+	reservedVersion  int
+}
+
+type RehydrateOption func(*File)
+
+func WithDeletedAt(deletedAt time.Time) RehydrateOption {
+	return func(f *File) {
+		f.deletedAt = &deletedAt
+	}
+}
+
+func WithChecksum(checksum string) RehydrateOption {
+	return func(f *File) {
+		f.checksum = checksum
+	}
+}
+
+func WithDetectedMimeType(mimeType string) RehydrateOption {
+	return func(f *File) {
+		f.detectedMimeType = mimeType
+	}
+}
+
+func WithVersions(current int, versions []FileVersion) RehydrateOption {
+	return func(f *File) {
+		f.version = current
+		f.versions = append([]FileVersion(nil), versions...)
+	}
+}
+
+func WithShares(shares []FileShare) RehydrateOption {
+	return func(f *File) {
+		f.shares = append([]FileShare(nil), shares...)
+	}
+}
+
+func WithTags(tags []string) RehydrateOption {
+	return func(f *File) {
+		f.tags = append([]string(nil), tags...)
+	}
+}
+
+func WithAttributes(attributes map[string]string) RehydrateOption {
+	return func(f *File) {
+		f.attributes = copyAttributes(attributes)
+	}
+}
+
+func WithPath(path string) RehydrateOption {
+	return func(f *File) {
+		f.path = path
+	}
+}
+
+// WithRevision restores the revision a file was stored at and when that
+// revision was written.
+func WithRevision(revision int64, updatedAt time.Time) RehydrateOption {
+	return func(f *File) {
+		f.revision = revision
+		f.updatedAt = updatedAt
+	}
+}
+
+// WithReservedVersion restores the highest version number handed out for the
+// file, including numbers whose content was never committed.
+func WithReservedVersion(number int) RehydrateOption {
+	return func(f *File) {
+		f.reservedVersion = number
+	}
 }
 
 func createFile(id string, ownerID string, projectID *string, fileName string, mimeType string, size int64, storageKey string, visibility Visibility, status Status, createdAt time.Time) (File, error) {
 	if id == "" {
-		return File{}, fmt.Errorf("id cannot be empty")
+		return File{}, apperror.New(apperror.ErrValidation, "id cannot be empty")
 	}
 	if ownerID == "" {
-		return File{}, fmt.Errorf("ownerID cannot be empty")
+		return File{}, apperror.New(apperror.ErrValidation, "ownerID cannot be empty")
 	}
 	if fileName == "" {
-		return File{}, fmt.Errorf("fileName cannot be empty")
+		return File{}, apperror.New(apperror.ErrValidation, "fileName cannot be empty")
 	}
-	if size < 0 {
-		return File{}, fmt.Errorf("size cannot be negative")
+	if size < 0 && (size != UnknownSize || (status != StatusPending && status != StatusFailed)) {
+		return File{}, apperror.New(apperror.ErrValidation, "size cannot be negative")
 	}
 
 	if !visibility.IsValid() {
-		return File{}, fmt.Errorf("invalid visibility value")
+		return File{}, apperror.New(apperror.ErrValidation, "invalid visibility value")
+	}
+	if visibility == VisibilityProject && projectID == nil {
+		return File{}, apperror.New(apperror.ErrValidation, "project visibility requires a projectID")
 	}
 	if !status.IsValid() {
-		return File{}, fmt.Errorf("invalid status value")
+		return File{}, apperror.New(apperror.ErrValidation, "invalid status value")
 	}
 
 	if createdAt.IsZero() {
-		return File{}, fmt.Errorf("createdAt cannot be zero")
+		return File{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
 	}
 
 	return File{
@@ -54,6 +142,7 @@ func createFile(id string, ownerID string, projectID *string, fileName string, m
 		visibility: visibility,
 		status:     status,
 		createdAt:  createdAt,
+		updatedAt:  createdAt,
 	}, nil
 }
 
@@ -62,8 +151,40 @@ func NewFile(id string, ownerID string, projectID *string, fileName string, mime
 	return createFile(id, ownerID, projectID, fileName, mimeType, size, "", visibility, StatusPending, time.Now())
 }
 
-func RehydrateFile(id string, ownerID string, projectID *string, fileName string, mimeType string, size int64, storageKey string, visibility Visibility, status Status, createdAt time.Time) (File, error) {
-	return createFile(id, ownerID, projectID, fileName, mimeType, size, storageKey, visibility, status, createdAt)
+func RehydrateFile(id string, ownerID string, projectID *string, fileName string, mimeType string, size int64, storageKey string, visibility Visibility, status Status, createdAt time.Time, options ...RehydrateOption) (File, error) {
+	file, err := createFile(id, ownerID, projectID, fileName, mimeType, size, storageKey, visibility, status, createdAt)
+	if err != nil {
+		return File{}, err
+	}
+	for _, option := range options {
+		option(&file)
+	}
+
+	if err := validateChecksum(file.checksum); err != nil {
+		return File{}, err
+	}
+
+	if file.revision < 0 {
+		return File{}, apperror.New(apperror.ErrValidation, "revision cannot be negative")
+	}
+	if file.updatedAt.IsZero() {
+		file.updatedAt = file.createdAt
+	}
+
+	if file.status == StatusDeleted && file.deletedAt == nil {
+		return File{}, apperror.New(apperror.ErrValidation, "deletedAt cannot be empty for deleted files")
+	}
+
+	if len(file.versions) == 0 && file.storageKey != "" {
+		file.version = 1
+		file.versions = []FileVersion{file.firstVersion(file.createdAt)}
+	}
+	if len(file.versions) > 0 {
+		if _, found := file.VersionByNumber(file.version); !found {
+			return File{}, apperror.New(apperror.ErrValidation, "current version %d not found", file.version)
+		}
+	}
+	return file, nil
 }
 
 func (f File) ID() string {
@@ -90,6 +211,30 @@ func (f File) Size() int64 {
 	return f.size
 }
 
+func (f File) Checksum() string {
+	return f.checksum
+}
+
+// DetectedMimeType is the type sniffed from the current content, which may
+// differ from the declared MimeType. It is empty when nothing was sniffed.
+func (f File) DetectedMimeType() string {
+	return f.detectedMimeType
+}
+
+func (f File) Tags() []string {
+	return append([]string(nil), f.tags...)
+}
+
+func (f File) Attributes() map[string]string {
+	return copyAttributes(f.attributes)
+}
+
+// Path places a project file in a virtual folder tree. It is empty for files
+// without one.
+func (f File) Path() string {
+	return f.path
+}
+
 func (f File) StorageKey() string {
 	return f.storageKey
 }
@@ -106,14 +251,489 @@ func (f File) CreatedAt() time.Time {
 	return f.createdAt
 }
 
+func (f File) DeletedAt() *time.Time {
+	return f.deletedAt
+}
+
+// Revision counts the writes a stored file has seen. Repositories only accept
+// an update made from the latest revision, so concurrent changes are not lost.
+func (f File) Revision() int64 {
+	return f.revision
+}
+
+func (f File) UpdatedAt() time.Time {
+	return f.updatedAt
+}
+
+// ContentModifiedAt is when the current version's content was stored, which
+// only changes when a new version is added or an older one restored.
+func (f File) ContentModifiedAt() time.Time {
+	if version, found := f.VersionByNumber(f.version); found && !version.createdAt.IsZero() {
+		return version.createdAt
+	}
+	return f.createdAt
+}
+
+func (f File) Version() int {
+	return f.version
+}
+
+func (f File) Versions() []FileVersion {
+	return append([]FileVersion(nil), f.versions...)
+}
+
+// StoredBytes is the combined size of every version kept for the file.
+func (f File) StoredBytes() int64 {
+	var total int64
+	for _, version := range f.versions {
+		total += version.size
+	}
+	return total
+}
+
+func (f File) VersionByNumber(number int) (FileVersion, bool) {
+	for _, version := range f.versions {
+		if version.number == number {
+			return version, true
+		}
+	}
+	return FileVersion{}, false
+}
+
+func (f File) ContentAddressed() bool {
+	version, _ := f.VersionByNumber(f.version)
+	return version.contentAddressed
+}
+
+func (f File) Shares() []FileShare {
+	return append([]FileShare(nil), f.shares...)
+}
+
+func (f File) PermissionFor(profileID string) (Permission, bool) {
+	for _, share := range f.shares {
+		if share.profileID == profileID {
+			return share.permission, true
+		}
+	}
+	return "", false
+}
+
+func (f File) CanBeManagedBy(profileID string) bool {
+	if f.ownerID == profileID {
+		return true
+	}
+	permission, shared := f.PermissionFor(profileID)
+	return shared && permission == PermissionManage
+}
+
+// ReservedVersion is the highest version number handed out for the file.
+func (f File) ReservedVersion() int {
+	return f.reservedVersion
+}
+
+func (f File) NextVersionNumber() int {
+	next := f.reservedVersion + 1
+	for _, version := range f.versions {
+		if version.number >= next {
+			next = version.number + 1
+		}
+	}
+	return next
+}
+
+func (f File) AtVersion(number int) (File, error) {
+	version, found := f.VersionByNumber(number)
+	if !found {
+		return File{}, apperror.New(apperror.ErrNotFound, "version %d not found", number)
+	}
+	f.versions = f.Versions()
+	f.useVersion(version)
+	return f, nil
+}
+
+func (f *File) RecordStoredSize(size int64) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "size cannot be recorded for %s files", f.status)
+	}
+	if size < 0 {
+		return apperror.New(apperror.ErrValidation, "size cannot be negative")
+	}
+	if f.size != UnknownSize && f.size != size {
+		return apperror.New(apperror.ErrValidation, "stored size %d does not match declared size %d", size, f.size)
+	}
+	f.size = size
+	return nil
+}
+
+// RecordMimeType replaces the declared type of content that was uploaded
+// before it could be inspected, once the upload policy has resolved it.
+func (f *File) RecordMimeType(mimeType string) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "mime type cannot be recorded for %s files", f.status)
+	}
+	if strings.TrimSpace(mimeType) == "" {
+		return apperror.New(apperror.ErrValidation, "mime type cannot be empty")
+	}
+	f.mimeType = mimeType
+	return nil
+}
+
+func (f *File) RecordDetectedMimeType(mimeType string) error {
+	if f.status != StatusPending && f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "mime type cannot be recorded for %s files", f.status)
+	}
+	if strings.TrimSpace(mimeType) == "" {
+		return apperror.New(apperror.ErrValidation, "detected mime type cannot be empty")
+	}
+	f.detectedMimeType = mimeType
+	return nil
+}
+
+func (f *File) RecordChecksum(checksum string) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "checksum cannot be recorded for %s files", f.status)
+	}
+	if checksum == "" {
+		return apperror.New(apperror.ErrValidation, "checksum cannot be empty")
+	}
+	if err := validateChecksum(checksum); err != nil {
+		return err
+	}
+	f.checksum = checksum
+	return nil
+}
+
 func (f *File) MarkAsAvailable(storageKey string) error {
 	if f.status != StatusPending {
-		return fmt.Errorf("file cannot be marked as available from %s", f.status)
+		return apperror.New(apperror.ErrConflict, "file cannot be marked as available from %s", f.status)
 	}
 	if storageKey == "" {
-		return fmt.Errorf("storageKey cannot be empty")
+		return apperror.New(apperror.ErrValidation, "storageKey cannot be empty")
+	}
+	if f.size == UnknownSize {
+		return apperror.New(apperror.ErrConflict, "size must be recorded before the file is available")
 	}
 	f.status = StatusAvailable
 	f.storageKey = storageKey
+	f.version = 1
+	f.versions = []FileVersion{f.firstVersion(time.Now())}
 	return nil
 }
+
+func (f *File) MarkAsAvailableFromBlob(blob Blob) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "file cannot be marked as available from %s", f.status)
+	}
+	if f.checksum != "" && f.checksum != blob.checksum {
+		return apperror.New(apperror.ErrValidation, "blob checksum %s does not match file checksum %s", blob.checksum, f.checksum)
+	}
+	if f.size != UnknownSize && f.size != blob.size {
+		return apperror.New(apperror.ErrValidation, "blob size %d does not match declared size %d", blob.size, f.size)
+	}
+	f.checksum = blob.checksum
+	f.size = blob.size
+	if err := f.MarkAsAvailable(blob.storageKey); err != nil {
+		return err
+	}
+	f.versions[0].contentAddressed = true
+	return nil
+}
+
+func (f *File) MarkAsFailed() error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "file cannot be marked as failed from %s", f.status)
+	}
+	f.status = StatusFailed
+	return nil
+}
+
+func (f *File) Rename(requesterID string, fileName string) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	if fileName == "" {
+		return apperror.New(apperror.ErrValidation, "fileName cannot be empty")
+	}
+	if len(fileName) > maxFileNameLength {
+		return apperror.New(apperror.ErrValidation, "fileName cannot be longer than %d characters", maxFileNameLength)
+	}
+	if strings.ContainsAny(fileName, "/\\") {
+		return apperror.New(apperror.ErrValidation, "fileName cannot contain path separators")
+	}
+	f.fileName = fileName
+	return nil
+}
+
+func (f *File) ChangeVisibility(requesterID string, visibility Visibility) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	if !visibility.IsValid() {
+		return apperror.New(apperror.ErrValidation, "invalid visibility value")
+	}
+	if visibility == VisibilityProject && f.projectID == nil {
+		return apperror.New(apperror.ErrValidation, "project visibility requires a projectID")
+	}
+	f.visibility = visibility
+	return nil
+}
+
+func (f *File) MoveToProject(requesterID string, projectID *string) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	if projectID != nil && *projectID == "" {
+		return apperror.New(apperror.ErrValidation, "projectID cannot be empty")
+	}
+	if projectID == nil && f.visibility == VisibilityProject {
+		return apperror.New(apperror.ErrValidation, "project visibility requires a projectID")
+	}
+	if projectID == nil && f.path != "" {
+		return apperror.New(apperror.ErrValidation, "path requires a projectID")
+	}
+	f.projectID = projectID
+	return nil
+}
+
+// Annotate sets the tags and attributes a file is uploaded with.
+func (f *File) Annotate(tags []string, attributes map[string]string) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "cannot annotate a file in %s", f.status)
+	}
+	return f.annotate(tags, attributes)
+}
+
+func (f *File) ChangeTags(requesterID string, tags []string) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	return f.annotate(tags, f.attributes)
+}
+
+func (f *File) ChangeAttributes(requesterID string, attributes map[string]string) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	return f.annotate(f.tags, attributes)
+}
+
+// PlaceAt sets the path a file is uploaded to; an empty path leaves it
+// outside the folder tree.
+func (f *File) PlaceAt(path string) error {
+	if f.status != StatusPending {
+		return apperror.New(apperror.ErrConflict, "cannot place a file in %s", f.status)
+	}
+	return f.place(path)
+}
+
+// ChangePath moves a file within its project's folder tree; an empty path
+// removes it from the tree.
+func (f *File) ChangePath(requesterID string, path string) error {
+	if err := f.checkEditableBy(requesterID); err != nil {
+		return err
+	}
+	return f.place(path)
+}
+
+func (f *File) place(path string) error {
+	if path == "" {
+		f.path = ""
+		return nil
+	}
+	if f.projectID == nil {
+		return apperror.New(apperror.ErrValidation, "path requires a projectID")
+	}
+	normalized, err := NormalizePath(path)
+	if err != nil {
+		return err
+	}
+	f.path = normalized
+	return nil
+}
+
+func (f *File) annotate(tags []string, attributes map[string]string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	if err := ValidateAttributes(attributes); err != nil {
+		return err
+	}
+	f.tags = normalized
+	f.attributes = copyAttributes(attributes)
+	return nil
+}
+
+func (f *File) RelocateVersion(number int, storageKey string) error {
+	if storageKey == "" {
+		return apperror.New(apperror.ErrValidation, "storageKey cannot be empty")
+	}
+	for i, version := range f.versions {
+		if version.number != number {
+			continue
+		}
+		f.versions = f.Versions()
+		f.versions[i].storageKey = storageKey
+		if f.version == number {
+			f.storageKey = storageKey
+		}
+		return nil
+	}
+	return apperror.New(apperror.ErrNotFound, "version %d not found", number)
+}
+
+func (f File) checkEditableBy(requesterID string) error {
+	if f.ownerID != requesterID {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "file cannot be updated in %s", f.status)
+	}
+	return nil
+}
+
+func (f *File) AddVersion(storageKey string, mimeType string, size int64, checksum string) error {
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "cannot add a version to a file in %s", f.status)
+	}
+	return f.appendVersion(f.NextVersionNumber(), storageKey, mimeType, size, checksum)
+}
+
+// ReserveVersion hands out the next version number so its content can be
+// stored before the version is added. Storing the file with the reservation
+// makes concurrent uploads conflict instead of sharing a number.
+func (f *File) ReserveVersion() (int, error) {
+	if f.status != StatusAvailable {
+		return 0, apperror.New(apperror.ErrConflict, "cannot add a version to a file in %s", f.status)
+	}
+	f.reservedVersion = f.NextVersionNumber()
+	return f.reservedVersion, nil
+}
+
+// AddReservedVersion adds a version under a number taken with ReserveVersion.
+func (f *File) AddReservedVersion(number int, storageKey string, mimeType string, size int64, checksum string) error {
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "cannot add a version to a file in %s", f.status)
+	}
+	if number < 1 || number > f.reservedVersion {
+		return apperror.New(apperror.ErrConflict, "version %d was not reserved", number)
+	}
+	if _, found := f.VersionByNumber(number); found {
+		return apperror.New(apperror.ErrConflict, "version %d already exists", number)
+	}
+	return f.appendVersion(number, storageKey, mimeType, size, checksum)
+}
+
+func (f *File) appendVersion(number int, storageKey string, mimeType string, size int64, checksum string) error {
+	version, err := RehydrateFileVersion(number, storageKey, mimeType, size, checksum, false, time.Now())
+	if err != nil {
+		return err
+	}
+	f.versions = append(f.Versions(), version)
+	f.useVersion(version)
+	return nil
+}
+
+func (f *File) RestoreVersion(number int) error {
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "cannot restore a version of a file in %s", f.status)
+	}
+	version, found := f.VersionByNumber(number)
+	if !found {
+		return apperror.New(apperror.ErrNotFound, "version %d not found", number)
+	}
+	f.useVersion(version)
+	return nil
+}
+
+func (f *File) useVersion(version FileVersion) {
+	f.version = version.number
+	f.storageKey = version.storageKey
+	f.mimeType = version.mimeType
+	f.size = version.size
+	f.checksum = version.checksum
+	f.detectedMimeType = ""
+}
+
+func (f File) firstVersion(createdAt time.Time) FileVersion {
+	return FileVersion{
+		number:     1,
+		storageKey: f.storageKey,
+		mimeType:   f.mimeType,
+		size:       f.size,
+		checksum:   f.checksum,
+		createdAt:  createdAt,
+	}
+}
+
+func (f *File) ShareWith(requesterID string, profileID string, permission Permission) error {
+	if !f.CanBeManagedBy(requesterID) {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "file cannot be shared in %s", f.status)
+	}
+	if profileID == f.ownerID {
+		return apperror.New(apperror.ErrValidation, "file cannot be shared with its owner")
+	}
+	share, err := RehydrateFileShare(profileID, permission, time.Now())
+	if err != nil {
+		return err
+	}
+
+	shares := f.Shares()
+	for i, existing := range shares {
+		if existing.profileID == profileID {
+			share.createdAt = existing.createdAt
+			shares[i] = share
+			f.shares = shares
+			return nil
+		}
+	}
+	f.shares = append(shares, share)
+	return nil
+}
+
+func (f *File) RevokeShare(requesterID string, profileID string) error {
+	if !f.CanBeManagedBy(requesterID) {
+		return apperror.New(apperror.ErrForbidden, "unauthorized")
+	}
+	shares := f.Shares()
+	for i, existing := range shares {
+		if existing.profileID == profileID {
+			f.shares = append(shares[:i], shares[i+1:]...)
+			return nil
+		}
+	}
+	return apperror.New(apperror.ErrNotFound, "share not found")
+}
+
+func (f *File) MarkAsDeleted() error {
+	if f.status != StatusAvailable {
+		return apperror.New(apperror.ErrConflict, "file cannot be deleted from %s", f.status)
+	}
+	deletedAt := time.Now()
+	f.status = StatusDeleted
+	f.deletedAt = &deletedAt
+	return nil
+}
+
+func (f *File) Restore() error {
+	if f.status != StatusDeleted {
+		return apperror.New(apperror.ErrConflict, "file cannot be restored from %s", f.status)
+	}
+	f.status = StatusAvailable
+	f.deletedAt = nil
+	return nil
+}
+
+func copyAttributes(attributes map[string]string) map[string]string {
+	if len(attributes) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(attributes))
+	for key, value := range attributes {
+		copied[key] = value
+	}
+	return copied
+}
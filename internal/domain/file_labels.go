@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"regexp"
+	"strings"
+)
+
+const (
+	maxTags                 = 20
+	maxTagLength            = 50
+	maxAttributes           = 20
+	maxAttributeValueLength = 2048
+)
+
+var attributeKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_-]{0,63}$`)
+
+// NormalizeTags lowercases and trims tags, dropping duplicates while keeping
+// the order they were given in.
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			return nil, apperror.New(apperror.ErrValidation, "tags cannot be empty")
+		}
+		if len(tag) > maxTagLength {
+			return nil, apperror.New(apperror.ErrValidation, "tags cannot be longer than %d characters", maxTagLength)
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, apperror.New(apperror.ErrValidation, "a file cannot have more than %d tags", maxTags)
+	}
+	return normalized, nil
+}
+
+// ValidateAttributes keeps keys to lowercase identifiers, which also makes
+// them safe to use as document field names.
+func ValidateAttributes(attributes map[string]string) error {
+	if len(attributes) > maxAttributes {
+		return apperror.New(apperror.ErrValidation, "a file cannot have more than %d attributes", maxAttributes)
+	}
+	for key, value := range attributes {
+		if !attributeKeyPattern.MatchString(key) {
+			return apperror.New(apperror.ErrValidation, "invalid attribute key %q", key)
+		}
+		if len(value) > maxAttributeValueLength {
+			return apperror.New(apperror.ErrValidation, "attribute %q cannot be longer than %d characters", key, maxAttributeValueLength)
+		}
+	}
+	return nil
+}
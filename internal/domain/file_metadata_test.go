@@ -0,0 +1,262 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func availableFileOwnedBy(t *testing.T, ownerID string) File {
+	file, err := RehydrateFile("file-1", ownerID, nil, "file.txt", "text/plain", 100, "s3/key", VisibilityPrivate, StatusAvailable, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return file
+}
+
+func TestRename_Success(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.Rename("user-1", "renamed.txt"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.FileName() != "renamed.txt" {
+		t.Errorf("fileName mismatch")
+	}
+}
+
+func TestRename_Invalid(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.Rename("user-2", "renamed.txt"); err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected unauthorized error for different owner")
+	}
+	if err := file.Rename("user-1", ""); err == nil {
+		t.Errorf("expected error for empty fileName")
+	}
+	if err := file.Rename("user-1", "dir/file.txt"); err == nil {
+		t.Errorf("expected error for fileName with path separator")
+	}
+	if err := file.Rename("user-1", strings.Repeat("a", 256)); err == nil {
+		t.Errorf("expected error for too long fileName")
+	}
+	if file.FileName() != "file.txt" {
+		t.Errorf("fileName must not change on invalid rename")
+	}
+}
+
+func TestRename_DeletedFile(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	_ = file.MarkAsDeleted()
+
+	if err := file.Rename("user-1", "renamed.txt"); err == nil {
+		t.Fatalf("expected error when renaming a deleted file")
+	}
+}
+
+func TestChangeVisibility_Success(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.ChangeVisibility("user-1", VisibilityPublic); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Visibility() != VisibilityPublic {
+		t.Errorf("visibility mismatch")
+	}
+}
+
+func TestChangeVisibility_Invalid(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.ChangeVisibility("user-2", VisibilityPublic); err == nil {
+		t.Errorf("expected error for different owner")
+	}
+	if err := file.ChangeVisibility("user-1", "INVALID"); err == nil {
+		t.Errorf("expected error for invalid visibility")
+	}
+}
+
+func TestMoveToProject_Success(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	projectID := "project-1"
+
+	if err := file.MoveToProject("user-1", &projectID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.ProjectID() == nil || *file.ProjectID() != projectID {
+		t.Errorf("projectID mismatch")
+	}
+	if err := file.MoveToProject("user-1", nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.ProjectID() != nil {
+		t.Errorf("expected projectID to be removed")
+	}
+}
+
+func TestMoveToProject_Invalid(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	empty := ""
+	projectID := "project-1"
+
+	if err := file.MoveToProject("user-2", &projectID); err == nil {
+		t.Errorf("expected error for different owner")
+	}
+	if err := file.MoveToProject("user-1", &empty); err == nil {
+		t.Errorf("expected error for empty projectID")
+	}
+}
+
+func TestRelocateVersion(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	_ = file.AddVersion("s3/key-v2", "text/plain", 10, "")
+
+	if err := file.RelocateVersion(1, "moved/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := file.RelocateVersion(2, "moved/v2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, _ := file.VersionByNumber(1)
+	if first.StorageKey() != "moved/v1" {
+		t.Errorf("expected version 1 to be relocated")
+	}
+	if file.StorageKey() != "moved/v2" {
+		t.Errorf("expected current storageKey to follow the current version")
+	}
+	if err := file.RelocateVersion(3, "moved/v3"); err == nil {
+		t.Errorf("expected error for unknown version")
+	}
+}
+
+func TestProjectVisibility_RequiresProject(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	projectID := "project-1"
+
+	if err := file.ChangeVisibility("user-1", VisibilityProject); err == nil {
+		t.Fatalf("expected error for project visibility without projectID")
+	}
+	if err := file.MoveToProject("user-1", &projectID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := file.ChangeVisibility("user-1", VisibilityProject); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := file.MoveToProject("user-1", nil); err == nil {
+		t.Errorf("expected error when detaching a project visible file")
+	}
+}
+
+func TestAnnotate_NormalizesTags(t *testing.T) {
+	file, _ := NewFile("file-1", "user-1", nil, "file.txt", "text/plain", 10, VisibilityPrivate)
+
+	if err := file.Annotate([]string{" Diagram ", "diagram", "ARCH"}, map[string]string{"kind": "avatar"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(file.Tags(), ",") != "diagram,arch" {
+		t.Errorf("unexpected tags: %v", file.Tags())
+	}
+	if file.Attributes()["kind"] != "avatar" {
+		t.Errorf("attributes mismatch")
+	}
+
+	_ = file.MarkAsAvailable("s3/key")
+	if err := file.Annotate([]string{"late"}, nil); err == nil {
+		t.Errorf("expected error when annotating an available file")
+	}
+}
+
+func TestChangeTags_Invalid(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.ChangeTags("user-2", []string{"a"}); err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected unauthorized error for different owner")
+	}
+	if err := file.ChangeTags("user-1", []string{" "}); err == nil {
+		t.Errorf("expected error for empty tag")
+	}
+	if err := file.ChangeTags("user-1", []string{strings.Repeat("a", maxTagLength+1)}); err == nil {
+		t.Errorf("expected error for too long tag")
+	}
+	tooMany := make([]string, 0, maxTags+1)
+	for i := 0; i <= maxTags; i++ {
+		tooMany = append(tooMany, strings.Repeat("t", i+1))
+	}
+	if err := file.ChangeTags("user-1", tooMany); err == nil {
+		t.Errorf("expected error for too many tags")
+	}
+	if len(file.Tags()) != 0 {
+		t.Errorf("tags must not change on invalid update")
+	}
+}
+
+func TestChangeAttributes(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	_ = file.ChangeTags("user-1", []string{"keep"})
+
+	if err := file.ChangeAttributes("user-1", map[string]string{"alt_text": "A cat", "description": "Profile picture"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Attributes()["description"] != "Profile picture" || len(file.Tags()) != 1 {
+		t.Errorf("attributes mismatch")
+	}
+	for _, key := range []string{"", "Kind", "a.b", "$where"} {
+		if err := file.ChangeAttributes("user-1", map[string]string{key: "x"}); err == nil {
+			t.Errorf("expected error for attribute key %q", key)
+		}
+	}
+	if err := file.ChangeAttributes("user-1", map[string]string{"long": strings.Repeat("a", maxAttributeValueLength+1)}); err == nil {
+		t.Errorf("expected error for too long attribute value")
+	}
+}
+
+func TestNormalizePath(t *testing.T) {
+	path, err := NormalizePath(" /docs/img/arch.png/ ")
+	if err != nil || path != "docs/img/arch.png" {
+		t.Fatalf("unexpected result %q, %v", path, err)
+	}
+	for _, invalid := range []string{"", "/", "docs//arch.png", "../arch.png", "docs/./arch.png", `docs\arch.png`, "docs/\x00", strings.Repeat("a", maxPathLength+1)} {
+		if _, err := NormalizePath(invalid); err == nil {
+			t.Errorf("expected error for path %q", invalid)
+		}
+	}
+}
+
+func TestChangePath(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	projectID := "project-1"
+
+	if err := file.ChangePath("user-1", "docs/arch.png"); err == nil {
+		t.Errorf("expected error for a path outside a project")
+	}
+	_ = file.MoveToProject("user-1", &projectID)
+	if err := file.ChangePath("user-2", "docs/arch.png"); err == nil {
+		t.Errorf("expected error for different owner")
+	}
+	if err := file.ChangePath("user-1", "/docs/arch.png"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Path() != "docs/arch.png" {
+		t.Errorf("path mismatch")
+	}
+	if err := file.MoveToProject("user-1", nil); err == nil {
+		t.Errorf("expected error when detaching a file with a path")
+	}
+	if err := file.ChangePath("user-1", ""); err != nil || file.Path() != "" {
+		t.Errorf("expected path to be cleared")
+	}
+}
+
+func TestPlaceAt_OnlyNewFiles(t *testing.T) {
+	projectID := "project-1"
+	file, _ := NewFile("file-1", "user-1", &projectID, "arch.png", "image/png", 10, VisibilityProject)
+
+	if err := file.PlaceAt("docs/arch.png"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = file.MarkAsAvailable("s3/key")
+	if err := file.PlaceAt("other/arch.png"); err == nil {
+		t.Errorf("expected error when placing an available file")
+	}
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"strings"
+	"unicode"
+)
+
+const maxPathLength = 1024
+
+// NormalizePath validates a slash separated path relative to a project's
+// root, such as "docs/img/arch.png". Surrounding slashes are dropped.
+func NormalizePath(path string) (string, error) {
+	path = strings.Trim(strings.TrimSpace(path), "/")
+	if path == "" {
+		return "", apperror.New(apperror.ErrValidation, "path cannot be empty")
+	}
+	if len(path) > maxPathLength {
+		return "", apperror.New(apperror.ErrValidation, "path cannot be longer than %d characters", maxPathLength)
+	}
+	if strings.ContainsFunc(path, func(r rune) bool { return r == '\\' || unicode.IsControl(r) }) {
+		return "", apperror.New(apperror.ErrValidation, "path cannot contain backslashes or control characters")
+	}
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return "", apperror.New(apperror.ErrValidation, "path cannot contain empty, . or .. segments")
+		}
+	}
+	return path, nil
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+type FileShare struct {
+	profileID  string
+	permission Permission
+	createdAt  time.Time
+}
+
+func RehydrateFileShare(profileID string, permission Permission, createdAt time.Time) (FileShare, error) {
+	if profileID == "" {
+		return FileShare{}, apperror.New(apperror.ErrValidation, "profileID cannot be empty")
+	}
+	if !permission.IsValid() {
+		return FileShare{}, apperror.New(apperror.ErrValidation, "invalid permission value")
+	}
+	if createdAt.IsZero() {
+		return FileShare{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	return FileShare{
+		profileID:  profileID,
+		permission: permission,
+		createdAt:  createdAt,
+	}, nil
+}
+
+func (s FileShare) ProfileID() string {
+	return s.profileID
+}
+
+func (s FileShare) Permission() Permission {
+	return s.permission
+}
+
+func (s FileShare) CreatedAt() time.Time {
+	return s.createdAt
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRehydrateFileShare_Invalid(t *testing.T) {
+	if _, err := RehydrateFileShare("", PermissionRead, time.Now()); err == nil {
+		t.Errorf("expected error for empty profileID")
+	}
+	if _, err := RehydrateFileShare("user-2", "OWNER", time.Now()); err == nil {
+		t.Errorf("expected error for invalid permission")
+	}
+	if _, err := RehydrateFileShare("user-2", PermissionRead, time.Time{}); err == nil {
+		t.Errorf("expected error for zero createdAt")
+	}
+}
+
+func TestShareWith_Success(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.ShareWith("user-1", "user-2", PermissionRead); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	permission, shared := file.PermissionFor("user-2")
+	if !shared || permission != PermissionRead {
+		t.Errorf("expected read share for user-2")
+	}
+	if file.CanBeManagedBy("user-2") {
+		t.Errorf("read share must not grant manage")
+	}
+
+	if err := file.ShareWith("user-1", "user-2", PermissionManage); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(file.Shares()) != 1 {
+		t.Errorf("expected share to be updated in place")
+	}
+	if !file.CanBeManagedBy("user-2") {
+		t.Errorf("manage share must grant manage")
+	}
+}
+
+func TestShareWith_Invalid(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+
+	if err := file.ShareWith("user-2", "user-3", PermissionRead); err == nil || err.Error() != "unauthorized" {
+		t.Errorf("expected unauthorized error for non owner")
+	}
+	if err := file.ShareWith("user-1", "user-1", PermissionRead); err == nil {
+		t.Errorf("expected error when sharing with owner")
+	}
+	if err := file.ShareWith("user-1", "user-2", "WRITE"); err == nil {
+		t.Errorf("expected error for invalid permission")
+	}
+	_ = file.MarkAsDeleted()
+	if err := file.ShareWith("user-1", "user-2", PermissionRead); err == nil {
+		t.Errorf("expected error when sharing a deleted file")
+	}
+}
+
+func TestShareWith_ManagerCanShare(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	_ = file.ShareWith("user-1", "user-2", PermissionManage)
+
+	if err := file.ShareWith("user-2", "user-3", PermissionRead); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestRevokeShare(t *testing.T) {
+	file := availableFileOwnedBy(t, "user-1")
+	_ = file.ShareWith("user-1", "user-2", PermissionRead)
+
+	if err := file.RevokeShare("user-2", "user-2"); err == nil {
+		t.Errorf("expected unauthorized error for read share")
+	}
+	if err := file.RevokeShare("user-1", "user-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, shared := file.PermissionFor("user-2"); shared {
+		t.Errorf("expected share to be revoked")
+	}
+	if err := file.RevokeShare("user-1", "user-2"); err == nil {
+		t.Errorf("expected error for unknown share")
+	}
+}
@@ -111,6 +111,24 @@ func TestRehydrateFile_InvalidVisibility(t *testing.T) {
 	}
 }
 
+func TestRehydrateFile_ProjectVisibilityRequiresProject(t *testing.T) {
+	_, err := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityProject,
+		StatusAvailable,
+		time.Now(),
+	)
+	if err == nil {
+		t.Fatalf("expected error for project visibility without projectID")
+	}
+}
+
 func TestRehydrateFile_InvalidStatus(t *testing.T) {
 	_, err := RehydrateFile(
 		"file-1",
@@ -288,3 +306,334 @@ func TestMarkAsAvailable_EmptyStorageKey(t *testing.T) {
 		t.Fatalf("expected error for empty storageKey")
 	}
 }
+
+func TestRehydrateFile_DeletedRequiresDeletedAt(t *testing.T) {
+	_, err := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusDeleted,
+		time.Now(),
+	)
+	if err == nil {
+		t.Fatalf("expected error for deleted file without deletedAt")
+	}
+}
+
+func TestRehydrateFile_WithDeletedAt(t *testing.T) {
+	deletedAt := time.Now()
+	file, err := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusDeleted,
+		time.Now(),
+		WithDeletedAt(deletedAt),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.DeletedAt() == nil || !file.DeletedAt().Equal(deletedAt) {
+		t.Errorf("deletedAt mismatch")
+	}
+}
+
+func TestMarkAsDeleted_Success(t *testing.T) {
+	file, _ := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusAvailable,
+		time.Now(),
+	)
+
+	err := file.MarkAsDeleted()
+	if err != nil {
+		t.Fatalf("unexpected error")
+	}
+	if file.Status() != StatusDeleted {
+		t.Errorf("expected status deleted")
+	}
+	if file.DeletedAt() == nil {
+		t.Errorf("expected deletedAt to be set")
+	}
+}
+
+func TestMarkAsDeleted_InvalidTransition(t *testing.T) {
+	file, _ := NewFile(
+		"1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		VisibilityPrivate,
+	)
+
+	err := file.MarkAsDeleted()
+	if err == nil {
+		t.Fatalf("expected error for invalid status transition")
+	}
+}
+
+func TestRestore_Success(t *testing.T) {
+	file, _ := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusDeleted,
+		time.Now(),
+		WithDeletedAt(time.Now()),
+	)
+
+	err := file.Restore()
+	if err != nil {
+		t.Fatalf("unexpected error")
+	}
+	if file.Status() != StatusAvailable {
+		t.Errorf("expected status available")
+	}
+	if file.DeletedAt() != nil {
+		t.Errorf("expected deletedAt to be cleared")
+	}
+}
+
+func TestRestore_InvalidTransition(t *testing.T) {
+	file, _ := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusAvailable,
+		time.Now(),
+	)
+
+	err := file.Restore()
+	if err == nil {
+		t.Fatalf("expected error for invalid status transition")
+	}
+}
+
+func TestMarkAsFailed_Success(t *testing.T) {
+	file, _ := NewFile(
+		"1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		VisibilityPrivate,
+	)
+
+	err := file.MarkAsFailed()
+	if err != nil {
+		t.Fatalf("unexpected error")
+	}
+	if file.Status() != StatusFailed {
+		t.Errorf("expected status failed")
+	}
+	if err := file.MarkAsAvailable("s3/key"); err == nil {
+		t.Errorf("expected failed file to not become available")
+	}
+}
+
+func TestMarkAsFailed_InvalidTransition(t *testing.T) {
+	file, _ := RehydrateFile(
+		"file-1",
+		"user-1",
+		nil,
+		"file.txt",
+		"text/plain",
+		100,
+		"s3/key",
+		VisibilityPrivate,
+		StatusAvailable,
+		time.Now(),
+	)
+
+	err := file.MarkAsFailed()
+	if err == nil {
+		t.Fatalf("expected error for invalid status transition")
+	}
+}
+
+func TestNewFile_AcceptsUnknownSize(t *testing.T) {
+	file, err := NewFile("1", "user-1", nil, "file.txt", "text/plain", UnknownSize, VisibilityPrivate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file.Size() != UnknownSize {
+		t.Errorf("expected unknown size")
+	}
+}
+
+func TestRehydrateFile_RejectsUnknownSizeForAvailableFile(t *testing.T) {
+	_, err := RehydrateFile("file-1", "user-1", nil, "file.txt", "text/plain", UnknownSize, "s3/key", VisibilityPrivate, StatusAvailable, time.Now())
+	if err == nil {
+		t.Fatalf("expected error for unknown size on available file")
+	}
+}
+
+func TestRecordStoredSize_SetsUnknownSize(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", UnknownSize, VisibilityPrivate)
+
+	if err := file.RecordStoredSize(42); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file.Size() != 42 {
+		t.Errorf("expected size 42, got %d", file.Size())
+	}
+}
+
+func TestRecordStoredSize_RejectsMismatch(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", 100, VisibilityPrivate)
+
+	if err := file.RecordStoredSize(42); err == nil {
+		t.Fatalf("expected error for size mismatch")
+	}
+}
+
+func TestRecordMimeType_OnlyForPendingFiles(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.bin", "application/octet-stream", 100, VisibilityPrivate)
+
+	if err := file.RecordMimeType("image/png"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.MimeType() != "image/png" {
+		t.Errorf("expected mime type image/png, got %s", file.MimeType())
+	}
+
+	_ = file.MarkAsAvailable("s3/key")
+	if err := file.RecordMimeType("text/plain"); err == nil {
+		t.Fatalf("expected error for an available file")
+	}
+}
+
+func TestMarkAsAvailable_RejectsUnknownSize(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", UnknownSize, VisibilityPrivate)
+
+	if err := file.MarkAsAvailable("s3/key"); err == nil {
+		t.Fatalf("expected error when size was never recorded")
+	}
+}
+
+func TestRecordChecksum_SetsChecksumOnFirstVersion(t *testing.T) {
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", 5, VisibilityPrivate)
+
+	if err := file.RecordChecksum(checksum); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = file.MarkAsAvailable("s3/key")
+
+	if file.Checksum() != checksum {
+		t.Errorf("expected checksum to be recorded")
+	}
+	if version, _ := file.VersionByNumber(1); version.Checksum() != checksum {
+		t.Errorf("expected first version to carry the checksum")
+	}
+}
+
+func TestRecordChecksum_RejectsInvalidDigest(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", 5, VisibilityPrivate)
+
+	for _, checksum := range []string{"", "abc", "2CF24DBA5FB0A30E26E83B2AC5B9E29E1B161E5C1FA7425E73043362938B9824"} {
+		if err := file.RecordChecksum(checksum); err == nil {
+			t.Errorf("expected error for checksum %q", checksum)
+		}
+	}
+}
+
+func TestRestoreVersion_RestoresVersionChecksum(t *testing.T) {
+	const first = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	const second = "486ea46224d1bb4fb680f34f7c9ad96a8f24ec88be73ea8e5a6c65260e9cb8a7"
+	file, _ := RehydrateFile("1", "user-1", nil, "file.txt", "text/plain", 5, "s3/key", VisibilityPrivate, StatusAvailable, time.Now(), WithChecksum(first))
+
+	_ = file.AddVersion("s3/key-v2", "text/plain", 5, second)
+	if file.Checksum() != second {
+		t.Fatalf("expected checksum of the new version")
+	}
+
+	_ = file.RestoreVersion(1)
+	if file.Checksum() != first {
+		t.Errorf("expected checksum of the restored version")
+	}
+}
+
+func TestRecordDetectedMimeType_IsClearedWhenVersionChanges(t *testing.T) {
+	file, _ := RehydrateFile("1", "user-1", nil, "file.png", "image/png", 5, "s3/key", VisibilityPrivate, StatusAvailable, time.Now(), WithDetectedMimeType("image/png"))
+
+	_ = file.AddVersion("s3/key-v2", "text/html", 5, "")
+	if file.DetectedMimeType() != "" {
+		t.Fatalf("expected detected mime type to be cleared")
+	}
+
+	if err := file.RecordDetectedMimeType("text/html"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.DetectedMimeType() != "text/html" {
+		t.Errorf("expected detected mime type to be recorded")
+	}
+	if err := file.RecordDetectedMimeType(""); err == nil {
+		t.Errorf("expected error for empty mime type")
+	}
+}
+
+func TestStoredBytes_SumsAllVersions(t *testing.T) {
+	file, _ := RehydrateFile("1", "user-1", nil, "file.txt", "text/plain", 5, "s3/key", VisibilityPrivate, StatusAvailable, time.Now())
+	_ = file.AddVersion("s3/key-v2", "text/plain", 7, "")
+
+	if file.StoredBytes() != 12 {
+		t.Errorf("expected 12 stored bytes, got %d", file.StoredBytes())
+	}
+}
+
+func TestRehydrateFile_Revision(t *testing.T) {
+	createdAt := time.Now().Add(-time.Hour)
+	file, err := RehydrateFile("1", "owner", nil, "a.txt", "text/plain", 1, "key", VisibilityPrivate, StatusAvailable, createdAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Revision() != 0 || !file.UpdatedAt().Equal(createdAt) {
+		t.Errorf("expected revision 0 updated at creation, got %d at %v", file.Revision(), file.UpdatedAt())
+	}
+
+	updatedAt := time.Now()
+	file, err = RehydrateFile("1", "owner", nil, "a.txt", "text/plain", 1, "key", VisibilityPrivate, StatusAvailable, createdAt, WithRevision(3, updatedAt))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Revision() != 3 || !file.UpdatedAt().Equal(updatedAt) {
+		t.Errorf("expected revision 3, got %d", file.Revision())
+	}
+
+	if _, err := RehydrateFile("1", "owner", nil, "a.txt", "text/plain", 1, "key", VisibilityPrivate, StatusAvailable, createdAt, WithRevision(-1, updatedAt)); err == nil {
+		t.Errorf("expected error for negative revision")
+	}
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+type FileVersion struct {
+	number           int
+	storageKey       string
+	mimeType         string
+	size             int64
+	checksum         string
+	createdAt        time.Time
+	contentAddressed bool
+}
+
+func RehydrateFileVersion(number int, storageKey string, mimeType string, size int64, checksum string, contentAddressed bool, createdAt time.Time) (FileVersion, error) {
+	if number < 1 {
+		return FileVersion{}, apperror.New(apperror.ErrValidation, "version number must be positive")
+	}
+	if storageKey == "" {
+		return FileVersion{}, apperror.New(apperror.ErrValidation, "storageKey cannot be empty")
+	}
+	if size < 0 {
+		return FileVersion{}, apperror.New(apperror.ErrValidation, "size cannot be negative")
+	}
+	if err := validateChecksum(checksum); err != nil {
+		return FileVersion{}, err
+	}
+	if contentAddressed && checksum == "" {
+		return FileVersion{}, apperror.New(apperror.ErrValidation, "content-addressed versions require a checksum")
+	}
+	if createdAt.IsZero() {
+		return FileVersion{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	return FileVersion{
+		number:           number,
+		storageKey:       storageKey,
+		mimeType:         mimeType,
+		size:             size,
+		checksum:         checksum,
+		createdAt:        createdAt,
+		contentAddressed: contentAddressed,
+	}, nil
+}
+
+func (v FileVersion) Number() int {
+	return v.number
+}
+
+func (v FileVersion) StorageKey() string {
+	return v.storageKey
+}
+
+func (v FileVersion) MimeType() string {
+	return v.mimeType
+}
+
+func (v FileVersion) Size() int64 {
+	return v.size
+}
+
+func (v FileVersion) Checksum() string {
+	return v.checksum
+}
+
+func (v FileVersion) ContentAddressed() bool {
+	return v.contentAddressed
+}
+
+func (v FileVersion) CreatedAt() time.Time {
+	return v.createdAt
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func availableFileForVersions(t *testing.T) File {
+	file, err := NewFile("1", "user-1", nil, "file.txt", "text/plain", 100, VisibilityPrivate)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := file.MarkAsAvailable("key/v1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return file
+}
+
+func TestMarkAsAvailable_CreatesFirstVersion(t *testing.T) {
+	file := availableFileForVersions(t)
+
+	if file.Version() != 1 {
+		t.Errorf("expected current version 1")
+	}
+	versions := file.Versions()
+	if len(versions) != 1 || versions[0].StorageKey() != "key/v1" || versions[0].Size() != 100 {
+		t.Errorf("expected first version to mirror file content")
+	}
+}
+
+func TestAddVersion_BecomesCurrent(t *testing.T) {
+	file := availableFileForVersions(t)
+
+	if file.NextVersionNumber() != 2 {
+		t.Fatalf("expected next version 2")
+	}
+	if err := file.AddVersion("key/v2", "text/markdown", 200, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file.Version() != 2 {
+		t.Errorf("expected current version 2")
+	}
+	if file.StorageKey() != "key/v2" || file.Size() != 200 || file.MimeType() != "text/markdown" {
+		t.Errorf("expected file to point at the new version")
+	}
+	if len(file.Versions()) != 2 {
+		t.Errorf("expected two versions")
+	}
+}
+
+func TestAddVersion_InvalidStatus(t *testing.T) {
+	file, _ := NewFile("1", "user-1", nil, "file.txt", "text/plain", 100, VisibilityPrivate)
+
+	if err := file.AddVersion("key/v2", "text/plain", 10, ""); err == nil {
+		t.Fatalf("expected error when adding a version to a pending file")
+	}
+}
+
+func TestAddVersion_EmptyStorageKey(t *testing.T) {
+	file := availableFileForVersions(t)
+
+	if err := file.AddVersion("", "text/plain", 10, ""); err == nil {
+		t.Fatalf("expected error for empty storageKey")
+	}
+}
+
+func TestRestoreVersion_Success(t *testing.T) {
+	file := availableFileForVersions(t)
+	_ = file.AddVersion("key/v2", "text/plain", 200, "")
+
+	if err := file.RestoreVersion(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if file.Version() != 1 || file.StorageKey() != "key/v1" || file.Size() != 100 {
+		t.Errorf("expected file to point at version 1")
+	}
+	if file.NextVersionNumber() != 3 {
+		t.Errorf("expected history to be kept after rollback")
+	}
+}
+
+func TestRestoreVersion_UnknownVersion(t *testing.T) {
+	file := availableFileForVersions(t)
+
+	if err := file.RestoreVersion(7); err == nil {
+		t.Fatalf("expected error for unknown version")
+	}
+}
+
+func TestAtVersion_DoesNotChangeFile(t *testing.T) {
+	file := availableFileForVersions(t)
+	_ = file.AddVersion("key/v2", "text/plain", 200, "")
+
+	view, err := file.AtVersion(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if view.StorageKey() != "key/v1" || file.StorageKey() != "key/v2" {
+		t.Errorf("expected only the view to point at version 1")
+	}
+}
+
+func TestRehydrateFile_SynthesizesFirstVersion(t *testing.T) {
+	createdAt := time.Now()
+	file, err := RehydrateFile("file-1", "user-1", nil, "file.txt", "text/plain", 100, "s3/key", VisibilityPrivate, StatusAvailable, createdAt)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	version, found := file.VersionByNumber(1)
+	if !found || version.StorageKey() != "s3/key" || !version.CreatedAt().Equal(createdAt) {
+		t.Errorf("expected legacy file to expose its content as version 1")
+	}
+}
+
+func TestRehydrateFile_UnknownCurrentVersion(t *testing.T) {
+	version, _ := RehydrateFileVersion(1, "s3/key", "text/plain", 100, "", false, time.Now())
+	_, err := RehydrateFile("file-1", "user-1", nil, "file.txt", "text/plain", 100, "s3/key", VisibilityPrivate, StatusAvailable, time.Now(), WithVersions(2, []FileVersion{version}))
+	if err == nil {
+		t.Fatalf("expected error for unknown current version")
+	}
+}
+
+func TestRehydrateFileVersion_Invalid(t *testing.T) {
+	if _, err := RehydrateFileVersion(0, "key", "text/plain", 1, "", false, time.Now()); err == nil {
+		t.Errorf("expected error for invalid number")
+	}
+	if _, err := RehydrateFileVersion(1, "", "text/plain", 1, "", false, time.Now()); err == nil {
+		t.Errorf("expected error for empty storageKey")
+	}
+	if _, err := RehydrateFileVersion(1, "key", "text/plain", -1, "", false, time.Now()); err == nil {
+		t.Errorf("expected error for negative size")
+	}
+	if _, err := RehydrateFileVersion(1, "key", "text/plain", 1, "", false, time.Time{}); err == nil {
+		t.Errorf("expected error for zero createdAt")
+	}
+}
+
+func TestReserveVersion_SkipsReservedNumbers(t *testing.T) {
+	file := availableFileForVersions(t)
+
+	first, err := file.ReserveVersion()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, _ := file.ReserveVersion()
+	if first != 2 || second != 3 {
+		t.Fatalf("expected reservations 2 and 3, got %d and %d", first, second)
+	}
+
+	if err := file.AddReservedVersion(second, "key/v3", "text/plain", 10, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if file.Version() != 3 {
+		t.Errorf("expected current version 3")
+	}
+	if err := file.AddReservedVersion(second, "key/v3", "text/plain", 10, ""); err == nil {
+		t.Errorf("expected error when adding a version twice")
+	}
+	if err := file.AddReservedVersion(4, "key/v4", "text/plain", 10, ""); err == nil {
+		t.Errorf("expected error for a number that was not reserved")
+	}
+}
+
+func TestContentModifiedAt_FollowsCurrentVersion(t *testing.T) {
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	first, _ := RehydrateFileVersion(1, "key/v1", "text/plain", 10, "", false, createdAt)
+	second, _ := RehydrateFileVersion(2, "key/v2", "text/plain", 12, "", false, createdAt.Add(time.Hour))
+	file, err := RehydrateFile("1", "user-1", nil, "file.txt", "text/plain", 12, "key/v2", VisibilityPrivate, StatusAvailable, createdAt, WithVersions(2, []FileVersion{first, second}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !file.ContentModifiedAt().Equal(createdAt.Add(time.Hour)) {
+		t.Errorf("expected the second version's timestamp, got %v", file.ContentModifiedAt())
+	}
+	if err := file.RestoreVersion(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !file.ContentModifiedAt().Equal(createdAt) {
+		t.Errorf("expected the first version's timestamp, got %v", file.ContentModifiedAt())
+	}
+}
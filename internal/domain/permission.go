@@ -0,0 +1,12 @@
+package domain
+
+type Permission string
+
+const (
+	PermissionRead   Permission = "READ"
+	PermissionManage Permission = "MANAGE"
+)
+
+func (p Permission) IsValid() bool {
+	return p == PermissionRead || p == PermissionManage
+}
@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+type ShareLink struct {
+	id            string
+	fileID        string
+	ownerID       string
+	passwordHash  string
+	maxDownloads  int64
+	downloadCount int64
+	expiresAt     time.Time
+	createdAt     time.Time
+	revokedAt     *time.Time
+}
+
+func NewShareLink(id string, fileID string, ownerID string, expiresAt time.Time, maxDownloads int64, passwordHash string) (ShareLink, error) {
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "expiresAt must be in the future")
+	}
+	return RehydrateShareLink(id, fileID, ownerID, expiresAt, maxDownloads, 0, passwordHash, now, nil)
+}
+
+func RehydrateShareLink(id string, fileID string, ownerID string, expiresAt time.Time, maxDownloads int64, downloadCount int64, passwordHash string, createdAt time.Time, revokedAt *time.Time) (ShareLink, error) {
+	if id == "" {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "id cannot be empty")
+	}
+	if fileID == "" {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "fileID cannot be empty")
+	}
+	if ownerID == "" {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "ownerID cannot be empty")
+	}
+	if maxDownloads < 0 {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "maxDownloads cannot be negative")
+	}
+	if downloadCount < 0 {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "downloadCount cannot be negative")
+	}
+	if expiresAt.IsZero() {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "expiresAt cannot be zero")
+	}
+	if createdAt.IsZero() {
+		return ShareLink{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	return ShareLink{
+		id:            id,
+		fileID:        fileID,
+		ownerID:       ownerID,
+		passwordHash:  passwordHash,
+		maxDownloads:  maxDownloads,
+		downloadCount: downloadCount,
+		expiresAt:     expiresAt,
+		createdAt:     createdAt,
+		revokedAt:     revokedAt,
+	}, nil
+}
+
+func (l ShareLink) ID() string {
+	return l.id
+}
+
+func (l ShareLink) FileID() string {
+	return l.fileID
+}
+
+func (l ShareLink) OwnerID() string {
+	return l.ownerID
+}
+
+func (l ShareLink) PasswordHash() string {
+	return l.passwordHash
+}
+
+func (l ShareLink) RequiresPassword() bool {
+	return l.passwordHash != ""
+}
+
+func (l ShareLink) MaxDownloads() int64 {
+	return l.maxDownloads
+}
+
+func (l ShareLink) DownloadCount() int64 {
+	return l.downloadCount
+}
+
+func (l ShareLink) ExpiresAt() time.Time {
+	return l.expiresAt
+}
+
+func (l ShareLink) CreatedAt() time.Time {
+	return l.createdAt
+}
+
+func (l ShareLink) RevokedAt() *time.Time {
+	return l.revokedAt
+}
+
+func (l ShareLink) CheckUsableAt(now time.Time) error {
+	if l.revokedAt != nil {
+		return apperror.New(apperror.ErrNotFound, "share link revoked")
+	}
+	if !now.Before(l.expiresAt) {
+		return apperror.New(apperror.ErrNotFound, "share link expired")
+	}
+	if l.maxDownloads > 0 && l.downloadCount >= l.maxDownloads {
+		return apperror.New(apperror.ErrForbidden, "share link download limit reached")
+	}
+	return nil
+}
+
+func (l *ShareLink) Revoke() error {
+	if l.revokedAt != nil {
+		return apperror.New(apperror.ErrConflict, "share link already revoked")
+	}
+	revokedAt := time.Now()
+	l.revokedAt = &revokedAt
+	return nil
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewShareLink_Success(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	link, err := NewShareLink("link-1", "file-1", "user-1", expiresAt, 3, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.DownloadCount() != 0 || link.MaxDownloads() != 3 {
+		t.Errorf("download counters mismatch")
+	}
+	if link.RequiresPassword() {
+		t.Errorf("expected link without password")
+	}
+	if err := link.CheckUsableAt(time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestNewShareLink_Invalid(t *testing.T) {
+	if _, err := NewShareLink("link-1", "file-1", "user-1", time.Now().Add(-time.Minute), 0, ""); err == nil {
+		t.Errorf("expected error for past expiry")
+	}
+	if _, err := NewShareLink("", "file-1", "user-1", time.Now().Add(time.Hour), 0, ""); err == nil {
+		t.Errorf("expected error for empty id")
+	}
+	if _, err := NewShareLink("link-1", "", "user-1", time.Now().Add(time.Hour), 0, ""); err == nil {
+		t.Errorf("expected error for empty fileID")
+	}
+	if _, err := NewShareLink("link-1", "file-1", "user-1", time.Now().Add(time.Hour), -1, ""); err == nil {
+		t.Errorf("expected error for negative maxDownloads")
+	}
+}
+
+func TestShareLink_CheckUsableAt(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+
+	exhausted, _ := RehydrateShareLink("link-1", "file-1", "user-1", expiresAt, 2, 2, "", time.Now(), nil)
+	if err := exhausted.CheckUsableAt(time.Now()); err == nil {
+		t.Errorf("expected error for exhausted link")
+	}
+
+	unlimited, _ := RehydrateShareLink("link-1", "file-1", "user-1", expiresAt, 0, 50, "", time.Now(), nil)
+	if err := unlimited.CheckUsableAt(time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := unlimited.CheckUsableAt(expiresAt); err == nil {
+		t.Errorf("expected error for expired link")
+	}
+}
+
+func TestShareLink_Revoke(t *testing.T) {
+	link, _ := NewShareLink("link-1", "file-1", "user-1", time.Now().Add(time.Hour), 0, "")
+
+	if err := link.Revoke(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.RevokedAt() == nil {
+		t.Errorf("expected revokedAt to be set")
+	}
+	if err := link.CheckUsableAt(time.Now()); err == nil {
+		t.Errorf("expected error for revoked link")
+	}
+	if err := link.Revoke(); err == nil {
+		t.Errorf("expected error when revoking twice")
+	}
+}
@@ -5,8 +5,10 @@ type Status string
 const (
 	StatusPending   Status = "PENDING"
 	StatusAvailable Status = "AVAILABLE"
+	StatusFailed    Status = "FAILED"
+	StatusDeleted   Status = "DELETED"
 )
 
 func (s Status) IsValid() bool {
-	return s == StatusPending || s == StatusAvailable
+	return s == StatusPending || s == StatusAvailable || s == StatusFailed || s == StatusDeleted
 }
@@ -0,0 +1,55 @@
+package domain
+
+import "devconnectstorage/internal/apperror"
+
+// StorageUsage tracks how much content an owner keeps in storage. A limit of
+// zero or less means the owner has no quota.
+type StorageUsage struct {
+	ownerID   string
+	usedBytes int64
+	fileCount int64
+}
+
+func NewStorageUsage(ownerID string) (StorageUsage, error) {
+	return RehydrateStorageUsage(ownerID, 0, 0)
+}
+
+func RehydrateStorageUsage(ownerID string, usedBytes int64, fileCount int64) (StorageUsage, error) {
+	if ownerID == "" {
+		return StorageUsage{}, apperror.New(apperror.ErrValidation, "ownerID cannot be empty")
+	}
+	if usedBytes < 0 {
+		return StorageUsage{}, apperror.New(apperror.ErrValidation, "used bytes cannot be negative")
+	}
+	if fileCount < 0 {
+		return StorageUsage{}, apperror.New(apperror.ErrValidation, "file count cannot be negative")
+	}
+	return StorageUsage{
+		ownerID:   ownerID,
+		usedBytes: usedBytes,
+		fileCount: fileCount,
+	}, nil
+}
+
+func (u StorageUsage) OwnerID() string {
+	return u.ownerID
+}
+
+func (u StorageUsage) UsedBytes() int64 {
+	return u.usedBytes
+}
+
+func (u StorageUsage) FileCount() int64 {
+	return u.fileCount
+}
+
+func (u StorageUsage) Remaining(limit int64) int64 {
+	return max(limit-u.usedBytes, 0)
+}
+
+func (u StorageUsage) CheckCapacity(bytes int64, limit int64) error {
+	if limit <= 0 || u.usedBytes+bytes <= limit {
+		return nil
+	}
+	return apperror.New(apperror.ErrInsufficientStorage, "upload of %d bytes exceeds the storage quota: %d of %d bytes remaining", bytes, u.Remaining(limit), limit)
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+)
+
+func TestRehydrateStorageUsage_RejectsNegativeCounters(t *testing.T) {
+	if _, err := RehydrateStorageUsage("user-1", -1, 0); err == nil {
+		t.Errorf("expected error for negative used bytes")
+	}
+	if _, err := RehydrateStorageUsage("user-1", 0, -1); err == nil {
+		t.Errorf("expected error for negative file count")
+	}
+	if _, err := RehydrateStorageUsage("", 0, 0); err == nil {
+		t.Errorf("expected error for empty owner")
+	}
+}
+
+func TestStorageUsage_CheckCapacity(t *testing.T) {
+	usage, _ := RehydrateStorageUsage("user-1", 70, 3)
+
+	if err := usage.CheckCapacity(30, 100); err != nil {
+		t.Errorf("expected upload filling the quota to fit: %v", err)
+	}
+	if err := usage.CheckCapacity(1<<40, 0); err != nil {
+		t.Errorf("expected no limit without a quota: %v", err)
+	}
+
+	err := usage.CheckCapacity(31, 100)
+	if !errors.Is(err, apperror.ErrInsufficientStorage) {
+		t.Fatalf("expected insufficient storage, got %v", err)
+	}
+	if err.Error() != "upload of 31 bytes exceeds the storage quota: 30 of 100 bytes remaining" {
+		t.Errorf("unexpected message %q", err.Error())
+	}
+	if usage.Remaining(50) != 0 {
+		t.Errorf("expected remaining bytes to stop at zero")
+	}
+}
@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"devconnectstorage/internal/apperror"
+	"time"
+)
+
+var (
+	ErrUploadSessionNotFound = apperror.New(apperror.ErrNotFound, "upload session not found")
+	ErrUploadSessionExpired  = apperror.New(apperror.ErrNotFound, "upload session expired")
+	ErrUploadOffsetMismatch  = apperror.New(apperror.ErrConflict, "upload offset mismatch")
+)
+
+// MaxUploadParts is the most parts object storage accepts in one multipart
+// upload. Part numbers are never reused, so retried chunks count as well.
+const MaxUploadParts = 10000
+
+type UploadPart struct {
+	number int
+	etag   string
+	size   int64
+}
+
+func RehydrateUploadPart(number int, etag string, size int64) (UploadPart, error) {
+	if number < 1 || number > MaxUploadParts {
+		return UploadPart{}, apperror.New(apperror.ErrValidation, "part number must be between 1 and %d", MaxUploadParts)
+	}
+	if etag == "" {
+		return UploadPart{}, apperror.New(apperror.ErrValidation, "etag cannot be empty")
+	}
+	if size <= 0 {
+		return UploadPart{}, apperror.New(apperror.ErrValidation, "part size must be positive")
+	}
+	return UploadPart{number: number, etag: etag, size: size}, nil
+}
+
+func (p UploadPart) Number() int {
+	return p.number
+}
+
+func (p UploadPart) ETag() string {
+	return p.etag
+}
+
+func (p UploadPart) Size() int64 {
+	return p.size
+}
+
+// Bytes too few to form a storage part are kept as a tail and prepended to
+// the next chunk. Parts and tails are stored under numbers reserved for each
+// write, so a request that loses the race to advance the offset never
+// overwrites what the winner stored.
+type UploadSession struct {
+	id          string
+	ownerID     string
+	multipartID string
+	length      int64
+	parts       []UploadPart
+	tailNumber  int
+	tailSize    int64
+	createdAt   time.Time
+	expiresAt   time.Time
+}
+
+func NewUploadSession(id string, ownerID string, multipartID string, length int64, expiresAt time.Time) (UploadSession, error) {
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "expiresAt must be in the future")
+	}
+	return RehydrateUploadSession(id, ownerID, multipartID, length, nil, 0, 0, now, expiresAt)
+}
+
+func RehydrateUploadSession(id string, ownerID string, multipartID string, length int64, parts []UploadPart, tailNumber int, tailSize int64, createdAt time.Time, expiresAt time.Time) (UploadSession, error) {
+	if id == "" {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "id cannot be empty")
+	}
+	if ownerID == "" {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "ownerID cannot be empty")
+	}
+	if multipartID == "" {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "multipartID cannot be empty")
+	}
+	if length <= 0 {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "length must be positive")
+	}
+	if tailSize < 0 {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "tailSize cannot be negative")
+	}
+	if tailSize > 0 && tailNumber < 1 {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "tailNumber must be positive")
+	}
+	if createdAt.IsZero() {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "createdAt cannot be zero")
+	}
+	if expiresAt.IsZero() {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "expiresAt cannot be zero")
+	}
+	session := UploadSession{
+		id:          id,
+		ownerID:     ownerID,
+		multipartID: multipartID,
+		length:      length,
+		parts:       append([]UploadPart(nil), parts...),
+		tailNumber:  tailNumber,
+		tailSize:    tailSize,
+		createdAt:   createdAt,
+		expiresAt:   expiresAt,
+	}
+	if session.Offset() > length {
+		return UploadSession{}, apperror.New(apperror.ErrValidation, "offset cannot exceed length")
+	}
+	return session, nil
+}
+
+func (s UploadSession) ID() string {
+	return s.id
+}
+
+func (s UploadSession) OwnerID() string {
+	return s.ownerID
+}
+
+func (s UploadSession) MultipartID() string {
+	return s.multipartID
+}
+
+func (s UploadSession) Length() int64 {
+	return s.length
+}
+
+func (s UploadSession) Parts() []UploadPart {
+	return append([]UploadPart(nil), s.parts...)
+}
+
+func (s UploadSession) TailNumber() int {
+	return s.tailNumber
+}
+
+func (s UploadSession) TailSize() int64 {
+	return s.tailSize
+}
+
+func (s UploadSession) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+func (s UploadSession) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+func (s UploadSession) PartsSize() int64 {
+	var size int64
+	for _, part := range s.parts {
+		size += part.size
+	}
+	return size
+}
+
+func (s UploadSession) Offset() int64 {
+	return s.PartsSize() + s.tailSize
+}
+
+func (s UploadSession) IsComplete() bool {
+	return s.tailSize == 0 && s.PartsSize() == s.length
+}
+
+func (s UploadSession) lastPartNumber() int {
+	if len(s.parts) == 0 {
+		return 0
+	}
+	return s.parts[len(s.parts)-1].number
+}
+
+func (s UploadSession) CheckAppendAt(offset int64, now time.Time) error {
+	if !now.Before(s.expiresAt) {
+		return ErrUploadSessionExpired
+	}
+	if offset != s.Offset() {
+		return ErrUploadOffsetMismatch
+	}
+	return nil
+}
+
+// AddPart records a part stored under a reserved number, which must follow
+// the numbers of the parts already recorded.
+func (s *UploadSession) AddPart(number int, etag string, size int64) error {
+	if s.PartsSize()+size > s.length {
+		return apperror.New(apperror.ErrPayloadTooLarge, "part exceeds upload length")
+	}
+	if number <= s.lastPartNumber() {
+		return apperror.New(apperror.ErrConflict, "part %d is out of order", number)
+	}
+	part, err := RehydrateUploadPart(number, etag, size)
+	if err != nil {
+		return err
+	}
+	s.parts = append(s.parts, part)
+	s.tailNumber = 0
+	s.tailSize = 0
+	return nil
+}
+
+func (s *UploadSession) SetTail(number int, size int64) error {
+	if number < 1 {
+		return apperror.New(apperror.ErrValidation, "tailNumber must be positive")
+	}
+	if size < 0 {
+		return apperror.New(apperror.ErrValidation, "tailSize cannot be negative")
+	}
+	if s.PartsSize()+size > s.length {
+		return apperror.New(apperror.ErrPayloadTooLarge, "tail exceeds upload length")
+	}
+	s.tailNumber = number
+	s.tailSize = size
+	return nil
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewUploadSession_Success(t *testing.T) {
+	session, err := NewUploadSession("file-1", "user-1", "multipart-1", 10, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Offset() != 0 || session.Length() != 10 {
+		t.Errorf("offset/length mismatch")
+	}
+	if session.IsComplete() {
+		t.Errorf("new session should not be complete")
+	}
+}
+
+func TestNewUploadSession_Invalid(t *testing.T) {
+	if _, err := NewUploadSession("file-1", "user-1", "multipart-1", 10, time.Now().Add(-time.Minute)); err == nil {
+		t.Errorf("expected error for past expiry")
+	}
+	if _, err := NewUploadSession("", "user-1", "multipart-1", 10, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected error for empty id")
+	}
+	if _, err := NewUploadSession("file-1", "user-1", "", 10, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected error for empty multipartID")
+	}
+	if _, err := NewUploadSession("file-1", "user-1", "multipart-1", 0, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected error for non-positive length")
+	}
+}
+
+func TestUploadSession_PartsAndTailAdvanceOffset(t *testing.T) {
+	session, _ := NewUploadSession("file-1", "user-1", "multipart-1", 10, time.Now().Add(time.Hour))
+
+	if err := session.SetTail(1, 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Offset() != 3 {
+		t.Errorf("expected offset 3, got %d", session.Offset())
+	}
+
+	if err := session.AddPart(2, "etag-1", 6); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Offset() != 6 || session.TailSize() != 0 || session.TailNumber() != 0 {
+		t.Errorf("part should absorb the tail, got offset %d tail %d", session.Offset(), session.TailSize())
+	}
+
+	if err := session.AddPart(2, "etag-2", 4); err == nil {
+		t.Errorf("expected error for a reused part number")
+	}
+	if err := session.AddPart(5, "etag-2", 4); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !session.IsComplete() {
+		t.Errorf("expected session to be complete")
+	}
+	if session.Parts()[1].Number() != 5 {
+		t.Errorf("expected parts to keep their reserved numbers")
+	}
+}
+
+func TestUploadSession_RejectsBytesBeyondLength(t *testing.T) {
+	session, _ := NewUploadSession("file-1", "user-1", "multipart-1", 10, time.Now().Add(time.Hour))
+
+	if err := session.AddPart(1, "etag-1", 11); err == nil {
+		t.Errorf("expected error for oversized part")
+	}
+	if err := session.SetTail(1, 11); err == nil {
+		t.Errorf("expected error for oversized tail")
+	}
+}
+
+func TestUploadSession_CheckAppendAt(t *testing.T) {
+	session, _ := NewUploadSession("file-1", "user-1", "multipart-1", 10, time.Now().Add(time.Hour))
+	_ = session.SetTail(1, 4)
+
+	if err := session.CheckAppendAt(4, time.Now()); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := session.CheckAppendAt(0, time.Now()); !errors.Is(err, ErrUploadOffsetMismatch) {
+		t.Errorf("expected offset mismatch, got %v", err)
+	}
+	if err := session.CheckAppendAt(4, time.Now().Add(2*time.Hour)); !errors.Is(err, ErrUploadSessionExpired) {
+		t.Errorf("expected expired session, got %v", err)
+	}
+}
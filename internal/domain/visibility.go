@@ -5,8 +5,9 @@ type Visibility string
 const (
 	VisibilityPublic  Visibility = "PUBLIC"
 	VisibilityPrivate Visibility = "PRIVATE"
+	VisibilityProject Visibility = "PROJECT"
 )
 
 func (v Visibility) IsValid() bool {
-	return v == VisibilityPublic || v == VisibilityPrivate
+	return v == VisibilityPublic || v == VisibilityPrivate || v == VisibilityProject
 }
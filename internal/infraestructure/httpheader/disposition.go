@@ -0,0 +1,12 @@
+package httpheader
+
+import "mime"
+
+// AttachmentDisposition quotes or RFC 2231-encodes fileName as needed, so
+// quotes, separators and non-ASCII characters cannot break the header.
+func AttachmentDisposition(fileName string) string {
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": fileName}); disposition != "" {
+		return disposition
+	}
+	return "attachment"
+}
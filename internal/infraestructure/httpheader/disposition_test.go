@@ -0,0 +1,14 @@
+package httpheader
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentDisposition_ShouldEscapeFileNames(t *testing.T) {
+	assert.Equal(t, `attachment; filename=test.txt`, AttachmentDisposition("test.txt"))
+	assert.Equal(t, `attachment; filename="a \"b\".txt"`, AttachmentDisposition(`a "b".txt`))
+	assert.Equal(t, `attachment; filename="x; y=z.txt"`, AttachmentDisposition("x; y=z.txt"))
+	assert.Equal(t, `attachment; filename*=utf-8''r%C3%A9sum%C3%A9.pdf`, AttachmentDisposition("résumé.pdf"))
+}
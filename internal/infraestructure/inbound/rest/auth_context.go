@@ -0,0 +1,21 @@
+package rest
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/infraestructure/outbound/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+func authenticatedContext(ctx *gin.Context) (context.Context, error) {
+	jwt, err := ctx.Cookie("jwt")
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrUnauthenticated, err, "authentication required")
+	}
+	return context.WithValue(
+		ctx.Request.Context(),
+		auth.AuthTokenKey,
+		jwt,
+	), nil
+}
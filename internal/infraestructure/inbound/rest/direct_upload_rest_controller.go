@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	completeupload "devconnectstorage/internal/application/usecase/complete_upload"
+	initiateupload "devconnectstorage/internal/application/usecase/initiate_upload"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DirectUploadRestController struct {
+	initiateUpload initiateupload.IInitiateUploadUseCase
+	completeUpload completeupload.ICompleteUploadUseCase
+}
+
+func NewDirectUploadRestController(initiateUploadUseCase initiateupload.IInitiateUploadUseCase, completeUploadUseCase completeupload.ICompleteUploadUseCase) *DirectUploadRestController {
+	return &DirectUploadRestController{
+		initiateUpload: initiateUploadUseCase,
+		completeUpload: completeUploadUseCase,
+	}
+}
+
+func (controller *DirectUploadRestController) InitiateUpload(ctx *gin.Context) {
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.InitiateUploadRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	result, err := controller.initiateUpload.Execute(ctxWithToken, body.ToCommand())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(201, dto.NewPresignedUploadResponse(result))
+}
+
+func (controller *DirectUploadRestController) CompleteUpload(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	result, err := controller.completeUpload.Execute(ctxWithToken, completeupload.CompleteUploadCommand{Id: id})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponse(result))
+}
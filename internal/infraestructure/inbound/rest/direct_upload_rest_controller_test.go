@@ -0,0 +1,148 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"devconnectstorage/internal/apperror"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/application/aggregate"
+	completeupload "devconnectstorage/internal/application/usecase/complete_upload"
+	initiateupload "devconnectstorage/internal/application/usecase/initiate_upload"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type InitiateUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *InitiateUploadUseCaseMock) Execute(ctx context.Context, command initiateupload.InitiateUploadCommand) (aggregate.PresignedUpload, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(aggregate.PresignedUpload), args.Error(1)
+}
+
+type CompleteUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *CompleteUploadUseCaseMock) Execute(ctx context.Context, command completeupload.CompleteUploadCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func TestInitiateUpload_ShouldReturn201WithUploadURL(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(InitiateUploadUseCaseMock)
+	controller := &DirectUploadRestController{initiateUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/uploads", controller.InitiateUpload)
+
+	file, _ := domain.NewFile("123", "1", nil, "video.mp4", "video/mp4", 1024, domain.VisibilityPrivate)
+	useCaseMock.On("Execute", mock.Anything, initiateupload.InitiateUploadCommand{FileName: "video.mp4", MimeType: "video/mp4", Size: 1024, Visibility: "PRIVATE"}).
+		Return(aggregate.PresignedUpload{
+			File:      file,
+			URL:       "http://minio/bucket/1/123/video.mp4?X-Amz-Signature=abc",
+			Headers:   map[string]string{"Content-Type": "video/mp4"},
+			ExpiresAt: time.Now().Add(time.Minute),
+		}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/uploads", bytes.NewBufferString(`{"file_name":"video.mp4","mime_type":"video/mp4","size":1024,"visibility":"PRIVATE"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"upload_url":"http://minio/bucket/1/123/video.mp4?X-Amz-Signature=abc"`)
+	assert.Contains(t, resp.Body.String(), `"method":"PUT"`)
+	assert.Contains(t, resp.Body.String(), `"status":"PENDING"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestInitiateUpload_ShouldReturn400WithoutSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(InitiateUploadUseCaseMock)
+	controller := &DirectUploadRestController{initiateUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/uploads", controller.InitiateUpload)
+
+	req := httptest.NewRequest(http.MethodPost, "/files/uploads", bytes.NewBufferString(`{"file_name":"video.mp4","mime_type":"video/mp4","visibility":"PRIVATE"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestInitiateUpload_ShouldReturn401WithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(InitiateUploadUseCaseMock)
+	controller := &DirectUploadRestController{initiateUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/uploads", controller.InitiateUpload)
+
+	req := httptest.NewRequest(http.MethodPost, "/files/uploads", bytes.NewBufferString(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestCompleteUpload_ShouldReturn200(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CompleteUploadUseCaseMock)
+	controller := &DirectUploadRestController{completeUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/complete", controller.CompleteUpload)
+
+	file, _ := domain.RehydrateFile("123", "1", nil, "video.mp4", "video/mp4", 1024, "1/123/video.mp4", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, completeupload.CompleteUploadCommand{Id: "123"}).Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/complete", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"AVAILABLE"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestCompleteUpload_ShouldReturn400OnSizeMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CompleteUploadUseCaseMock)
+	controller := &DirectUploadRestController{completeUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/complete", controller.CompleteUpload)
+
+	useCaseMock.On("Execute", mock.Anything, completeupload.CompleteUploadCommand{Id: "123"}).
+		Return(domain.File{}, apperror.New(apperror.ErrValidation, "uploaded size 10 does not match declared size 1024")).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/complete", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	assert.Contains(t, resp.Body.String(), "does not match declared size")
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type CleanupJobResponse struct {
+	ID           string     `json:"id"`
+	Scope        string     `json:"scope"`
+	SubjectID    string     `json:"subject_id"`
+	Status       string     `json:"status"`
+	TrashedCount int64      `json:"trashed_count"`
+	LastError    string     `json:"last_error,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+}
+
+func NewCleanupJobResponse(job domain.CleanupJob) CleanupJobResponse {
+	return CleanupJobResponse{
+		ID:           job.ID(),
+		Scope:        string(job.Scope()),
+		SubjectID:    job.SubjectID(),
+		Status:       string(job.Status()),
+		TrashedCount: job.TrashedCount(),
+		LastError:    job.LastError(),
+		CreatedAt:    job.CreatedAt(),
+		UpdatedAt:    job.UpdatedAt(),
+		CompletedAt:  job.CompletedAt(),
+	}
+}
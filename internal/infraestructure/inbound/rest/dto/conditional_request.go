@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"devconnectstorage/internal/application/aggregate"
+	"net/http"
+	"strings"
+)
+
+// ParsePreconditions reads If-None-Match and If-Modified-Since. Entity tags
+// are compared weakly, so W/ prefixes and quotes are dropped.
+func ParsePreconditions(ifNoneMatch string, ifModifiedSince string) aggregate.Preconditions {
+	var preconditions aggregate.Preconditions
+	for _, tag := range strings.Split(ifNoneMatch, ",") {
+		tag = strings.TrimSpace(tag)
+		tag = strings.TrimPrefix(tag, "W/")
+		tag = strings.Trim(tag, "\"")
+		if tag != "" {
+			preconditions.IfNoneMatch = append(preconditions.IfNoneMatch, tag)
+		}
+	}
+	if date, err := http.ParseTime(strings.TrimSpace(ifModifiedSince)); err == nil {
+		preconditions.IfModifiedSince = date.UTC()
+	}
+	return preconditions
+}
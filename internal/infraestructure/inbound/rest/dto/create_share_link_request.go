@@ -0,0 +1,21 @@
+package dto
+
+import (
+	createsharelink "devconnectstorage/internal/application/usecase/create_share_link"
+	"time"
+)
+
+type CreateShareLinkRequest struct {
+	ExpiresInSeconds int64  `json:"expires_in_seconds" binding:"required,min=1"`
+	MaxDownloads     int64  `json:"max_downloads" binding:"min=0"`
+	Password         string `json:"password"`
+}
+
+func (req CreateShareLinkRequest) ToCommand(fileId string) createsharelink.CreateShareLinkCommand {
+	return createsharelink.CreateShareLinkCommand{
+		FileId:       fileId,
+		ExpiresIn:    time.Duration(req.ExpiresInSeconds) * time.Second,
+		MaxDownloads: req.MaxDownloads,
+		Password:     req.Password,
+	}
+}
@@ -0,0 +1,24 @@
+package dto
+
+import (
+	receivedeletionevent "devconnectstorage/internal/application/usecase/receive_deletion_event"
+	"encoding/json"
+)
+
+// DeletionEventRequest is a ProjectDeleted or ProfileDeleted event from the
+// main service. Profile IDs may arrive as JSON numbers or strings.
+type DeletionEventRequest struct {
+	ID        string      `json:"id" binding:"required"`
+	Type      string      `json:"type" binding:"required"`
+	ProjectID string      `json:"project_id"`
+	ProfileID json.Number `json:"profile_id"`
+}
+
+func (req DeletionEventRequest) ToCommand() receivedeletionevent.ReceiveDeletionEventCommand {
+	return receivedeletionevent.ReceiveDeletionEventCommand{
+		EventID:   req.ID,
+		Type:      req.Type,
+		ProjectID: req.ProjectID,
+		ProfileID: req.ProfileID.String(),
+	}
+}
@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"crypto/sha256"
+	"devconnectstorage/internal/apperror"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"strings"
+)
+
+// ExpectedChecksum reads the client-declared SHA-256 of an upload from either
+// Content-SHA256 (hex) or an RFC 3230 Digest header (sha-256=<base64>).
+func ExpectedChecksum(header http.Header) (string, error) {
+	var checksums []string
+
+	if value := strings.TrimSpace(header.Get("Content-SHA256")); value != "" {
+		decoded, err := hex.DecodeString(value)
+		if err != nil || len(decoded) != sha256.Size {
+			return "", apperror.New(apperror.ErrValidation, "invalid Content-SHA256 header")
+		}
+		checksums = append(checksums, hex.EncodeToString(decoded))
+	}
+
+	for _, entry := range strings.Split(header.Get("Digest"), ",") {
+		algorithm, value, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || !strings.EqualFold(algorithm, "sha-256") {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(decoded) != sha256.Size {
+			return "", apperror.New(apperror.ErrValidation, "invalid Digest header")
+		}
+		checksums = append(checksums, hex.EncodeToString(decoded))
+	}
+
+	if len(checksums) == 0 {
+		return "", nil
+	}
+	for _, checksum := range checksums[1:] {
+		if checksum != checksums[0] {
+			return "", apperror.New(apperror.ErrValidation, "Content-SHA256 and Digest headers disagree")
+		}
+	}
+	return checksums[0], nil
+}
@@ -6,27 +6,57 @@ import (
 )
 
 type FileMetadataResponse struct {
-	Id         string    `json:"id"`
-	OwnerID    string    `json:"owner_id"`
-	ProjectID  *string   `json:"project_id"`
-	FileName   string    `json:"file_name"`
-	MimeType   string    `json:"mime_type"`
-	Size       int64     `json:"size"`
-	Visibility string    `json:"visibility"`
-	Status     string    `json:"status"`
-	CreatedAt  time.Time `json:"created_at"`
+	Id               string            `json:"id"`
+	OwnerID          string            `json:"owner_id"`
+	ProjectID        *string           `json:"project_id"`
+	FileName         string            `json:"file_name"`
+	MimeType         string            `json:"mime_type"`
+	DetectedMimeType string            `json:"detected_mime_type,omitempty"`
+	Size             int64             `json:"size"`
+	Checksum         string            `json:"checksum,omitempty"`
+	Visibility       string            `json:"visibility"`
+	Status           string            `json:"status"`
+	CreatedAt        time.Time         `json:"created_at"`
+	DeletedAt        *time.Time        `json:"deleted_at,omitempty"`
+	Version          int               `json:"version"`
+	Tags             []string          `json:"tags"`
+	Attributes       map[string]string `json:"attributes"`
+	Path             string            `json:"path,omitempty"`
 }
 
 func NewFileMetadataResponse(file domain.File) FileMetadataResponse {
+	tags := file.Tags()
+	if tags == nil {
+		tags = []string{}
+	}
+	attributes := file.Attributes()
+	if attributes == nil {
+		attributes = map[string]string{}
+	}
 	return FileMetadataResponse{
-		Id:         file.ID(),
-		OwnerID:    file.OwnerID(),
-		ProjectID:  file.ProjectID(),
-		FileName:   file.FileName(),
-		MimeType:   file.MimeType(),
-		Size:       file.Size(),
-		Visibility: string(file.Visibility()),
-		Status:     string(file.Status()),
-		CreatedAt:  file.CreatedAt(),
+		Id:               file.ID(),
+		OwnerID:          file.OwnerID(),
+		ProjectID:        file.ProjectID(),
+		FileName:         file.FileName(),
+		MimeType:         file.MimeType(),
+		DetectedMimeType: file.DetectedMimeType(),
+		Size:             file.Size(),
+		Checksum:         file.Checksum(),
+		Visibility:       string(file.Visibility()),
+		Status:           string(file.Status()),
+		CreatedAt:        file.CreatedAt(),
+		DeletedAt:        file.DeletedAt(),
+		Version:          file.Version(),
+		Tags:             tags,
+		Attributes:       attributes,
+		Path:             file.Path(),
+	}
+}
+
+func NewFileMetadataResponses(files []domain.File) []FileMetadataResponse {
+	responses := make([]FileMetadataResponse, 0, len(files))
+	for _, file := range files {
+		responses = append(responses, NewFileMetadataResponse(file))
 	}
+	return responses
 }
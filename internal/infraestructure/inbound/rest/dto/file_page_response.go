@@ -0,0 +1,15 @@
+package dto
+
+import "devconnectstorage/internal/application/aggregate"
+
+type FilePageResponse struct {
+	Items      []FileMetadataResponse `json:"items"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+func NewFilePageResponse(page aggregate.FilePage) FilePageResponse {
+	return FilePageResponse{
+		Items:      NewFileMetadataResponses(page.Files),
+		NextCursor: page.NextCursor,
+	}
+}
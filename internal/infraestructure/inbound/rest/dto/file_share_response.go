@@ -0,0 +1,32 @@
+package dto
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type FileShareResponse struct {
+	ProfileID  string    `json:"profile_id"`
+	Permission string    `json:"permission"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type FileSharesResponse struct {
+	FileId string              `json:"file_id"`
+	Shares []FileShareResponse `json:"shares"`
+}
+
+func NewFileSharesResponse(file domain.File) FileSharesResponse {
+	shares := make([]FileShareResponse, 0, len(file.Shares()))
+	for _, share := range file.Shares() {
+		shares = append(shares, FileShareResponse{
+			ProfileID:  share.ProfileID(),
+			Permission: string(share.Permission()),
+			CreatedAt:  share.CreatedAt(),
+		})
+	}
+	return FileSharesResponse{
+		FileId: file.ID(),
+		Shares: shares,
+	}
+}
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type FileVersionResponse struct {
+	Version   int       `json:"version"`
+	MimeType  string    `json:"mime_type"`
+	Size      int64     `json:"size"`
+	Checksum  string    `json:"checksum,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type FileVersionsResponse struct {
+	FileId         string                `json:"file_id"`
+	CurrentVersion int                   `json:"current_version"`
+	Versions       []FileVersionResponse `json:"versions"`
+}
+
+func NewFileVersionsResponse(file domain.File) FileVersionsResponse {
+	versions := make([]FileVersionResponse, 0, len(file.Versions()))
+	for _, version := range file.Versions() {
+		versions = append(versions, FileVersionResponse{
+			Version:   version.Number(),
+			MimeType:  version.MimeType(),
+			Size:      version.Size(),
+			Checksum:  version.Checksum(),
+			CreatedAt: version.CreatedAt(),
+		})
+	}
+	return FileVersionsResponse{
+		FileId:         file.ID(),
+		CurrentVersion: file.Version(),
+		Versions:       versions,
+	}
+}
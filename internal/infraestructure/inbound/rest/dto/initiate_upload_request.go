@@ -0,0 +1,23 @@
+package dto
+
+import (
+	initiateupload "devconnectstorage/internal/application/usecase/initiate_upload"
+)
+
+type InitiateUploadRequest struct {
+	ProjectID  *string `json:"project_id"`
+	FileName   string  `json:"file_name" binding:"required"`
+	MimeType   string  `json:"mime_type" binding:"required"`
+	Size       int64   `json:"size" binding:"required,min=1"`
+	Visibility string  `json:"visibility" binding:"required"`
+}
+
+func (req InitiateUploadRequest) ToCommand() initiateupload.InitiateUploadCommand {
+	return initiateupload.InitiateUploadCommand{
+		ProjectID:  req.ProjectID,
+		FileName:   req.FileName,
+		MimeType:   req.MimeType,
+		Size:       req.Size,
+		Visibility: req.Visibility,
+	}
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	listfiles "devconnectstorage/internal/application/usecase/list_files"
+	"time"
+)
+
+// ListFilesRequest takes repeated tag parameters. Attribute filters use
+// attr[key]=value parameters, which the controller reads separately.
+type ListFilesRequest struct {
+	OwnerID     string    `form:"owner_id"`
+	ProjectID   *string   `form:"project_id"`
+	Visibility  string    `form:"visibility"`
+	Status      string    `form:"status"`
+	MimePrefix  string    `form:"mime_prefix"`
+	Tags        []string  `form:"tag"`
+	CreatedFrom time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   time.Time `form:"created_to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Sort        string    `form:"sort"`
+	Order       string    `form:"order"`
+	Cursor      string    `form:"cursor"`
+	Limit       int64     `form:"limit"`
+}
+
+func (req ListFilesRequest) ToQuery(attributes map[string]string) listfiles.ListFilesQuery {
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	return listfiles.ListFilesQuery{
+		OwnerID:     req.OwnerID,
+		ProjectID:   req.ProjectID,
+		Visibility:  req.Visibility,
+		Status:      req.Status,
+		MimePrefix:  req.MimePrefix,
+		Tags:        req.Tags,
+		Attributes:  attributes,
+		CreatedFrom: req.CreatedFrom,
+		CreatedTo:   req.CreatedTo,
+		Sort:        req.Sort,
+		Order:       req.Order,
+		Cursor:      req.Cursor,
+		Limit:       req.Limit,
+	}
+}
@@ -0,0 +1,20 @@
+package dto
+
+import movefolder "devconnectstorage/internal/application/usecase/move_folder"
+
+type MoveFolderRequest struct {
+	From string `json:"from" binding:"required"`
+	To   string `json:"to" binding:"required"`
+}
+
+func (req MoveFolderRequest) ToCommand(projectID string) movefolder.MoveFolderCommand {
+	return movefolder.MoveFolderCommand{
+		ProjectID: projectID,
+		From:      req.From,
+		To:        req.To,
+	}
+}
+
+type MoveFolderResponse struct {
+	Moved int64 `json:"moved"`
+}
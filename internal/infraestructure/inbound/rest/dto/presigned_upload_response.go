@@ -0,0 +1,25 @@
+package dto
+
+import (
+	"devconnectstorage/internal/application/aggregate"
+	"net/http"
+	"time"
+)
+
+type PresignedUploadResponse struct {
+	File      FileMetadataResponse `json:"file"`
+	UploadURL string               `json:"upload_url"`
+	Method    string               `json:"method"`
+	Headers   map[string]string    `json:"headers"`
+	ExpiresAt time.Time            `json:"expires_at"`
+}
+
+func NewPresignedUploadResponse(upload aggregate.PresignedUpload) PresignedUploadResponse {
+	return PresignedUploadResponse{
+		File:      NewFileMetadataResponse(upload.File),
+		UploadURL: upload.URL,
+		Method:    http.MethodPut,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
@@ -0,0 +1,22 @@
+package dto
+
+import "net/http"
+
+// ProblemResponse is an RFC 7807 problem details body.
+type ProblemResponse struct {
+	Type     string `json:"type"`
+	Title    string `json:"title"`
+	Status   int    `json:"status"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+func NewProblemResponse(status int, detail string, instance string) ProblemResponse {
+	return ProblemResponse{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+	}
+}
@@ -0,0 +1,18 @@
+package dto
+
+import getprojecttree "devconnectstorage/internal/application/usecase/get_project_tree"
+
+type ProjectTreeRequest struct {
+	Prefix string `form:"prefix"`
+	Cursor string `form:"cursor"`
+	Limit  int64  `form:"limit"`
+}
+
+func (req ProjectTreeRequest) ToQuery(projectID string) getprojecttree.GetProjectTreeQuery {
+	return getprojecttree.GetProjectTreeQuery{
+		ProjectID: projectID,
+		Prefix:    req.Prefix,
+		Cursor:    req.Cursor,
+		Limit:     req.Limit,
+	}
+}
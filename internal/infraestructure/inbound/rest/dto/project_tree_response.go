@@ -0,0 +1,25 @@
+package dto
+
+import "devconnectstorage/internal/application/aggregate"
+
+// ProjectTreeResponse lists folder names relative to Prefix and the files
+// placed directly in it. NextCursor is set while more entries follow.
+type ProjectTreeResponse struct {
+	Prefix     string                 `json:"prefix"`
+	Folders    []string               `json:"folders"`
+	Files      []FileMetadataResponse `json:"files"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+}
+
+func NewProjectTreeResponse(folder aggregate.Folder) ProjectTreeResponse {
+	folders := folder.Folders
+	if folders == nil {
+		folders = []string{}
+	}
+	return ProjectTreeResponse{
+		Prefix:     folder.Prefix,
+		Folders:    folders,
+		Files:      NewFileMetadataResponses(folder.Files),
+		NextCursor: folder.NextCursor,
+	}
+}
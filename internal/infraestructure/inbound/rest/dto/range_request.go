@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"devconnectstorage/internal/application/aggregate"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const maxRanges = 8
+
+// ParseRange reads a "bytes=" Range header. Malformed or unsupported headers
+// yield no ranges so the full content is served, as RFC 9110 allows.
+func ParseRange(header string) []aggregate.RangeSpec {
+	value, found := strings.CutPrefix(strings.TrimSpace(header), "bytes=")
+	if !found {
+		return nil
+	}
+	parts := strings.Split(value, ",")
+	if len(parts) > maxRanges {
+		return nil
+	}
+	specs := make([]aggregate.RangeSpec, 0, len(parts))
+	for _, part := range parts {
+		spec, ok := parseRangeSpec(strings.TrimSpace(part))
+		if !ok {
+			return nil
+		}
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func parseRangeSpec(value string) (aggregate.RangeSpec, bool) {
+	first, last, found := strings.Cut(value, "-")
+	if !found {
+		return aggregate.RangeSpec{}, false
+	}
+	if first == "" {
+		suffix, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || suffix < 0 {
+			return aggregate.RangeSpec{}, false
+		}
+		return aggregate.RangeSpec{First: -1, Last: suffix}, true
+	}
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return aggregate.RangeSpec{}, false
+	}
+	if last == "" {
+		return aggregate.RangeSpec{First: start, Last: -1}, true
+	}
+	end, err := strconv.ParseInt(last, 10, 64)
+	if err != nil || end < start {
+		return aggregate.RangeSpec{}, false
+	}
+	return aggregate.RangeSpec{First: start, Last: end}, true
+}
+
+// ParseIfRange reads an If-Range header. Weak entity tags can never satisfy
+// If-Range, so they become a condition that matches nothing.
+func ParseIfRange(header string) aggregate.RangeCondition {
+	value := strings.TrimSpace(header)
+	if value == "" {
+		return aggregate.RangeCondition{}
+	}
+	if strings.HasPrefix(value, "W/") {
+		return aggregate.RangeCondition{ETag: value}
+	}
+	if strings.HasPrefix(value, "\"") {
+		return aggregate.RangeCondition{ETag: strings.Trim(value, "\"")}
+	}
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return aggregate.RangeCondition{ETag: value}
+	}
+	return aggregate.RangeCondition{LastModified: date.UTC().Truncate(time.Second)}
+}
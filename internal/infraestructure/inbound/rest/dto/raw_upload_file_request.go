@@ -0,0 +1,109 @@
+package dto
+
+import (
+	"devconnectstorage/internal/apperror"
+	uploadfile "devconnectstorage/internal/application/usecase/upload_file"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RawUploadFileRequest reads metadata from query parameters named like the
+// multipart form fields, falling back to X-File-* headers. Tags are repeated
+// tags parameters or a comma separated X-File-Tags header; attributes are
+// attributes[key]=value parameters or key=value pairs in X-File-Attributes.
+type RawUploadFileRequest struct {
+	ProjectID  *string
+	FileName   string
+	MimeType   string
+	Visibility string
+	Tags       []string
+	Attributes map[string]string
+	Path       string
+}
+
+func NewRawUploadFileRequest(query url.Values, header http.Header, contentType string) (RawUploadFileRequest, error) {
+	req := RawUploadFileRequest{
+		FileName:   firstNonEmpty(query.Get("file_name"), header.Get("X-File-Name")),
+		MimeType:   firstNonEmpty(query.Get("mime_type"), contentType),
+		Visibility: firstNonEmpty(query.Get("visibility"), header.Get("X-File-Visibility")),
+		Tags:       query["tags"],
+		Path:       firstNonEmpty(query.Get("path"), header.Get("X-File-Path")),
+	}
+	if len(req.Tags) == 0 {
+		req.Tags = headerList(header.Values("X-File-Tags"))
+	}
+	attributes, err := rawUploadAttributes(query, header)
+	if err != nil {
+		return RawUploadFileRequest{}, err
+	}
+	req.Attributes = attributes
+	if projectID := firstNonEmpty(query.Get("project_id"), header.Get("X-Project-Id")); projectID != "" {
+		req.ProjectID = &projectID
+	}
+	if req.FileName == "" || req.Visibility == "" {
+		return RawUploadFileRequest{}, apperror.New(apperror.ErrValidation, "file_name and visibility are required")
+	}
+	return req, nil
+}
+
+func (req RawUploadFileRequest) ToCommand(content io.Reader, size int64) uploadfile.UploadFileCommand {
+	return uploadfile.UploadFileCommand{
+		ProjectID:  req.ProjectID,
+		FileName:   req.FileName,
+		MimeType:   req.MimeType,
+		Size:       size,
+		Visibility: req.Visibility,
+		Tags:       req.Tags,
+		Attributes: req.Attributes,
+		Path:       req.Path,
+		Content:    content,
+	}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func rawUploadAttributes(query url.Values, header http.Header) (map[string]string, error) {
+	attributes := map[string]string{}
+	for key, values := range query {
+		name, ok := strings.CutPrefix(key, "attributes[")
+		if !ok || !strings.HasSuffix(name, "]") || len(values) == 0 {
+			continue
+		}
+		attributes[strings.TrimSuffix(name, "]")] = values[0]
+	}
+	if len(attributes) > 0 {
+		return attributes, nil
+	}
+	for _, pair := range headerList(header.Values("X-File-Attributes")) {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, apperror.New(apperror.ErrValidation, "X-File-Attributes must hold key=value pairs")
+		}
+		attributes[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+	if len(attributes) == 0 {
+		return nil, nil
+	}
+	return attributes, nil
+}
+
+func headerList(values []string) []string {
+	var items []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+	}
+	return items
+}
@@ -0,0 +1,17 @@
+package dto
+
+import searchfiles "devconnectstorage/internal/application/usecase/search_files"
+
+type SearchFilesRequest struct {
+	Text      string  `form:"q" binding:"required"`
+	ProjectID *string `form:"project_id"`
+	Limit     int64   `form:"limit"`
+}
+
+func (req SearchFilesRequest) ToQuery() searchfiles.SearchFilesQuery {
+	return searchfiles.SearchFilesQuery{
+		Text:      req.Text,
+		ProjectID: req.ProjectID,
+		Limit:     req.Limit,
+	}
+}
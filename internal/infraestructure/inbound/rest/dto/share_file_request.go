@@ -0,0 +1,16 @@
+package dto
+
+import sharefile "devconnectstorage/internal/application/usecase/share_file"
+
+type ShareFileRequest struct {
+	ProfileID  string `json:"profile_id" binding:"required"`
+	Permission string `json:"permission" binding:"required"`
+}
+
+func (req ShareFileRequest) ToCommand(fileId string) sharefile.ShareFileCommand {
+	return sharefile.ShareFileCommand{
+		FileId:     fileId,
+		ProfileID:  req.ProfileID,
+		Permission: req.Permission,
+	}
+}
@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"devconnectstorage/internal/application/aggregate"
+	"time"
+)
+
+type ShareLinkResponse struct {
+	Id                string     `json:"id"`
+	FileId            string     `json:"file_id"`
+	Token             string     `json:"token"`
+	Path              string     `json:"path"`
+	PasswordProtected bool       `json:"password_protected"`
+	MaxDownloads      int64      `json:"max_downloads"`
+	DownloadCount     int64      `json:"download_count"`
+	ExpiresAt         time.Time  `json:"expires_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	RevokedAt         *time.Time `json:"revoked_at,omitempty"`
+}
+
+func NewShareLinkResponse(signed aggregate.SignedShareLink) ShareLinkResponse {
+	return ShareLinkResponse{
+		Id:                signed.Link.ID(),
+		FileId:            signed.Link.FileID(),
+		Token:             signed.Token,
+		Path:              "/s/" + signed.Token,
+		PasswordProtected: signed.Link.RequiresPassword(),
+		MaxDownloads:      signed.Link.MaxDownloads(),
+		DownloadCount:     signed.Link.DownloadCount(),
+		ExpiresAt:         signed.Link.ExpiresAt(),
+		CreatedAt:         signed.Link.CreatedAt(),
+		RevokedAt:         signed.Link.RevokedAt(),
+	}
+}
+
+func NewShareLinkResponses(links []aggregate.SignedShareLink) []ShareLinkResponse {
+	responses := make([]ShareLinkResponse, 0, len(links))
+	for _, link := range links {
+		responses = append(responses, NewShareLinkResponse(link))
+	}
+	return responses
+}
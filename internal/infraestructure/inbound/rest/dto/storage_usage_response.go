@@ -0,0 +1,24 @@
+package dto
+
+import "devconnectstorage/internal/application/aggregate"
+
+// StorageUsageResponse leaves the quota fields out for unlimited profiles.
+type StorageUsageResponse struct {
+	UsedBytes      int64  `json:"used_bytes"`
+	FileCount      int64  `json:"file_count"`
+	QuotaBytes     *int64 `json:"quota_bytes,omitempty"`
+	RemainingBytes *int64 `json:"remaining_bytes,omitempty"`
+}
+
+func NewStorageUsageResponse(report aggregate.StorageReport) StorageUsageResponse {
+	response := StorageUsageResponse{
+		UsedBytes: report.Usage.UsedBytes(),
+		FileCount: report.Usage.FileCount(),
+	}
+	if report.Limit > 0 {
+		remaining := report.Usage.Remaining(report.Limit)
+		response.QuotaBytes = &report.Limit
+		response.RemainingBytes = &remaining
+	}
+	return response
+}
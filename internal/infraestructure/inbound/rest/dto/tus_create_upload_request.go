@@ -0,0 +1,76 @@
+package dto
+
+import (
+	"devconnectstorage/internal/apperror"
+	createresumableupload "devconnectstorage/internal/application/usecase/create_resumable_upload"
+	"encoding/base64"
+	"strconv"
+	"strings"
+)
+
+type TusCreateUploadRequest struct {
+	ProjectID  *string
+	FileName   string
+	MimeType   string
+	Length     int64
+	Visibility string
+}
+
+func NewTusCreateUploadRequest(uploadLength string, uploadMetadata string) (TusCreateUploadRequest, error) {
+	length, err := strconv.ParseInt(uploadLength, 10, 64)
+	if err != nil || length <= 0 {
+		return TusCreateUploadRequest{}, apperror.New(apperror.ErrValidation, "invalid Upload-Length header")
+	}
+
+	metadata, err := parseTusMetadata(uploadMetadata)
+	if err != nil {
+		return TusCreateUploadRequest{}, err
+	}
+
+	req := TusCreateUploadRequest{
+		FileName:   metadata["filename"],
+		MimeType:   metadata["filetype"],
+		Length:     length,
+		Visibility: metadata["visibility"],
+	}
+	if projectID, ok := metadata["project_id"]; ok && projectID != "" {
+		req.ProjectID = &projectID
+	}
+	if req.FileName == "" || req.MimeType == "" || req.Visibility == "" {
+		return TusCreateUploadRequest{}, apperror.New(apperror.ErrValidation, "missing filename, filetype or visibility in Upload-Metadata")
+	}
+	return req, nil
+}
+
+func (req TusCreateUploadRequest) ToCommand() createresumableupload.CreateResumableUploadCommand {
+	return createresumableupload.CreateResumableUploadCommand{
+		ProjectID:  req.ProjectID,
+		FileName:   req.FileName,
+		MimeType:   req.MimeType,
+		Length:     req.Length,
+		Visibility: req.Visibility,
+	}
+}
+
+func parseTusMetadata(header string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if strings.TrimSpace(header) == "" {
+		return metadata, nil
+	}
+	for _, pair := range strings.Split(header, ",") {
+		fields := strings.Fields(pair)
+		if len(fields) == 0 || len(fields) > 2 {
+			return nil, apperror.New(apperror.ErrValidation, "invalid Upload-Metadata")
+		}
+		value := ""
+		if len(fields) == 2 {
+			decoded, err := base64.StdEncoding.DecodeString(fields[1])
+			if err != nil {
+				return nil, apperror.New(apperror.ErrValidation, "invalid Upload-Metadata")
+			}
+			value = string(decoded)
+		}
+		metadata[fields[0]] = value
+	}
+	return metadata, nil
+}
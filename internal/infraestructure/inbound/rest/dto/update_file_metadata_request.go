@@ -0,0 +1,24 @@
+package dto
+
+import updatefilemetadata "devconnectstorage/internal/application/usecase/update_file_metadata"
+
+type UpdateFileMetadataRequest struct {
+	FileName   *string           `json:"file_name"`
+	Visibility *string           `json:"visibility"`
+	ProjectID  *string           `json:"project_id"`
+	Tags       []string          `json:"tags"`
+	Attributes map[string]string `json:"attributes"`
+	Path       *string           `json:"path"`
+}
+
+func (req UpdateFileMetadataRequest) ToCommand(id string) updatefilemetadata.UpdateFileMetadataCommand {
+	return updatefilemetadata.UpdateFileMetadataCommand{
+		Id:         id,
+		FileName:   req.FileName,
+		Visibility: req.Visibility,
+		ProjectID:  req.ProjectID,
+		Tags:       req.Tags,
+		Attributes: req.Attributes,
+		Path:       req.Path,
+	}
+}
@@ -5,11 +5,16 @@ import (
 	"io"
 )
 
+// UploadFileRequest takes repeated tags fields and attributes as a JSON
+// object of strings.
 type UploadFileRequest struct {
-	ProjectID  *string `form:"project_id"`
-	FileName   string  `form:"file_name" binding:"required"`
-	MimeType   string  `form:"mime_type" binding:"required"`
-	Visibility string  `form:"visibility" binding:"required"`
+	ProjectID  *string           `form:"project_id"`
+	FileName   string            `form:"file_name" binding:"required"`
+	MimeType   string            `form:"mime_type"`
+	Visibility string            `form:"visibility" binding:"required"`
+	Tags       []string          `form:"tags"`
+	Attributes map[string]string `form:"attributes"`
+	Path       string            `form:"path"`
 }
 
 func (req UploadFileRequest) ToCommand(content io.Reader, size int64) uploadfile.UploadFileCommand {
@@ -20,5 +25,8 @@ func (req UploadFileRequest) ToCommand(content io.Reader, size int64) uploadfile
 		Size:       size,
 		Visibility: req.Visibility,
 		Content:    content,
+		Tags:       req.Tags,
+		Attributes: req.Attributes,
+		Path:       req.Path,
 	}
 }
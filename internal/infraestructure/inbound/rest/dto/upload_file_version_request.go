@@ -0,0 +1,19 @@
+package dto
+
+import (
+	uploadfileversion "devconnectstorage/internal/application/usecase/upload_file_version"
+	"io"
+)
+
+type UploadFileVersionRequest struct {
+	MimeType string `form:"mime_type"`
+}
+
+func (req UploadFileVersionRequest) ToCommand(fileId string, content io.Reader, size int64) uploadfileversion.UploadFileVersionCommand {
+	return uploadfileversion.UploadFileVersionCommand{
+		FileId:   fileId,
+		MimeType: req.MimeType,
+		Size:     size,
+		Content:  content,
+	}
+}
@@ -0,0 +1,64 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+var statusByKind = map[error]int{
+	apperror.ErrValidation:          http.StatusBadRequest,
+	apperror.ErrUnauthenticated:     http.StatusUnauthorized,
+	apperror.ErrForbidden:           http.StatusForbidden,
+	apperror.ErrNotFound:            http.StatusNotFound,
+	apperror.ErrConflict:            http.StatusConflict,
+	apperror.ErrPayloadTooLarge:     http.StatusRequestEntityTooLarge,
+	apperror.ErrUnsupportedMedia:    http.StatusUnsupportedMediaType,
+	apperror.ErrInsufficientStorage: http.StatusInsufficientStorage,
+	apperror.ErrUpstreamUnavailable: http.StatusServiceUnavailable,
+}
+
+// ErrorHandler renders the last error a handler attached with ctx.Error as a
+// problem+json response. Client errors expose their classified message;
+// server errors are logged and answered with a generic detail.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		err := ctx.Errors.Last().Err
+
+		status, classified := statusByKind[apperror.KindOf(err)]
+		if !classified {
+			status = http.StatusInternalServerError
+		}
+		detail := apperror.MessageOf(err)
+		if status >= http.StatusInternalServerError && status != http.StatusInsufficientStorage {
+			log.Printf("%s %s failed: %v", ctx.Request.Method, ctx.Request.URL.Path, err)
+			detail = serverErrorDetail(err)
+		}
+		writeProblem(ctx, status, detail)
+	}
+}
+
+func serverErrorDetail(err error) string {
+	if errors.Is(err, apperror.ErrUpstreamUnavailable) {
+		return "a required service is temporarily unavailable"
+	}
+	return "an unexpected error occurred"
+}
+
+func writeProblem(ctx *gin.Context, status int, detail string) {
+	if ctx.Request.Method == http.MethodHead {
+		ctx.Status(status)
+		return
+	}
+	ctx.Header("Content-Type", "application/problem+json")
+	ctx.JSON(status, dto.NewProblemResponse(status, detail, ctx.Request.URL.Path))
+}
@@ -0,0 +1,86 @@
+package rest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(ErrorHandler())
+	return router
+}
+
+func serveError(t *testing.T, err error) (*httptest.ResponseRecorder, dto.ProblemResponse) {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	router := newTestRouter()
+	router.GET("/files/:id", func(ctx *gin.Context) {
+		_ = ctx.Error(err)
+	})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, "/files/123", nil))
+
+	var problem dto.ProblemResponse
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &problem))
+	return resp, problem
+}
+
+func TestErrorHandler_ShouldMapKindsToStatusCodes(t *testing.T) {
+	cases := map[error]int{
+		apperror.ErrValidation:          http.StatusBadRequest,
+		apperror.ErrUnauthenticated:     http.StatusUnauthorized,
+		apperror.ErrForbidden:           http.StatusForbidden,
+		apperror.ErrNotFound:            http.StatusNotFound,
+		apperror.ErrConflict:            http.StatusConflict,
+		apperror.ErrPayloadTooLarge:     http.StatusRequestEntityTooLarge,
+		apperror.ErrUnsupportedMedia:    http.StatusUnsupportedMediaType,
+		apperror.ErrInsufficientStorage: http.StatusInsufficientStorage,
+		apperror.ErrUpstreamUnavailable: http.StatusServiceUnavailable,
+	}
+	for kind, status := range cases {
+		resp, problem := serveError(t, apperror.New(kind, "something happened"))
+
+		assert.Equal(t, status, resp.Code)
+		assert.Equal(t, status, problem.Status)
+	}
+}
+
+func TestErrorHandler_ShouldRenderClientErrorsAsProblem(t *testing.T) {
+	resp, problem := serveError(t, apperror.Wrap(apperror.ErrNotFound, errors.New("mongo: no documents in result"), "file not found"))
+
+	assert.Equal(t, "application/problem+json", resp.Header().Get("Content-Type"))
+	assert.Equal(t, dto.ProblemResponse{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "file not found",
+		Instance: "/files/123",
+	}, problem)
+}
+
+func TestErrorHandler_ShouldHideServerErrorDetails(t *testing.T) {
+	resp, problem := serveError(t, errors.New("dial tcp 10.0.0.3:27017: connection refused"))
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Equal(t, "an unexpected error occurred", problem.Detail)
+	assert.NotContains(t, resp.Body.String(), "10.0.0.3")
+
+	resp, problem = serveError(t, apperror.Wrap(apperror.ErrUpstreamUnavailable, errors.New("dial tcp minio:9000"), "object storage unavailable"))
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.Code)
+	assert.Equal(t, "a required service is temporarily unavailable", problem.Detail)
+	assert.NotContains(t, resp.Body.String(), "minio")
+}
@@ -0,0 +1,77 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"devconnectstorage/internal/apperror"
+	getcleanupjob "devconnectstorage/internal/application/usecase/get_cleanup_job"
+	receivedeletionevent "devconnectstorage/internal/application/usecase/receive_deletion_event"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookSecretHeader carries the secret shared with the main service, which
+// is the only caller of the event endpoints.
+const WebhookSecretHeader = "X-Webhook-Secret"
+
+type EventRestController struct {
+	receiveDeletionEvent receivedeletionevent.IReceiveDeletionEventUseCase
+	getCleanupJob        getcleanupjob.IGetCleanupJobUseCase
+	secret               []byte
+}
+
+func NewEventRestController(
+	receiveDeletionEventUseCase receivedeletionevent.IReceiveDeletionEventUseCase,
+	getCleanupJobUseCase getcleanupjob.IGetCleanupJobUseCase,
+	secret []byte,
+) *EventRestController {
+	return &EventRestController{
+		receiveDeletionEvent: receiveDeletionEventUseCase,
+		getCleanupJob:        getCleanupJobUseCase,
+		secret:               secret,
+	}
+}
+
+// ReceiveEvent answers 202 once the cleanup is scheduled; the files are
+// trashed in the background.
+func (controller *EventRestController) ReceiveEvent(ctx *gin.Context) {
+	if err := controller.authorize(ctx); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.DeletionEventRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	job, err := controller.receiveDeletionEvent.Execute(ctx.Request.Context(), body.ToCommand())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(202, dto.NewCleanupJobResponse(job))
+}
+
+func (controller *EventRestController) GetEvent(ctx *gin.Context) {
+	if err := controller.authorize(ctx); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	job, err := controller.getCleanupJob.Execute(ctx.Request.Context(), getcleanupjob.GetCleanupJobQuery{Id: ctx.Param("id")})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewCleanupJobResponse(job))
+}
+
+func (controller *EventRestController) authorize(ctx *gin.Context) error {
+	provided := []byte(ctx.GetHeader(WebhookSecretHeader))
+	if len(controller.secret) == 0 || subtle.ConstantTimeCompare(provided, controller.secret) != 1 {
+		return apperror.New(apperror.ErrUnauthenticated, "invalid webhook secret")
+	}
+	return nil
+}
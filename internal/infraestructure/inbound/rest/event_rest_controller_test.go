@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	getcleanupjob "devconnectstorage/internal/application/usecase/get_cleanup_job"
+	receivedeletionevent "devconnectstorage/internal/application/usecase/receive_deletion_event"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ReceiveDeletionEventUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ReceiveDeletionEventUseCaseMock) Execute(ctx context.Context, command receivedeletionevent.ReceiveDeletionEventCommand) (domain.CleanupJob, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.CleanupJob), args.Error(1)
+}
+
+type GetCleanupJobUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetCleanupJobUseCaseMock) Execute(ctx context.Context, query getcleanupjob.GetCleanupJobQuery) (domain.CleanupJob, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.CleanupJob), args.Error(1)
+}
+
+func newEventRouter(receive *ReceiveDeletionEventUseCaseMock, get *GetCleanupJobUseCaseMock) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := NewEventRestController(receive, get, []byte("s3cret"))
+
+	router := newTestRouter()
+	router.POST("/events", controller.ReceiveEvent)
+	router.GET("/events/:id", controller.GetEvent)
+	return router
+}
+
+func TestReceiveEvent_ShouldReturn202WithScheduledJob(t *testing.T) {
+	receive := new(ReceiveDeletionEventUseCaseMock)
+	router := newEventRouter(receive, new(GetCleanupJobUseCaseMock))
+
+	job, _ := domain.NewCleanupJob("event-1", domain.CleanupScopeOwner, "12")
+	receive.On("Execute", mock.Anything, receivedeletionevent.ReceiveDeletionEventCommand{EventID: "event-1", Type: "ProfileDeleted", ProfileID: "12"}).Return(job, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{"id":"event-1","type":"ProfileDeleted","profile_id":12}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSecretHeader, "s3cret")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusAccepted, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"status":"PENDING"`)
+	receive.AssertExpectations(t)
+}
+
+func TestReceiveEvent_ShouldReturn401WithWrongSecret(t *testing.T) {
+	receive := new(ReceiveDeletionEventUseCaseMock)
+	router := newEventRouter(receive, new(GetCleanupJobUseCaseMock))
+
+	req := httptest.NewRequest(http.MethodPost, "/events", strings.NewReader(`{"id":"event-1","type":"ProjectDeleted","project_id":"p1"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookSecretHeader, "guess")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	receive.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGetEvent_ShouldReturnCleanupProgress(t *testing.T) {
+	get := new(GetCleanupJobUseCaseMock)
+	router := newEventRouter(new(ReceiveDeletionEventUseCaseMock), get)
+
+	job, _ := domain.NewCleanupJob("event-1", domain.CleanupScopeProject, "project-1")
+	_ = job.RecordBatch(25, nil)
+	get.On("Execute", mock.Anything, getcleanupjob.GetCleanupJobQuery{Id: "event-1"}).Return(job, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/events/event-1", nil)
+	req.Header.Set(WebhookSecretHeader, "s3cret")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"trashed_count":25`)
+	get.AssertExpectations(t)
+}
@@ -1,61 +1,87 @@
 package rest
 
 import (
-	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
 	deletefile "devconnectstorage/internal/application/usecase/delete_file"
 	getfile "devconnectstorage/internal/application/usecase/get_file"
+	getfiledownloadurl "devconnectstorage/internal/application/usecase/get_file_download_url"
+	getfilemetadata "devconnectstorage/internal/application/usecase/get_file_metadata"
+	listfiles "devconnectstorage/internal/application/usecase/list_files"
+	updatefilemetadata "devconnectstorage/internal/application/usecase/update_file_metadata"
 	uploadfile "devconnectstorage/internal/application/usecase/upload_file"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/httpheader"
 	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
-	"devconnectstorage/internal/infraestructure/outbound/auth"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
+const publicMaxAge = 300
+
 type FileRestController struct {
-	uploadFile uploadfile.IUploadFileUseCase
-	getFile    getfile.IGetFileByIdUseCase
-	deleteFile deletefile.IDeleteFileUseCase
+	uploadFile        uploadfile.IUploadFileUseCase
+	getFile           getfile.IGetFileByIdUseCase
+	getMetadata       getfilemetadata.IGetFileMetadataUseCase
+	deleteFile        deletefile.IDeleteFileUseCase
+	updateFile        updatefilemetadata.IUpdateFileMetadataUseCase
+	downloadURL       getfiledownloadurl.IGetFileDownloadURLUseCase
+	listFiles         listfiles.IListFilesUseCase
+	redirectDownloads bool
+	maxUploadSize     int64
 }
 
-func NewFileRestController(usecase uploadfile.IUploadFileUseCase, getFileUsecase getfile.IGetFileByIdUseCase, deleteFileUseCase deletefile.IDeleteFileUseCase) *FileRestController {
+func NewFileRestController(usecase uploadfile.IUploadFileUseCase, getFileUsecase getfile.IGetFileByIdUseCase, getMetadataUseCase getfilemetadata.IGetFileMetadataUseCase, deleteFileUseCase deletefile.IDeleteFileUseCase, updateFileUseCase updatefilemetadata.IUpdateFileMetadataUseCase, downloadURLUseCase getfiledownloadurl.IGetFileDownloadURLUseCase, listFilesUseCase listfiles.IListFilesUseCase, redirectDownloads bool, maxUploadSize int64) *FileRestController {
 	return &FileRestController{
-		uploadFile: usecase,
-		getFile:    getFileUsecase,
-		deleteFile: deleteFileUseCase,
+		uploadFile:        usecase,
+		getFile:           getFileUsecase,
+		getMetadata:       getMetadataUseCase,
+		deleteFile:        deleteFileUseCase,
+		updateFile:        updateFileUseCase,
+		downloadURL:       downloadURLUseCase,
+		listFiles:         listFilesUseCase,
+		redirectDownloads: redirectDownloads,
+		maxUploadSize:     maxUploadSize,
 	}
 }
 
 func (controller *FileRestController) UploadFile(ctx *gin.Context) {
 	var fileBody dto.UploadFileRequest
-	jwt, err := ctx.Cookie("jwt")
+	ctxWithToken, err := authenticatedContext(ctx)
 	if err != nil {
-		ctx.JSON(401, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
-	ctxWithToken := context.WithValue(
-		ctx.Request.Context(),
-		auth.AuthTokenKey,
-		jwt,
-	)
 	if err := ctx.ShouldBind(&fileBody); err != nil {
-		ctx.JSON(400, gin.H{"error": err.Error()})
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+	expectedChecksum, err := dto.ExpectedChecksum(ctx.Request.Header)
+	if err != nil {
+		_ = ctx.Error(err)
 		return
 	}
 	fileHeader, err := ctx.FormFile("file")
 	if err != nil {
-		ctx.JSON(400, gin.H{"error": "file is required"})
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "file is required"))
 		return
 	}
 
 	if fileHeader.Size <= 0 {
-		ctx.JSON(400, gin.H{"error": "file size cannot be 0"})
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "file size cannot be 0"))
 		return
 	}
 
 	file, err := fileHeader.Open()
 	if err != nil {
 		//govulncheck:ignore GO-2025-4233 reason: false positive via gin error handling; HTTP/3 not used
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -66,11 +92,62 @@ func (controller *FileRestController) UploadFile(ctx *gin.Context) {
 	}()
 
 	command := fileBody.ToCommand(file, fileHeader.Size)
+	command.ExpectedChecksum = expectedChecksum
 
 	result, err := controller.uploadFile.Execute(ctxWithToken, command)
 	if err != nil {
 		//govulncheck:ignore GO-2025-4233 reason: false positive via gin error handling; HTTP/3 not used
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.NewFileMetadataResponse(result))
+}
+
+func (controller *FileRestController) UploadRawFile(ctx *gin.Context) {
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	fileBody, err := dto.NewRawUploadFileRequest(ctx.Request.URL.Query(), ctx.Request.Header, ctx.ContentType())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	expectedChecksum, err := dto.ExpectedChecksum(ctx.Request.Header)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	size := ctx.Request.ContentLength
+	if size == 0 {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "file size cannot be 0"))
+		return
+	}
+	if controller.maxUploadSize > 0 && size > controller.maxUploadSize {
+		_ = ctx.Error(apperror.New(apperror.ErrPayloadTooLarge, "file exceeds the maximum upload size"))
+		return
+	}
+
+	var content io.Reader = ctx.Request.Body
+	if controller.maxUploadSize > 0 {
+		content = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, controller.maxUploadSize)
+	}
+
+	command := fileBody.ToCommand(content, size)
+	command.ExpectedChecksum = expectedChecksum
+
+	result, err := controller.uploadFile.Execute(ctxWithToken, command)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			_ = ctx.Error(apperror.New(apperror.ErrPayloadTooLarge, "file exceeds the maximum upload size"))
+			return
+		}
+		//govulncheck:ignore GO-2025-4233 reason: false positive via gin error handling; HTTP/3 not used
+		_ = ctx.Error(err)
 		return
 	}
 
@@ -80,99 +157,297 @@ func (controller *FileRestController) UploadFile(ctx *gin.Context) {
 func (controller *FileRestController) GetFileContentById(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
-		ctx.JSON(400, gin.H{"error": "id cannot be empty"})
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
 		return
 	}
 
-	jwt, err := ctx.Cookie("jwt")
+	ctxWithToken, err := authenticatedContext(ctx)
 	if err != nil {
-		ctx.JSON(401, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
+		return
+	}
+
+	if controller.shouldRedirect(ctx) {
+		download, err := controller.downloadURL.Execute(
+			ctxWithToken,
+			getfiledownloadurl.GetFileDownloadURLQuery{Id: id},
+		)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		ctx.Header("Cache-Control", "private, no-store")
+		ctx.Redirect(302, download.URL)
 		return
 	}
-	ctxWithToken := context.WithValue(
-		ctx.Request.Context(),
-		auth.AuthTokenKey,
-		jwt,
-	)
 
 	result, err := controller.getFile.Execute(
 		ctxWithToken,
-		getfile.GetFileByIdQuery{Id: id},
+		getfile.GetFileByIdQuery{
+			Id:            id,
+			Ranges:        dto.ParseRange(ctx.GetHeader("Range")),
+			IfRange:       dto.ParseIfRange(ctx.GetHeader("If-Range")),
+			Preconditions: dto.ParsePreconditions(ctx.GetHeader("If-None-Match"), ctx.GetHeader("If-Modified-Since")),
+		},
 	)
-
+	if errors.Is(err, aggregate.ErrNotModified) {
+		writeNotModified(ctx, result.Metadata, result.Validators)
+		return
+	}
+	if errors.Is(err, aggregate.ErrRangeNotSatisfiable) {
+		writeRangeNotSatisfiable(ctx, result)
+		return
+	}
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 
-	defer func() { _ = result.Content.Close() }()
+	writeFileContent(ctx, result, cacheControl(result.Metadata))
+}
 
-	ctx.Header("Content-Disposition", "attachment; filename=\""+result.Metadata.FileName()+"\"")
+func (controller *FileRestController) shouldRedirect(ctx *gin.Context) bool {
+	switch ctx.Query("redirect") {
+	case "true":
+		return true
+	case "false":
+		return false
+	default:
+		return controller.redirectDownloads
+	}
+}
 
-	ctx.DataFromReader(
-		200,
-		result.Metadata.Size(),
-		result.Metadata.MimeType(),
-		result.Content,
-		nil,
-	)
+func (controller *FileRestController) ListFiles(ctx *gin.Context) {
+	var request dto.ListFilesRequest
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid query parameters"))
+		return
+	}
 
+	page, err := controller.listFiles.Execute(ctxWithToken, request.ToQuery(ctx.QueryMap("attr")))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFilePageResponse(page))
 }
 
 func (controller *FileRestController) GetFileMetadataById(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
-		ctx.JSON(400, gin.H{"error": "id cannot be empty"})
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
 		return
 	}
 
-	jwt, err := ctx.Cookie("jwt")
+	ctxWithToken, err := authenticatedContext(ctx)
 	if err != nil {
-		ctx.JSON(401, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
-	ctxWithToken := context.WithValue(
-		ctx.Request.Context(),
-		auth.AuthTokenKey,
-		jwt,
+
+	file, err := controller.getMetadata.Execute(
+		ctxWithToken,
+		getfilemetadata.GetFileMetadataQuery{
+			Id:            id,
+			Preconditions: dto.ParsePreconditions(ctx.GetHeader("If-None-Match"), ctx.GetHeader("If-Modified-Since")),
+		},
 	)
+	if errors.Is(err, aggregate.ErrNotModified) {
+		writeNotModified(ctx, file, metadataValidators(file))
+		return
+	}
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	writeValidators(ctx, metadataValidators(file), cacheControl(file))
+	ctx.JSON(200, dto.NewFileMetadataResponse(file))
+}
 
-	result, err := controller.getFile.Execute(
+func (controller *FileRestController) HeadFileContent(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file, err := controller.getMetadata.Execute(
 		ctxWithToken,
-		getfile.GetFileByIdQuery{Id: id},
+		getfilemetadata.GetFileMetadataQuery{
+			Id:            id,
+			Preconditions: dto.ParsePreconditions(ctx.GetHeader("If-None-Match"), ctx.GetHeader("If-Modified-Since")),
+			Content:       true,
+		},
 	)
+	if errors.Is(err, aggregate.ErrNotModified) {
+		writeNotModified(ctx, file, contentValidators(file))
+		return
+	}
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
-	defer func() { _ = result.Content.Close() }()
-	ctx.JSON(200, dto.NewFileMetadataResponse(result.Metadata))
+
+	writeValidators(ctx, contentValidators(file), cacheControl(file))
+	ctx.Header("Accept-Ranges", "bytes")
+	ctx.Header("Content-Type", file.MimeType())
+	ctx.Header("Content-Length", strconv.FormatInt(file.Size(), 10))
+	ctx.Status(200)
 }
 
 func (controller *FileRestController) DeleteFile(ctx *gin.Context) {
 	id := ctx.Param("id")
 	if id == "" {
-		ctx.JSON(400, gin.H{"error": "id cannot be empty"})
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
 		return
 	}
 
-	jwt, err := ctx.Cookie("jwt")
+	ctxWithToken, err := authenticatedContext(ctx)
 	if err != nil {
-		ctx.JSON(401, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
-	ctxWithToken := context.WithValue(
-		ctx.Request.Context(),
-		auth.AuthTokenKey,
-		jwt,
-	)
 	command := deletefile.DeleteFileCommand{
-		Id: id,
+		Id:        id,
+		Permanent: ctx.Query("permanent") == "true",
 	}
 	err = controller.deleteFile.Execute(ctxWithToken, command)
 	if err != nil {
-		ctx.JSON(500, gin.H{"error": err.Error()})
+		_ = ctx.Error(err)
 		return
 	}
 	ctx.JSON(204, gin.H{})
 }
+
+func (controller *FileRestController) UpdateFile(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.UpdateFileMetadataRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	result, err := controller.updateFile.Execute(ctxWithToken, body.ToCommand(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponse(result))
+}
+
+func writeFileContent(ctx *gin.Context, result *aggregate.FileContent, cacheControl string) {
+	defer func() { _ = result.Close() }()
+
+	ctx.Header("Content-Disposition", httpheader.AttachmentDisposition(result.Metadata.FileName()))
+	writeValidators(ctx, result.Validators, cacheControl)
+	ctx.Header("Accept-Ranges", "bytes")
+
+	switch len(result.Parts) {
+	case 0:
+		ctx.DataFromReader(
+			200,
+			result.Metadata.Size(),
+			result.Metadata.MimeType(),
+			result.Content,
+			nil,
+		)
+	case 1:
+		part := result.Parts[0]
+		ctx.DataFromReader(
+			206,
+			part.Range.Length,
+			result.Metadata.MimeType(),
+			part.Content,
+			map[string]string{"Content-Range": contentRange(part.Range, result.Metadata.Size())},
+		)
+	default:
+		writeMultipartRanges(ctx, result)
+	}
+}
+
+func writeMultipartRanges(ctx *gin.Context, result *aggregate.FileContent) {
+	writer := multipart.NewWriter(ctx.Writer)
+	ctx.Header("Content-Type", "multipart/byteranges; boundary="+writer.Boundary())
+	ctx.Status(206)
+	for _, part := range result.Parts {
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", result.Metadata.MimeType())
+		header.Set("Content-Range", contentRange(part.Range, result.Metadata.Size()))
+		partWriter, err := writer.CreatePart(header)
+		if err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+		if _, err := io.Copy(partWriter, part.Content); err != nil {
+			_ = ctx.Error(err)
+			return
+		}
+	}
+	_ = writer.Close()
+}
+
+func writeValidators(ctx *gin.Context, validators aggregate.Validators, cacheControl string) {
+	if validators.ETag != "" {
+		ctx.Header("ETag", "\""+validators.ETag+"\"")
+	}
+	if !validators.LastModified.IsZero() {
+		ctx.Header("Last-Modified", validators.LastModified.Format(http.TimeFormat))
+	}
+	ctx.Header("Cache-Control", cacheControl)
+}
+
+func writeNotModified(ctx *gin.Context, file domain.File, validators aggregate.Validators) {
+	writeValidators(ctx, validators, cacheControl(file))
+	ctx.Status(304)
+}
+
+func metadataValidators(file domain.File) aggregate.Validators {
+	return aggregate.NewMetadataValidators(file)
+}
+
+// contentValidators never stat the object, so files stored before checksums
+// were recorded are only validated by Last-Modified.
+func contentValidators(file domain.File) aggregate.Validators {
+	return aggregate.NewValidators(file, file.Checksum())
+}
+
+// cacheControl lets shared caches keep public files briefly; everything else
+// may only be cached by the client and must be revalidated on every use.
+func cacheControl(file domain.File) string {
+	if file.Visibility() == domain.VisibilityPublic {
+		return "public, max-age=" + strconv.Itoa(publicMaxAge)
+	}
+	return "private, no-cache"
+}
+
+func writeRangeNotSatisfiable(ctx *gin.Context, result *aggregate.FileContent) {
+	ctx.Header("Accept-Ranges", "bytes")
+	ctx.Header("Content-Range", "bytes */"+strconv.FormatInt(result.Metadata.Size(), 10))
+	writeProblem(ctx, 416, aggregate.ErrRangeNotSatisfiable.Error())
+}
+
+func contentRange(byteRange aggregate.ByteRange, size int64) string {
+	return "bytes " + strconv.FormatInt(byteRange.Start, 10) + "-" + strconv.FormatInt(byteRange.End(), 10) + "/" + strconv.FormatInt(size, 10)
+}
@@ -5,14 +5,20 @@ import (
 	"context"
 	"errors"
 	"io"
+	"mime"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"devconnectstorage/internal/application/aggregate"
 	deletefile "devconnectstorage/internal/application/usecase/delete_file"
 	getfile "devconnectstorage/internal/application/usecase/get_file"
+	getfiledownloadurl "devconnectstorage/internal/application/usecase/get_file_download_url"
+	getfilemetadata "devconnectstorage/internal/application/usecase/get_file_metadata"
+	listfiles "devconnectstorage/internal/application/usecase/list_files"
+	updatefilemetadata "devconnectstorage/internal/application/usecase/update_file_metadata"
 	uploadfile "devconnectstorage/internal/application/usecase/upload_file"
 	"devconnectstorage/internal/domain"
 
@@ -29,10 +35,46 @@ type GetFileUseCaseMock struct {
 	mock.Mock
 }
 
+type GetFileMetadataUseCaseMock struct {
+	mock.Mock
+}
+
 type DeleteFileUseCaseMock struct {
 	mock.Mock
 }
 
+type UpdateFileMetadataUseCaseMock struct {
+	mock.Mock
+}
+
+type GetFileDownloadURLUseCaseMock struct {
+	mock.Mock
+}
+
+type ListFilesUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ListFilesUseCaseMock) Execute(ctx context.Context, query listfiles.ListFilesQuery) (aggregate.FilePage, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(aggregate.FilePage), args.Error(1)
+}
+
+func (m *GetFileDownloadURLUseCaseMock) Execute(ctx context.Context, query getfiledownloadurl.GetFileDownloadURLQuery) (aggregate.PresignedDownload, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(aggregate.PresignedDownload), args.Error(1)
+}
+
+func (m *UpdateFileMetadataUseCaseMock) Execute(ctx context.Context, command updatefilemetadata.UpdateFileMetadataCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func (m *GetFileMetadataUseCaseMock) Execute(ctx context.Context, query getfilemetadata.GetFileMetadataQuery) (domain.File, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
 func (m *GetFileUseCaseMock) Execute(ctx context.Context, query getfile.GetFileByIdQuery) (*aggregate.FileContent, error) {
 	args := m.Called(ctx, query)
 	return args.Get(0).(*aggregate.FileContent), args.Error(1)
@@ -60,7 +102,7 @@ func TestUploadFile_ShouldReturn201_WhenRequestIsValid(t *testing.T) {
 		uploadFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/files", controller.UploadFile)
 
 	body := &bytes.Buffer{}
@@ -106,6 +148,199 @@ func TestUploadFile_ShouldReturn201_WhenRequestIsValid(t *testing.T) {
 	useCaseMock.AssertExpectations(t)
 }
 
+func TestUploadRawFile_ShouldStreamBodyWithMetadataFromQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock, maxUploadSize: 1024}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt&visibility=PUBLIC&project_id=123&tags=report&tags=q1&attributes%5Bkind%5D=invoice&path=/finance", bytes.NewReader([]byte("file content")))
+	req.Header.Set("Content-Type", "text/plain")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	expectedFile, _ := domain.NewFile("123", "owner-123", nil, "test.txt", "text/plain", 12, domain.VisibilityPublic)
+
+	var streamed []byte
+	useCaseMock.
+		On("Execute", mock.Anything, mock.MatchedBy(func(cmd uploadfile.UploadFileCommand) bool {
+			return cmd.FileName == "test.txt" && cmd.MimeType == "text/plain" && cmd.Visibility == "PUBLIC" &&
+				cmd.ProjectID != nil && *cmd.ProjectID == "123" && cmd.Size == 12 &&
+				len(cmd.Tags) == 2 && cmd.Tags[0] == "report" && cmd.Tags[1] == "q1" &&
+				len(cmd.Attributes) == 1 && cmd.Attributes["kind"] == "invoice" && cmd.Path == "/finance"
+		})).
+		Run(func(args mock.Arguments) {
+			streamed, _ = io.ReadAll(args.Get(1).(uploadfile.UploadFileCommand).Content)
+		}).
+		Return(expectedFile, nil).
+		Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, "file content", string(streamed))
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUploadRawFile_ShouldReadMetadataFromHeadersAndAcceptUnknownSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock, maxUploadSize: 1024}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files", bytes.NewReader([]byte("file content")))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("X-File-Name", "test.txt")
+	req.Header.Set("X-File-Visibility", "PRIVATE")
+	req.Header.Set("X-File-Tags", "report, q1")
+	req.Header.Set("X-File-Attributes", "kind=invoice")
+	req.Header.Set("X-File-Path", "/finance")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	expectedFile, _ := domain.NewFile("123", "owner-123", nil, "test.txt", "text/plain", 12, domain.VisibilityPrivate)
+
+	useCaseMock.
+		On("Execute", mock.Anything, mock.MatchedBy(func(cmd uploadfile.UploadFileCommand) bool {
+			return cmd.FileName == "test.txt" && cmd.Visibility == "PRIVATE" && cmd.ProjectID == nil && cmd.Size == domain.UnknownSize &&
+				len(cmd.Tags) == 2 && cmd.Tags[0] == "report" && cmd.Tags[1] == "q1" &&
+				len(cmd.Attributes) == 1 && cmd.Attributes["kind"] == "invoice" && cmd.Path == "/finance"
+		})).
+		Return(expectedFile, nil).
+		Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUploadRawFile_ShouldPassDigestHeaderAsExpectedChecksum(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt&visibility=PUBLIC", bytes.NewReader([]byte("hello")))
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Digest", "sha-256=LPJNul+wow4m6DsqxbninhsWHlwfp0JecwQzYpOLmCQ=")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	expectedFile, _ := domain.NewFile("123", "owner-123", nil, "test.txt", "text/plain", 5, domain.VisibilityPublic)
+
+	useCaseMock.
+		On("Execute", mock.Anything, mock.MatchedBy(func(cmd uploadfile.UploadFileCommand) bool {
+			return cmd.ExpectedChecksum == "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+		})).
+		Return(expectedFile, nil).
+		Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUploadRawFile_ShouldReturn400_WhenChecksumHeaderIsInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt&visibility=PUBLIC", bytes.NewReader([]byte("hello")))
+	req.Header.Set("Content-Type", "text/plain")
+	req.Header.Set("Content-SHA256", "not-a-digest")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestUploadRawFile_ShouldReturn400_WhenMetadataIsMissing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt", bytes.NewReader([]byte("file content")))
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestUploadRawFile_ShouldReturn413_WhenDeclaredLengthExceedsLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock, maxUploadSize: 4}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt&visibility=PUBLIC", bytes.NewReader([]byte("file content")))
+	req.Header.Set("Content-Type", "text/plain")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestUploadRawFile_ShouldReturn413_WhenStreamExceedsLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock, maxUploadSize: 4}
+
+	router := newTestRouter()
+	router.PUT("/files", controller.UploadRawFile)
+
+	req := httptest.NewRequest(http.MethodPut, "/files?file_name=test.txt&visibility=PUBLIC", bytes.NewReader([]byte("file content")))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "text/plain")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	var readErr error
+	useCaseMock.
+		On("Execute", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_, readErr = io.ReadAll(args.Get(1).(uploadfile.UploadFileCommand).Content)
+		}).
+		Return(domain.File{}, errors.Join(errors.New("upload failed"), &http.MaxBytesError{Limit: 4})).
+		Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	var tooLarge *http.MaxBytesError
+	assert.ErrorAs(t, readErr, &tooLarge)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
 func TestUploadFile_ShouldReturn400_WhenFileIsMissing(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -114,7 +349,7 @@ func TestUploadFile_ShouldReturn400_WhenFileIsMissing(t *testing.T) {
 		uploadFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/files", controller.UploadFile)
 
 	body := &bytes.Buffer{}
@@ -148,7 +383,7 @@ func TestUploadFile_ShouldReturn400_WhenRequiredMetadataIsMissing(t *testing.T)
 		uploadFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/files", controller.UploadFile)
 
 	body := &bytes.Buffer{}
@@ -180,7 +415,7 @@ func TestUploadFile_ShouldReturn400_WhenFileSizeIsZero(t *testing.T) {
 	useCaseMock := new(UploadFileUseCaseMock)
 	controller := &FileRestController{uploadFile: useCaseMock}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/files", controller.UploadFile)
 
 	body := &bytes.Buffer{}
@@ -212,7 +447,7 @@ func TestUploadFile_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
 		uploadFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.POST("/files", controller.UploadFile)
 
 	body := &bytes.Buffer{}
@@ -264,7 +499,7 @@ func TestGetFileContentById_ShouldReturn200_WhenFileExists(t *testing.T) {
 		getFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/content", controller.GetFileContentById)
 
 	useCaseMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123"}).
@@ -277,18 +512,324 @@ func TestGetFileContentById_ShouldReturn200_WhenFileExists(t *testing.T) {
 	router.ServeHTTP(resp, req)
 
 	assert.Equal(t, http.StatusOK, resp.Code)
-	assert.Equal(t, "attachment; filename=\"test.txt\"", resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, "attachment; filename=test.txt", resp.Header().Get("Content-Disposition"))
 	assert.Equal(t, "text/plain", resp.Header().Get("Content-Type"))
 	assert.Equal(t, "file content", resp.Body.String())
 
 	useCaseMock.AssertExpectations(t)
 }
 
+func TestGetFileContentById_ShouldEscapeFileNameInContentDisposition(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	file, _ := domain.NewFile("123", "owner-1", nil, `résumé "final".pdf`, "application/pdf", 5, domain.VisibilityPublic)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	useCaseMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123"}).
+		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(bytes.NewBufferString("%PDF-"))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "attachment; filename*=utf-8''r%C3%A9sum%C3%A9%20%22final%22.pdf", resp.Header().Get("Content-Disposition"))
+}
+
+func TestGetFileContentById_ShouldWriteValidatorsAndCacheControl(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 5, "key", domain.VisibilityPublic, domain.StatusAvailable, createdAt, domain.WithChecksum(checksum))
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	useCaseMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123"}).
+		Return(&aggregate.FileContent{
+			Metadata:   file,
+			Validators: aggregate.NewValidators(file, checksum),
+			Content:    io.NopCloser(bytes.NewBufferString("hello")),
+		}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "\""+checksum+"\"", resp.Header().Get("ETag"))
+	assert.Equal(t, "Fri, 01 Mar 2024 10:30:00 GMT", resp.Header().Get("Last-Modified"))
+	assert.Equal(t, "public, max-age=300", resp.Header().Get("Cache-Control"))
+}
+
+func TestGetFileContentById_ShouldReturn304_WhenPreconditionsMatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 5, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	query := getfile.GetFileByIdQuery{
+		Id:            "123",
+		Preconditions: aggregate.Preconditions{IfNoneMatch: []string{"abc", "def"}},
+	}
+	useCaseMock.On("Execute", mock.Anything, query).
+		Return(&aggregate.FileContent{Metadata: file, Validators: aggregate.NewValidators(file, "abc")}, aggregate.ErrNotModified).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.Header.Set("If-None-Match", "\"abc\", W/\"def\"")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Empty(t, resp.Body.String())
+	assert.Equal(t, "\"abc\"", resp.Header().Get("ETag"))
+	assert.Equal(t, "private, no-cache", resp.Header().Get("Cache-Control"))
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestGetFileMetadataById_ShouldReturn304_WhenNotModifiedSince(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	createdAt := time.Date(2024, 3, 1, 10, 30, 0, 0, time.UTC)
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 5, "key", domain.VisibilityPublic, domain.StatusAvailable, createdAt)
+
+	useCaseMock := new(GetFileMetadataUseCaseMock)
+	controller := &FileRestController{getMetadata: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id", controller.GetFileMetadataById)
+
+	query := getfilemetadata.GetFileMetadataQuery{
+		Id:            "123",
+		Preconditions: aggregate.Preconditions{IfModifiedSince: createdAt},
+	}
+	useCaseMock.On("Execute", mock.Anything, query).Return(file, aggregate.ErrNotModified).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123", nil)
+	req.Header.Set("If-Modified-Since", "Fri, 01 Mar 2024 10:30:00 GMT")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNotModified, resp.Code)
+	assert.Equal(t, "Fri, 01 Mar 2024 10:30:00 GMT", resp.Header().Get("Last-Modified"))
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestListFiles_ShouldReturn200WithPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListFilesUseCaseMock)
+	controller := &FileRestController{listFiles: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files", controller.ListFiles)
+
+	projectID := "project-1"
+	query := listfiles.ListFilesQuery{
+		ProjectID:   &projectID,
+		MimePrefix:  "image/",
+		Tags:        []string{"avatar", "profile"},
+		Attributes:  map[string]string{"kind": "avatar"},
+		CreatedFrom: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Sort:        "size",
+		Order:       "desc",
+		Cursor:      "abc",
+		Limit:       20,
+	}
+	file, _ := domain.RehydrateFile("123", "owner-1", &projectID, "photo.png", "image/png", 5, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, query).Return(aggregate.FilePage{Files: []domain.File{file}, NextCursor: "next"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files?project_id=project-1&mime_prefix=image/&tag=avatar&tag=profile&attr[kind]=avatar&created_from=2024-03-01T00:00:00Z&sort=size&order=desc&cursor=abc&limit=20", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"next_cursor":"next"`)
+	assert.Contains(t, resp.Body.String(), `"file_name":"photo.png"`)
+	assert.Contains(t, resp.Body.String(), `"tags":[]`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestListFiles_ShouldReturn400_WhenQueryIsMalformed(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListFilesUseCaseMock)
+	controller := &FileRestController{listFiles: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files", controller.ListFiles)
+
+	req := httptest.NewRequest(http.MethodGet, "/files?limit=many", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func rangeFile() domain.File {
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 10, "key", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	return file
+}
+
+func TestGetFileContentById_ShouldReturn206_WhenSingleRangeIsRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	query := getfile.GetFileByIdQuery{Id: "123", Ranges: []aggregate.RangeSpec{{First: 2, Last: 4}}}
+	useCaseMock.On("Execute", mock.Anything, query).
+		Return(&aggregate.FileContent{
+			Metadata: rangeFile(),
+			Parts: []aggregate.RangeContent{
+				{Range: aggregate.ByteRange{Start: 2, Length: 3}, Content: io.NopCloser(bytes.NewBufferString("234"))},
+			},
+		}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.Header.Set("Range", "bytes=2-4")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPartialContent, resp.Code)
+	assert.Equal(t, "bytes 2-4/10", resp.Header().Get("Content-Range"))
+	assert.Equal(t, "bytes", resp.Header().Get("Accept-Ranges"))
+	assert.Equal(t, "3", resp.Header().Get("Content-Length"))
+	assert.Equal(t, "234", resp.Body.String())
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestGetFileContentById_ShouldReturnMultipartByteRanges(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	query := getfile.GetFileByIdQuery{Id: "123", Ranges: []aggregate.RangeSpec{{First: 0, Last: 1}, {First: -1, Last: 2}}}
+	useCaseMock.On("Execute", mock.Anything, query).
+		Return(&aggregate.FileContent{
+			Metadata: rangeFile(),
+			Parts: []aggregate.RangeContent{
+				{Range: aggregate.ByteRange{Start: 0, Length: 2}, Content: io.NopCloser(bytes.NewBufferString("01"))},
+				{Range: aggregate.ByteRange{Start: 8, Length: 2}, Content: io.NopCloser(bytes.NewBufferString("89"))},
+			},
+		}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.Header.Set("Range", "bytes=0-1, -2")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPartialContent, resp.Code)
+	mediaType, params, err := mime.ParseMediaType(resp.Header().Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/byteranges", mediaType)
+
+	reader := multipart.NewReader(resp.Body, params["boundary"])
+	var ranges, bodies []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(part)
+		ranges = append(ranges, part.Header.Get("Content-Range"))
+		bodies = append(bodies, string(body))
+	}
+	assert.Equal(t, []string{"bytes 0-1/10", "bytes 8-9/10"}, ranges)
+	assert.Equal(t, []string{"01", "89"}, bodies)
+}
+
+func TestGetFileContentById_ShouldReturn416_WhenRangeIsNotSatisfiable(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).
+		Return(&aggregate.FileContent{Metadata: rangeFile()}, aggregate.ErrRangeNotSatisfiable).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.Header.Set("Range", "bytes=50-")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.Code)
+	assert.Equal(t, "bytes */10", resp.Header().Get("Content-Range"))
+}
+
+func TestGetFileContentById_ShouldIgnoreMalformedRangeAndPassIfRange(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	query := getfile.GetFileByIdQuery{Id: "123", IfRange: aggregate.RangeCondition{ETag: "abc"}}
+	useCaseMock.On("Execute", mock.Anything, query).
+		Return(&aggregate.FileContent{Metadata: rangeFile(), Content: io.NopCloser(bytes.NewBufferString("0123456789"))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.Header.Set("Range", "bytes=5-2")
+	req.Header.Set("If-Range", "\"abc\"")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "bytes", resp.Header().Get("Accept-Ranges"))
+	useCaseMock.AssertExpectations(t)
+}
+
 func TestGetFileContentById_ShouldReturn400_WhenIdMissing(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	controller := &FileRestController{}
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/content", controller.GetFileContentById)
 
 	req := httptest.NewRequest(http.MethodGet, "/files//content", nil)
@@ -306,7 +847,7 @@ func TestGetFileContentById_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
 		getFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/content", controller.GetFileContentById)
 
 	useCaseMock.On("Execute", mock.Anything, mock.Anything).
@@ -322,30 +863,133 @@ func TestGetFileContentById_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
 	useCaseMock.AssertExpectations(t)
 }
 
+func TestGetFileContentById_ShouldRedirect_WhenRequested(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	getFileMock := new(GetFileUseCaseMock)
+	downloadURLMock := new(GetFileDownloadURLUseCaseMock)
+	controller := &FileRestController{
+		getFile:     getFileMock,
+		downloadURL: downloadURLMock,
+	}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	downloadURLMock.On("Execute", mock.Anything, getfiledownloadurl.GetFileDownloadURLQuery{Id: "123"}).
+		Return(aggregate.PresignedDownload{URL: "http://minio/bucket/key?X-Amz-Signature=abc"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content?redirect=true", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusFound, resp.Code)
+	assert.Equal(t, "http://minio/bucket/key?X-Amz-Signature=abc", resp.Header().Get("Location"))
+	assert.Equal(t, "private, no-store", resp.Header().Get("Cache-Control"))
+	downloadURLMock.AssertExpectations(t)
+	getFileMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGetFileContentById_ShouldRedirectByDefault_WhenConfigured(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	downloadURLMock := new(GetFileDownloadURLUseCaseMock)
+	controller := &FileRestController{
+		downloadURL:       downloadURLMock,
+		redirectDownloads: true,
+	}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	downloadURLMock.On("Execute", mock.Anything, getfiledownloadurl.GetFileDownloadURLQuery{Id: "123"}).
+		Return(aggregate.PresignedDownload{URL: "http://minio/signed"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusFound, resp.Code)
+	assert.Equal(t, "http://minio/signed", resp.Header().Get("Location"))
+}
+
+func TestGetFileContentById_ShouldStream_WhenRedirectIsDisabledPerRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	file, _ := domain.NewFile("123", "owner-1", nil, "test.txt", "text/plain", 4, domain.VisibilityPublic)
+	getFileMock := new(GetFileUseCaseMock)
+	downloadURLMock := new(GetFileDownloadURLUseCaseMock)
+	controller := &FileRestController{
+		getFile:           getFileMock,
+		downloadURL:       downloadURLMock,
+		redirectDownloads: true,
+	}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	getFileMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123"}).
+		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(bytes.NewBufferString("data"))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content?redirect=false", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "data", resp.Body.String())
+	downloadURLMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestGetFileContentById_ShouldReturn500_WhenRedirectFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	downloadURLMock := new(GetFileDownloadURLUseCaseMock)
+	controller := &FileRestController{downloadURL: downloadURLMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/content", controller.GetFileContentById)
+
+	downloadURLMock.On("Execute", mock.Anything, mock.Anything).
+		Return(aggregate.PresignedDownload{}, errors.New("unauthorized")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/content?redirect=true", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
 func TestGetFileMetadataById_ShouldReturn200_WhenFileExists(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	content := bytes.NewBufferString("file content")
 	file, _ := domain.NewFile(
 		"123",
 		"owner-1",
 		nil,
 		"test.txt",
 		"text/plain",
-		int64(content.Len()),
+		12,
 		domain.VisibilityPublic,
 	)
 
-	useCaseMock := new(GetFileUseCaseMock)
+	useCaseMock := new(GetFileMetadataUseCaseMock)
 	controller := &FileRestController{
-		getFile: useCaseMock,
+		getMetadata: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/metadata", controller.GetFileMetadataById)
 
-	useCaseMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123"}).
-		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(content)}, nil).Once()
+	useCaseMock.On("Execute", mock.Anything, getfilemetadata.GetFileMetadataQuery{Id: "123"}).
+		Return(file, nil).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/files/123/metadata", nil)
 	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
@@ -356,6 +1000,7 @@ func TestGetFileMetadataById_ShouldReturn200_WhenFileExists(t *testing.T) {
 	assert.Equal(t, http.StatusOK, resp.Code)
 	assert.Contains(t, resp.Body.String(), file.ID())
 	assert.NotContains(t, resp.Body.String(), "storage_key")
+	assert.Equal(t, "\""+file.ID()+"-r0\"", resp.Header().Get("ETag"))
 	useCaseMock.AssertExpectations(t)
 }
 
@@ -363,7 +1008,7 @@ func TestGetFileMetadataById_ShouldReturn400_WhenIdMissing(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
 	controller := &FileRestController{}
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/metadata", controller.GetFileMetadataById)
 
 	req := httptest.NewRequest(http.MethodGet, "/files//metadata", nil)
@@ -377,16 +1022,16 @@ func TestGetFileMetadataById_ShouldReturn400_WhenIdMissing(t *testing.T) {
 func TestGetFileMetadataById_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	useCaseMock := new(GetFileUseCaseMock)
+	useCaseMock := new(GetFileMetadataUseCaseMock)
 	controller := &FileRestController{
-		getFile: useCaseMock,
+		getMetadata: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.GET("/files/:id/metadata", controller.GetFileMetadataById)
 
 	useCaseMock.On("Execute", mock.Anything, mock.Anything).
-		Return(&aggregate.FileContent{}, errors.New("use case error")).Once()
+		Return(domain.File{}, errors.New("use case error")).Once()
 
 	req := httptest.NewRequest(http.MethodGet, "/files/123/metadata", nil)
 	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
@@ -397,6 +1042,71 @@ func TestGetFileMetadataById_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
 	useCaseMock.AssertExpectations(t)
 }
 
+func TestHeadFileContent_ShouldReturnHeadersFromMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 5, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithChecksum(checksum))
+
+	useCaseMock := new(GetFileMetadataUseCaseMock)
+	controller := &FileRestController{getMetadata: useCaseMock}
+
+	router := newTestRouter()
+	router.HEAD("/files/:id/content", controller.HeadFileContent)
+
+	useCaseMock.On("Execute", mock.Anything, getfilemetadata.GetFileMetadataQuery{Id: "123", Content: true}).
+		Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodHead, "/files/123/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "5", resp.Header().Get("Content-Length"))
+	assert.Equal(t, "text/plain", resp.Header().Get("Content-Type"))
+	assert.Equal(t, "\""+checksum+"\"", resp.Header().Get("ETag"))
+	assert.Equal(t, "bytes", resp.Header().Get("Accept-Ranges"))
+	assert.Empty(t, resp.Body.String())
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestHeadFileContent_ShouldReturn401_WithoutToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := &FileRestController{}
+	router := newTestRouter()
+	router.HEAD("/files/:id/content", controller.HeadFileContent)
+
+	req := httptest.NewRequest(http.MethodHead, "/files/123/content", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+}
+
+func TestHeadFileContent_ShouldReturn500_WhenUseCaseFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileMetadataUseCaseMock)
+	controller := &FileRestController{getMetadata: useCaseMock}
+
+	router := newTestRouter()
+	router.HEAD("/files/:id/content", controller.HeadFileContent)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).
+		Return(domain.File{}, errors.New("use case error")).Once()
+
+	req := httptest.NewRequest(http.MethodHead, "/files/123/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	assert.Empty(t, resp.Body.String())
+}
+
 func TestDeleteFile_ShouldReturn204WhenSuccess(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
@@ -405,7 +1115,7 @@ func TestDeleteFile_ShouldReturn204WhenSuccess(t *testing.T) {
 		deleteFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.DELETE("/files/:id", controller.DeleteFile)
 
 	useCaseMock.On("Execute", mock.Anything, mock.Anything).
@@ -428,7 +1138,7 @@ func TestDeleteFile_ShouldReturn500WhenFail(t *testing.T) {
 		deleteFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.DELETE("/files/:id", controller.DeleteFile)
 
 	useCaseMock.On("Execute", mock.Anything, mock.Anything).
@@ -451,7 +1161,7 @@ func TestDeleteFile_ShouldReturn400WhenIdMissing(t *testing.T) {
 		deleteFile: useCaseMock,
 	}
 
-	router := gin.New()
+	router := newTestRouter()
 	router.DELETE("/files/:id/delete", controller.DeleteFile)
 
 	req := httptest.NewRequest(http.MethodDelete, "/files//delete", nil)
@@ -462,3 +1172,160 @@ func TestDeleteFile_ShouldReturn400WhenIdMissing(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, resp.Code)
 	useCaseMock.AssertExpectations(t)
 }
+
+func TestDeleteFile_ShouldRequestPermanentDeleteWhenQueryIsSet(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(DeleteFileUseCaseMock)
+	controller := &FileRestController{
+		deleteFile: useCaseMock,
+	}
+
+	router := newTestRouter()
+	router.DELETE("/files/:id", controller.DeleteFile)
+
+	useCaseMock.On("Execute", mock.Anything, deletefile.DeleteFileCommand{Id: "123", Permanent: true}).
+		Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/files/123?permanent=true", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUpdateFile_ShouldReturn200WhenSuccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UpdateFileMetadataUseCaseMock)
+	controller := &FileRestController{
+		updateFile: useCaseMock,
+	}
+
+	router := newTestRouter()
+	router.PATCH("/files/:id", controller.UpdateFile)
+
+	visibility := "PUBLIC"
+	file, _ := domain.NewFile("123", "1", nil, "a.txt", "text/plain", 1, domain.VisibilityPublic)
+	useCaseMock.On("Execute", mock.Anything, updatefilemetadata.UpdateFileMetadataCommand{Id: "123", Visibility: &visibility}).
+		Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/files/123", bytes.NewBufferString(`{"visibility":"PUBLIC"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"visibility":"PUBLIC"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUploadFile_ShouldPassTagsAndAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileUseCaseMock)
+	controller := &FileRestController{uploadFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files", controller.UploadFile)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("visibility", "PRIVATE")
+	_ = writer.WriteField("file_name", "avatar.png")
+	_ = writer.WriteField("tags", "avatar")
+	_ = writer.WriteField("tags", "profile")
+	_ = writer.WriteField("attributes", `{"kind":"avatar","alt_text":"A cat"}`)
+	part, _ := writer.CreateFormFile("file", "avatar.png")
+	_, _ = part.Write([]byte("file content"))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/files", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	file, _ := domain.NewFile("123", "1", nil, "avatar.png", "image/png", 12, domain.VisibilityPrivate)
+	_ = file.Annotate([]string{"avatar", "profile"}, map[string]string{"kind": "avatar"})
+	useCaseMock.On("Execute", mock.Anything, mock.MatchedBy(func(cmd uploadfile.UploadFileCommand) bool {
+		return len(cmd.Tags) == 2 && cmd.Attributes["alt_text"] == "A cat"
+	})).Return(file, nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"tags":["avatar","profile"]`)
+	assert.Contains(t, resp.Body.String(), `"attributes":{"kind":"avatar"}`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUpdateFile_ShouldPassTagsAndAttributes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UpdateFileMetadataUseCaseMock)
+	controller := &FileRestController{updateFile: useCaseMock}
+
+	router := newTestRouter()
+	router.PATCH("/files/:id", controller.UpdateFile)
+
+	file, _ := domain.NewFile("123", "1", nil, "a.txt", "text/plain", 1, domain.VisibilityPublic)
+	command := updatefilemetadata.UpdateFileMetadataCommand{Id: "123", Tags: []string{}, Attributes: map[string]string{"kind": "doc"}}
+	useCaseMock.On("Execute", mock.Anything, command).Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/files/123", bytes.NewBufferString(`{"tags":[],"attributes":{"kind":"doc"}}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUpdateFile_ShouldReturn400WhenBodyIsInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UpdateFileMetadataUseCaseMock)
+	controller := &FileRestController{
+		updateFile: useCaseMock,
+	}
+
+	router := newTestRouter()
+	router.PATCH("/files/:id", controller.UpdateFile)
+
+	req := httptest.NewRequest(http.MethodPatch, "/files/123", bytes.NewBufferString(`{"visibility":`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestUpdateFile_ShouldReturn500WhenFail(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UpdateFileMetadataUseCaseMock)
+	controller := &FileRestController{
+		updateFile: useCaseMock,
+	}
+
+	router := newTestRouter()
+	router.PATCH("/files/:id", controller.UpdateFile)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).
+		Return(domain.File{}, errors.New("unauthorized")).Once()
+
+	req := httptest.NewRequest(http.MethodPatch, "/files/123", bytes.NewBufferString(`{"file_name":"b.txt"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
@@ -0,0 +1,181 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	getfile "devconnectstorage/internal/application/usecase/get_file"
+	listfileversions "devconnectstorage/internal/application/usecase/list_file_versions"
+	restorefileversion "devconnectstorage/internal/application/usecase/restore_file_version"
+	uploadfileversion "devconnectstorage/internal/application/usecase/upload_file_version"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FileVersionRestController struct {
+	uploadVersion  uploadfileversion.IUploadFileVersionUseCase
+	listVersions   listfileversions.IListFileVersionsUseCase
+	restoreVersion restorefileversion.IRestoreFileVersionUseCase
+	getFile        getfile.IGetFileByIdUseCase
+}
+
+func NewFileVersionRestController(uploadVersionUseCase uploadfileversion.IUploadFileVersionUseCase, listVersionsUseCase listfileversions.IListFileVersionsUseCase, restoreVersionUseCase restorefileversion.IRestoreFileVersionUseCase, getFileUseCase getfile.IGetFileByIdUseCase) *FileVersionRestController {
+	return &FileVersionRestController{
+		uploadVersion:  uploadVersionUseCase,
+		listVersions:   listVersionsUseCase,
+		restoreVersion: restoreVersionUseCase,
+		getFile:        getFileUseCase,
+	}
+}
+
+func (controller *FileVersionRestController) UploadVersion(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var versionBody dto.UploadFileVersionRequest
+	if err := ctx.ShouldBind(&versionBody); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+	expectedChecksum, err := dto.ExpectedChecksum(ctx.Request.Header)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "file is required"))
+		return
+	}
+
+	if fileHeader.Size <= 0 {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "file size cannot be 0"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	defer func() { _ = file.Close() }()
+
+	command := versionBody.ToCommand(id, file, fileHeader.Size)
+	command.ExpectedChecksum = expectedChecksum
+
+	result, err := controller.uploadVersion.Execute(ctxWithToken, command)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(201, dto.NewFileMetadataResponse(result))
+}
+
+func (controller *FileVersionRestController) ListVersions(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	result, err := controller.listVersions.Execute(ctxWithToken, listfileversions.ListFileVersionsQuery{FileId: id})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.NewFileVersionsResponse(result))
+}
+
+func (controller *FileVersionRestController) GetVersionContent(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "version must be a positive number"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	result, err := controller.getFile.Execute(
+		ctxWithToken,
+		getfile.GetFileByIdQuery{
+			Id:            id,
+			Version:       version,
+			Ranges:        dto.ParseRange(ctx.GetHeader("Range")),
+			IfRange:       dto.ParseIfRange(ctx.GetHeader("If-Range")),
+			Preconditions: dto.ParsePreconditions(ctx.GetHeader("If-None-Match"), ctx.GetHeader("If-Modified-Since")),
+		},
+	)
+	if errors.Is(err, aggregate.ErrNotModified) {
+		writeNotModified(ctx, result.Metadata, result.Validators)
+		return
+	}
+	if errors.Is(err, aggregate.ErrRangeNotSatisfiable) {
+		writeRangeNotSatisfiable(ctx, result)
+		return
+	}
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	writeFileContent(ctx, result, cacheControl(result.Metadata))
+}
+
+func (controller *FileVersionRestController) RestoreVersion(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+	version, err := strconv.Atoi(ctx.Param("version"))
+	if err != nil || version < 1 {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "version must be a positive number"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	result, err := controller.restoreVersion.Execute(
+		ctxWithToken,
+		restorefileversion.RestoreFileVersionCommand{FileId: id, Version: version},
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(200, dto.NewFileMetadataResponse(result))
+}
@@ -0,0 +1,219 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/application/aggregate"
+	getfile "devconnectstorage/internal/application/usecase/get_file"
+	listfileversions "devconnectstorage/internal/application/usecase/list_file_versions"
+	restorefileversion "devconnectstorage/internal/application/usecase/restore_file_version"
+	uploadfileversion "devconnectstorage/internal/application/usecase/upload_file_version"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type UploadFileVersionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *UploadFileVersionUseCaseMock) Execute(ctx context.Context, command uploadfileversion.UploadFileVersionCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type ListFileVersionsUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ListFileVersionsUseCaseMock) Execute(ctx context.Context, query listfileversions.ListFileVersionsQuery) (domain.File, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type RestoreFileVersionUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RestoreFileVersionUseCaseMock) Execute(ctx context.Context, command restorefileversion.RestoreFileVersionCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func versionedTestFile() domain.File {
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "readme.md", "text/markdown", 2, "key/v1", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	_ = file.AddVersion("key/v2", "text/markdown", 3, "")
+	return file
+}
+
+func TestUploadVersion_ShouldReturn201_WhenRequestIsValid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileVersionUseCaseMock)
+	controller := &FileVersionRestController{uploadVersion: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/versions", controller.UploadVersion)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "readme.md")
+	_, _ = part.Write([]byte("new"))
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/versions", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+
+	useCaseMock.On("Execute", mock.Anything, mock.MatchedBy(func(command uploadfileversion.UploadFileVersionCommand) bool {
+		return command.FileId == "123" && command.Size == 3
+	})).Return(versionedTestFile(), nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"version":2`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestUploadVersion_ShouldReturn400_WhenFileIsMissing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(UploadFileVersionUseCaseMock)
+	controller := &FileVersionRestController{uploadVersion: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/versions", controller.UploadVersion)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	_ = writer.WriteField("mime_type", "text/plain")
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/versions", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute")
+}
+
+func TestListVersions_ShouldReturn200WithHistory(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListFileVersionsUseCaseMock)
+	controller := &FileVersionRestController{listVersions: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/versions", controller.ListVersions)
+
+	useCaseMock.On("Execute", mock.Anything, listfileversions.ListFileVersionsQuery{FileId: "123"}).
+		Return(versionedTestFile(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/versions", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"current_version":2`)
+	assert.NotContains(t, resp.Body.String(), "storage_key")
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestListVersions_ShouldReturn500WhenUseCaseFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListFileVersionsUseCaseMock)
+	controller := &FileVersionRestController{listVersions: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/versions", controller.ListVersions)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).Return(domain.File{}, errors.New("error")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/versions", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestGetVersionContent_ShouldReturnRequestedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileVersionRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/versions/:version/content", controller.GetVersionContent)
+
+	file, _ := versionedTestFile().AtVersion(1)
+	useCaseMock.On("Execute", mock.Anything, getfile.GetFileByIdQuery{Id: "123", Version: 1}).
+		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(bytes.NewBufferString("v1"))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/versions/1/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "v1", resp.Body.String())
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestGetVersionContent_ShouldReturn400WhenVersionIsInvalid(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetFileUseCaseMock)
+	controller := &FileVersionRestController{getFile: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/versions/:version/content", controller.GetVersionContent)
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/versions/abc/content", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute")
+}
+
+func TestRestoreVersion_ShouldReturn200WhenRestored(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RestoreFileVersionUseCaseMock)
+	controller := &FileVersionRestController{restoreVersion: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/versions/:version/restore", controller.RestoreVersion)
+
+	file := versionedTestFile()
+	_ = file.RestoreVersion(1)
+	useCaseMock.On("Execute", mock.Anything, restorefileversion.RestoreFileVersionCommand{FileId: "123", Version: 1}).
+		Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/versions/1/restore", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"version":1`)
+	useCaseMock.AssertExpectations(t)
+}
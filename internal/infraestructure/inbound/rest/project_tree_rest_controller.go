@@ -0,0 +1,111 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	getprojecttree "devconnectstorage/internal/application/usecase/get_project_tree"
+	movefolder "devconnectstorage/internal/application/usecase/move_folder"
+	resolveprojectpath "devconnectstorage/internal/application/usecase/resolve_project_path"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProjectTreeRestController struct {
+	getProjectTree     getprojecttree.IGetProjectTreeUseCase
+	resolveProjectPath resolveprojectpath.IResolveProjectPathUseCase
+	moveFolder         movefolder.IMoveFolderUseCase
+}
+
+func NewProjectTreeRestController(
+	getProjectTreeUseCase getprojecttree.IGetProjectTreeUseCase,
+	resolveProjectPathUseCase resolveprojectpath.IResolveProjectPathUseCase,
+	moveFolderUseCase movefolder.IMoveFolderUseCase,
+) *ProjectTreeRestController {
+	return &ProjectTreeRestController{
+		getProjectTree:     getProjectTreeUseCase,
+		resolveProjectPath: resolveProjectPathUseCase,
+		moveFolder:         moveFolderUseCase,
+	}
+}
+
+func (controller *ProjectTreeRestController) GetTree(ctx *gin.Context) {
+	projectID := ctx.Param("projectId")
+	if projectID == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "projectId cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var request dto.ProjectTreeRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid query parameters"))
+		return
+	}
+
+	folder, err := controller.getProjectTree.Execute(ctxWithToken, request.ToQuery(projectID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewProjectTreeResponse(folder))
+}
+
+// ResolvePath redirects to the content of the file at a project path, so
+// relative links between project files work like links between static files.
+func (controller *ProjectTreeRestController) ResolvePath(ctx *gin.Context) {
+	projectID := ctx.Param("projectId")
+	if projectID == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "projectId cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file, err := controller.resolveProjectPath.Execute(ctxWithToken, resolveprojectpath.ResolveProjectPathQuery{
+		ProjectID: projectID,
+		Path:      ctx.Param("path"),
+		OwnerID:   ctx.Query("owner_id"),
+	})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.Redirect(302, "/files/"+url.PathEscape(file.ID())+"/content")
+}
+
+func (controller *ProjectTreeRestController) MoveFolder(ctx *gin.Context) {
+	projectID := ctx.Param("projectId")
+	if projectID == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "projectId cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.MoveFolderRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	moved, err := controller.moveFolder.Execute(ctxWithToken, body.ToCommand(projectID))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.MoveFolderResponse{Moved: moved})
+}
@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	getprojecttree "devconnectstorage/internal/application/usecase/get_project_tree"
+	movefolder "devconnectstorage/internal/application/usecase/move_folder"
+	resolveprojectpath "devconnectstorage/internal/application/usecase/resolve_project_path"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type GetProjectTreeUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetProjectTreeUseCaseMock) Execute(ctx context.Context, query getprojecttree.GetProjectTreeQuery) (aggregate.Folder, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(aggregate.Folder), args.Error(1)
+}
+
+type ResolveProjectPathUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ResolveProjectPathUseCaseMock) Execute(ctx context.Context, query resolveprojectpath.ResolveProjectPathQuery) (domain.File, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type MoveFolderUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *MoveFolderUseCaseMock) Execute(ctx context.Context, command movefolder.MoveFolderCommand) (int64, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func newProjectTreeRouter(tree *GetProjectTreeUseCaseMock, resolve *ResolveProjectPathUseCaseMock, move *MoveFolderUseCaseMock) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	controller := NewProjectTreeRestController(tree, resolve, move)
+
+	router := newTestRouter()
+	router.GET("/projects/:projectId/tree", controller.GetTree)
+	router.POST("/projects/:projectId/tree/move", controller.MoveFolder)
+	router.GET("/projects/:projectId/files/*path", controller.ResolvePath)
+	return router
+}
+
+func TestGetTree_ShouldReturn200WithFoldersAndFiles(t *testing.T) {
+	tree := new(GetProjectTreeUseCaseMock)
+	router := newProjectTreeRouter(tree, new(ResolveProjectPathUseCaseMock), new(MoveFolderUseCaseMock))
+
+	projectID := "project-1"
+	file, _ := domain.RehydrateFile("123", "owner-1", &projectID, "README.md", "text/markdown", 5, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now(), domain.WithPath("docs/README.md"))
+	tree.On("Execute", mock.Anything, getprojecttree.GetProjectTreeQuery{ProjectID: projectID, Prefix: "docs"}).
+		Return(aggregate.Folder{Prefix: "docs", Folders: []string{"img"}, Files: []domain.File{file}}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/project-1/tree?prefix=docs", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"folders":["img"]`)
+	assert.Contains(t, resp.Body.String(), `"path":"docs/README.md"`)
+	tree.AssertExpectations(t)
+}
+
+func TestGetTree_ShouldPassPagingAndReturnNextCursor(t *testing.T) {
+	tree := new(GetProjectTreeUseCaseMock)
+	router := newProjectTreeRouter(tree, new(ResolveProjectPathUseCaseMock), new(MoveFolderUseCaseMock))
+
+	tree.On("Execute", mock.Anything, getprojecttree.GetProjectTreeQuery{ProjectID: "project-1", Cursor: "img", Limit: 2}).
+		Return(aggregate.Folder{Folders: []string{"src"}, NextCursor: "src"}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/project-1/tree?cursor=img&limit=2", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"next_cursor":"src"`)
+	tree.AssertExpectations(t)
+}
+
+func TestResolvePath_ShouldRedirectToContent(t *testing.T) {
+	resolve := new(ResolveProjectPathUseCaseMock)
+	router := newProjectTreeRouter(new(GetProjectTreeUseCaseMock), resolve, new(MoveFolderUseCaseMock))
+
+	projectID := "project-1"
+	file, _ := domain.RehydrateFile("123", "owner-1", &projectID, "arch.png", "image/png", 5, "key", domain.VisibilityProject, domain.StatusAvailable, time.Now(), domain.WithPath("docs/img/arch.png"))
+	resolve.On("Execute", mock.Anything, resolveprojectpath.ResolveProjectPathQuery{ProjectID: projectID, Path: "/docs/img/arch.png", OwnerID: "owner-1"}).Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/project-1/files/docs/img/arch.png?owner_id=owner-1", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusFound, resp.Code)
+	assert.Equal(t, "/files/123/content", resp.Header().Get("Location"))
+	resolve.AssertExpectations(t)
+}
+
+func TestResolvePath_ShouldReturn409WhenAmbiguous(t *testing.T) {
+	resolve := new(ResolveProjectPathUseCaseMock)
+	router := newProjectTreeRouter(new(GetProjectTreeUseCaseMock), resolve, new(MoveFolderUseCaseMock))
+
+	resolve.On("Execute", mock.Anything, mock.Anything).Return(domain.File{}, apperror.New(apperror.ErrConflict, "pass owner_id")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/projects/project-1/files/README.md", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestMoveFolder_ShouldReturn200WithMovedCount(t *testing.T) {
+	move := new(MoveFolderUseCaseMock)
+	router := newProjectTreeRouter(new(GetProjectTreeUseCaseMock), new(ResolveProjectPathUseCaseMock), move)
+
+	move.On("Execute", mock.Anything, movefolder.MoveFolderCommand{ProjectID: "project-1", From: "docs", To: "guides"}).Return(int64(4), nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/projects/project-1/tree/move", strings.NewReader(`{"from":"docs","to":"guides"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"moved":4}`, resp.Body.String())
+	move.AssertExpectations(t)
+}
+
+func TestMoveFolder_ShouldReturn400WithoutTarget(t *testing.T) {
+	move := new(MoveFolderUseCaseMock)
+	router := newProjectTreeRouter(new(GetProjectTreeUseCaseMock), new(ResolveProjectPathUseCaseMock), move)
+
+	req := httptest.NewRequest(http.MethodPost, "/projects/project-1/tree/move", strings.NewReader(`{"from":"docs"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	move.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
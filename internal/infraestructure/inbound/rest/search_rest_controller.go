@@ -0,0 +1,39 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	searchfiles "devconnectstorage/internal/application/usecase/search_files"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SearchRestController struct {
+	searchFiles searchfiles.ISearchFilesUseCase
+}
+
+func NewSearchRestController(searchFilesUseCase searchfiles.ISearchFilesUseCase) *SearchRestController {
+	return &SearchRestController{
+		searchFiles: searchFilesUseCase,
+	}
+}
+
+func (controller *SearchRestController) SearchFiles(ctx *gin.Context) {
+	var request dto.SearchFilesRequest
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "q is required"))
+		return
+	}
+
+	files, err := controller.searchFiles.Execute(ctxWithToken, request.ToQuery())
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponses(files))
+}
@@ -0,0 +1,65 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	searchfiles "devconnectstorage/internal/application/usecase/search_files"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type SearchFilesUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *SearchFilesUseCaseMock) Execute(ctx context.Context, query searchfiles.SearchFilesQuery) ([]domain.File, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func TestSearchFiles_ShouldReturn200WithRankedFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(SearchFilesUseCaseMock)
+	controller := NewSearchRestController(useCaseMock)
+
+	router := newTestRouter()
+	router.GET("/files/search", controller.SearchFiles)
+
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "arch.png", "image/png", 5, "key", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, searchfiles.SearchFilesQuery{Text: "architecture diagram", Limit: 5}).Return([]domain.File{file}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/search?q=architecture+diagram&limit=5", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"file_name":"arch.png"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestSearchFiles_ShouldReturn400WithoutText(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(SearchFilesUseCaseMock)
+	controller := NewSearchRestController(useCaseMock)
+
+	router := newTestRouter()
+	router.GET("/files/search", controller.SearchFiles)
+
+	req := httptest.NewRequest(http.MethodGet, "/files/search", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,126 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	createsharelink "devconnectstorage/internal/application/usecase/create_share_link"
+	downloadsharedfile "devconnectstorage/internal/application/usecase/download_shared_file"
+	listsharelinks "devconnectstorage/internal/application/usecase/list_share_links"
+	revokesharelink "devconnectstorage/internal/application/usecase/revoke_share_link"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareLinkRestController struct {
+	createLink     createsharelink.ICreateShareLinkUseCase
+	listLinks      listsharelinks.IListShareLinksUseCase
+	revokeLink     revokesharelink.IRevokeShareLinkUseCase
+	downloadShared downloadsharedfile.IDownloadSharedFileUseCase
+}
+
+func NewShareLinkRestController(createLinkUseCase createsharelink.ICreateShareLinkUseCase, listLinksUseCase listsharelinks.IListShareLinksUseCase, revokeLinkUseCase revokesharelink.IRevokeShareLinkUseCase, downloadSharedUseCase downloadsharedfile.IDownloadSharedFileUseCase) *ShareLinkRestController {
+	return &ShareLinkRestController{
+		createLink:     createLinkUseCase,
+		listLinks:      listLinksUseCase,
+		revokeLink:     revokeLinkUseCase,
+		downloadShared: downloadSharedUseCase,
+	}
+}
+
+func (controller *ShareLinkRestController) CreateLink(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.CreateShareLinkRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	result, err := controller.createLink.Execute(ctxWithToken, body.ToCommand(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(201, dto.NewShareLinkResponse(result))
+}
+
+func (controller *ShareLinkRestController) ListLinks(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	links, err := controller.listLinks.Execute(ctxWithToken, listsharelinks.ListShareLinksQuery{FileId: id})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewShareLinkResponses(links))
+}
+
+func (controller *ShareLinkRestController) RevokeLink(ctx *gin.Context) {
+	id := ctx.Param("id")
+	linkId := ctx.Param("linkId")
+	if id == "" || linkId == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	command := revokesharelink.RevokeShareLinkCommand{FileId: id, LinkId: linkId}
+	if err := controller.revokeLink.Execute(ctxWithToken, command); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(204, gin.H{})
+}
+
+// DownloadSharedFile takes a link's password from the X-Share-Password header
+// or, for POSTed forms, the password field. It is never read from the query
+// string, where it would end up in logs, history and Referer headers.
+func (controller *ShareLinkRestController) DownloadSharedFile(ctx *gin.Context) {
+	token := ctx.Param("token")
+	if token == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "token cannot be empty"))
+		return
+	}
+
+	password := ctx.GetHeader("X-Share-Password")
+	if password == "" && ctx.Request.Method == http.MethodPost {
+		password = ctx.PostForm("password")
+	}
+
+	result, err := controller.downloadShared.Execute(
+		ctx.Request.Context(),
+		downloadsharedfile.DownloadSharedFileQuery{Token: token, Password: password},
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	writeFileContent(ctx, result, "private, no-store")
+}
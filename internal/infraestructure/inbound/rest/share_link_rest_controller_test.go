@@ -0,0 +1,232 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
+	createsharelink "devconnectstorage/internal/application/usecase/create_share_link"
+	downloadsharedfile "devconnectstorage/internal/application/usecase/download_shared_file"
+	listsharelinks "devconnectstorage/internal/application/usecase/list_share_links"
+	revokesharelink "devconnectstorage/internal/application/usecase/revoke_share_link"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateShareLinkUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *CreateShareLinkUseCaseMock) Execute(ctx context.Context, command createsharelink.CreateShareLinkCommand) (aggregate.SignedShareLink, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(aggregate.SignedShareLink), args.Error(1)
+}
+
+type ListShareLinksUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ListShareLinksUseCaseMock) Execute(ctx context.Context, query listsharelinks.ListShareLinksQuery) ([]aggregate.SignedShareLink, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).([]aggregate.SignedShareLink), args.Error(1)
+}
+
+type RevokeShareLinkUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RevokeShareLinkUseCaseMock) Execute(ctx context.Context, command revokesharelink.RevokeShareLinkCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+type DownloadSharedFileUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *DownloadSharedFileUseCaseMock) Execute(ctx context.Context, query downloadsharedfile.DownloadSharedFileQuery) (*aggregate.FileContent, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*aggregate.FileContent), args.Error(1)
+}
+
+func signedLink() aggregate.SignedShareLink {
+	link, _ := domain.NewShareLink("link-1", "123", "1", time.Now().Add(time.Hour), 3, "")
+	return aggregate.SignedShareLink{Link: link, Token: "signed-token"}
+}
+
+func TestCreateShareLink_ShouldReturn201WithToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CreateShareLinkUseCaseMock)
+	controller := &ShareLinkRestController{createLink: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/links", controller.CreateLink)
+
+	useCaseMock.On("Execute", mock.Anything, createsharelink.CreateShareLinkCommand{FileId: "123", ExpiresIn: time.Hour, MaxDownloads: 3}).
+		Return(signedLink(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/links", bytes.NewBufferString(`{"expires_in_seconds":3600,"max_downloads":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"path":"/s/signed-token"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestCreateShareLink_ShouldReturn400WithoutExpiration(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CreateShareLinkUseCaseMock)
+	controller := &ShareLinkRestController{createLink: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/links", controller.CreateLink)
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/links", bytes.NewBufferString(`{"max_downloads":3}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestListShareLinks_ShouldReturn200(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListShareLinksUseCaseMock)
+	controller := &ShareLinkRestController{listLinks: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/:id/links", controller.ListLinks)
+
+	useCaseMock.On("Execute", mock.Anything, listsharelinks.ListShareLinksQuery{FileId: "123"}).
+		Return([]aggregate.SignedShareLink{signedLink()}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/123/links", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":"link-1"`)
+}
+
+func TestRevokeShareLink_ShouldReturn204(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RevokeShareLinkUseCaseMock)
+	controller := &ShareLinkRestController{revokeLink: useCaseMock}
+
+	router := newTestRouter()
+	router.DELETE("/files/:id/links/:linkId", controller.RevokeLink)
+
+	useCaseMock.On("Execute", mock.Anything, revokesharelink.RevokeShareLinkCommand{FileId: "123", LinkId: "link-1"}).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/files/123/links/link-1", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestDownloadSharedFile_ShouldStreamWithoutCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(DownloadSharedFileUseCaseMock)
+	controller := &ShareLinkRestController{downloadShared: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/s/:token", controller.DownloadSharedFile)
+
+	file, _ := domain.RehydrateFile("123", "1", nil, "a.txt", "text/plain", 7, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, downloadsharedfile.DownloadSharedFileQuery{Token: "signed-token", Password: "s3cret"}).
+		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(bytes.NewReader([]byte("content")))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/s/signed-token", nil)
+	req.Header.Set("X-Share-Password", "s3cret")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "content", resp.Body.String())
+	assert.Contains(t, resp.Header().Get("Content-Disposition"), "a.txt")
+}
+
+func TestDownloadSharedFile_ShouldTakePasswordFromPostedForm(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(DownloadSharedFileUseCaseMock)
+	controller := &ShareLinkRestController{downloadShared: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/s/:token", controller.DownloadSharedFile)
+
+	file, _ := domain.RehydrateFile("123", "1", nil, "a.txt", "text/plain", 7, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, downloadsharedfile.DownloadSharedFileQuery{Token: "signed-token", Password: "s3cret"}).
+		Return(&aggregate.FileContent{Metadata: file, Content: io.NopCloser(bytes.NewReader([]byte("content")))}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/s/signed-token", strings.NewReader("password=s3cret"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestDownloadSharedFile_ShouldIgnorePasswordInQuery(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(DownloadSharedFileUseCaseMock)
+	controller := &ShareLinkRestController{downloadShared: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/s/:token", controller.DownloadSharedFile)
+
+	useCaseMock.On("Execute", mock.Anything, downloadsharedfile.DownloadSharedFileQuery{Token: "signed-token"}).
+		Return(&aggregate.FileContent{}, apperror.New(apperror.ErrForbidden, "password required")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/s/signed-token?password=s3cret", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestDownloadSharedFile_ShouldReturn500WhenUseCaseFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(DownloadSharedFileUseCaseMock)
+	controller := &ShareLinkRestController{downloadShared: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/s/:token", controller.DownloadSharedFile)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).Return(&aggregate.FileContent{}, errors.New("share link expired")).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/s/signed-token", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
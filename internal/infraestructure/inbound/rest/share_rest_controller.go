@@ -0,0 +1,93 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	listsharedwithme "devconnectstorage/internal/application/usecase/list_shared_with_me"
+	revokefileshare "devconnectstorage/internal/application/usecase/revoke_file_share"
+	sharefile "devconnectstorage/internal/application/usecase/share_file"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ShareRestController struct {
+	shareFile        sharefile.IShareFileUseCase
+	revokeShare      revokefileshare.IRevokeFileShareUseCase
+	listSharedWithMe listsharedwithme.IListSharedWithMeUseCase
+}
+
+func NewShareRestController(shareFileUseCase sharefile.IShareFileUseCase, revokeShareUseCase revokefileshare.IRevokeFileShareUseCase, listSharedWithMeUseCase listsharedwithme.IListSharedWithMeUseCase) *ShareRestController {
+	return &ShareRestController{
+		shareFile:        shareFileUseCase,
+		revokeShare:      revokeShareUseCase,
+		listSharedWithMe: listSharedWithMeUseCase,
+	}
+}
+
+func (controller *ShareRestController) ShareFile(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	var body dto.ShareFileRequest
+	if err := ctx.ShouldBindJSON(&body); err != nil {
+		_ = ctx.Error(apperror.Wrap(apperror.ErrValidation, err, "invalid request body"))
+		return
+	}
+
+	file, err := controller.shareFile.Execute(ctxWithToken, body.ToCommand(id))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileSharesResponse(file))
+}
+
+func (controller *ShareRestController) RevokeShare(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+	profileID := ctx.Param("profileId")
+	if profileID == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "profile id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	command := revokefileshare.RevokeFileShareCommand{FileId: id, ProfileID: profileID}
+	if err := controller.revokeShare.Execute(ctxWithToken, command); err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(204, gin.H{})
+}
+
+func (controller *ShareRestController) ListSharedWithMe(ctx *gin.Context) {
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	files, err := controller.listSharedWithMe.Execute(ctxWithToken)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponses(files))
+}
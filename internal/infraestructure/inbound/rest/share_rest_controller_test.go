@@ -0,0 +1,172 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	revokefileshare "devconnectstorage/internal/application/usecase/revoke_file_share"
+	sharefile "devconnectstorage/internal/application/usecase/share_file"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ShareFileUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ShareFileUseCaseMock) Execute(ctx context.Context, command sharefile.ShareFileCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+type RevokeFileShareUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RevokeFileShareUseCaseMock) Execute(ctx context.Context, command revokefileshare.RevokeFileShareCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+type ListSharedWithMeUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ListSharedWithMeUseCaseMock) Execute(ctx context.Context) ([]domain.File, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+func sharedFile() domain.File {
+	file, _ := domain.RehydrateFile("123", "1", nil, "a.txt", "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	_ = file.ShareWith("1", "2", domain.PermissionRead)
+	return file
+}
+
+func TestShareFile_ShouldReturn200WithShares(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ShareFileUseCaseMock)
+	controller := &ShareRestController{shareFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/shares", controller.ShareFile)
+
+	useCaseMock.On("Execute", mock.Anything, sharefile.ShareFileCommand{FileId: "123", ProfileID: "2", Permission: "READ"}).
+		Return(sharedFile(), nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/shares", bytes.NewBufferString(`{"profile_id":"2","permission":"READ"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"profile_id":"2"`)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestShareFile_ShouldReturn400WhenProfileMissing(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ShareFileUseCaseMock)
+	controller := &ShareRestController{shareFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/shares", controller.ShareFile)
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/shares", bytes.NewBufferString(`{"permission":"READ"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestShareFile_ShouldReturn500WhenUseCaseFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ShareFileUseCaseMock)
+	controller := &ShareRestController{shareFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/shares", controller.ShareFile)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).Return(domain.File{}, errors.New("unauthorized")).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/shares", bytes.NewBufferString(`{"profile_id":"2","permission":"READ"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+}
+
+func TestRevokeShare_ShouldReturn204WhenRevoked(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RevokeFileShareUseCaseMock)
+	controller := &ShareRestController{revokeShare: useCaseMock}
+
+	router := newTestRouter()
+	router.DELETE("/files/:id/shares/:profileId", controller.RevokeShare)
+
+	useCaseMock.On("Execute", mock.Anything, revokefileshare.RevokeFileShareCommand{FileId: "123", ProfileID: "2"}).Return(nil).Once()
+
+	req := httptest.NewRequest(http.MethodDelete, "/files/123/shares/2", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestRevokeShare_ShouldReturn401WithoutCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RevokeFileShareUseCaseMock)
+	controller := &ShareRestController{revokeShare: useCaseMock}
+
+	router := newTestRouter()
+	router.DELETE("/files/:id/shares/:profileId", controller.RevokeShare)
+
+	req := httptest.NewRequest(http.MethodDelete, "/files/123/shares/2", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestListSharedWithMe_ShouldReturn200WithFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListSharedWithMeUseCaseMock)
+	controller := &ShareRestController{listSharedWithMe: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/shared-with-me", controller.ListSharedWithMe)
+
+	useCaseMock.On("Execute", mock.Anything).Return([]domain.File{sharedFile()}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/shared-with-me", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"id":"123"`)
+	useCaseMock.AssertExpectations(t)
+}
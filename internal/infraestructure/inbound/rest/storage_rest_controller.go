@@ -0,0 +1,33 @@
+package rest
+
+import (
+	getstorageusage "devconnectstorage/internal/application/usecase/get_storage_usage"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StorageRestController struct {
+	getStorageUsage getstorageusage.IGetStorageUsageUseCase
+}
+
+func NewStorageRestController(getStorageUsageUseCase getstorageusage.IGetStorageUsageUseCase) *StorageRestController {
+	return &StorageRestController{
+		getStorageUsage: getStorageUsageUseCase,
+	}
+}
+
+func (controller *StorageRestController) GetMyStorage(ctx *gin.Context) {
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	report, err := controller.getStorageUsage.Execute(ctxWithToken)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewStorageUsageResponse(report))
+}
@@ -0,0 +1,84 @@
+package rest
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"devconnectstorage/internal/application/aggregate"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type GetStorageUsageUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetStorageUsageUseCaseMock) Execute(ctx context.Context) (aggregate.StorageReport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(aggregate.StorageReport), args.Error(1)
+}
+
+func TestGetMyStorage_ShouldReturn200WithUsageAndRemainingQuota(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetStorageUsageUseCaseMock)
+	controller := NewStorageRestController(useCaseMock)
+
+	router := newTestRouter()
+	router.GET("/me/storage", controller.GetMyStorage)
+
+	usage, _ := domain.RehydrateStorageUsage("12", 40, 2)
+	useCaseMock.On("Execute", mock.Anything).Return(aggregate.StorageReport{Usage: usage, Limit: 100}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/me/storage", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"used_bytes":40,"file_count":2,"quota_bytes":100,"remaining_bytes":60}`, resp.Body.String())
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestGetMyStorage_ShouldOmitQuotaWhenUnlimited(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetStorageUsageUseCaseMock)
+	controller := NewStorageRestController(useCaseMock)
+
+	router := newTestRouter()
+	router.GET("/me/storage", controller.GetMyStorage)
+
+	usage, _ := domain.RehydrateStorageUsage("12", 40, 2)
+	useCaseMock.On("Execute", mock.Anything).Return(aggregate.StorageReport{Usage: usage}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/me/storage", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.JSONEq(t, `{"used_bytes":40,"file_count":2}`, resp.Body.String())
+}
+
+func TestGetMyStorage_ShouldReturn401WithoutCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetStorageUsageUseCaseMock)
+	controller := NewStorageRestController(useCaseMock)
+
+	router := newTestRouter()
+	router.GET("/me/storage", controller.GetMyStorage)
+
+	req := httptest.NewRequest(http.MethodGet, "/me/storage", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything)
+}
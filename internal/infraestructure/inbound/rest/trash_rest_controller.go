@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	listtrash "devconnectstorage/internal/application/usecase/list_trash"
+	restorefile "devconnectstorage/internal/application/usecase/restore_file"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashRestController struct {
+	listTrash   listtrash.IListTrashUseCase
+	restoreFile restorefile.IRestoreFileUseCase
+}
+
+func NewTrashRestController(listTrashUseCase listtrash.IListTrashUseCase, restoreFileUseCase restorefile.IRestoreFileUseCase) *TrashRestController {
+	return &TrashRestController{
+		listTrash:   listTrashUseCase,
+		restoreFile: restoreFileUseCase,
+	}
+}
+
+func (controller *TrashRestController) ListTrash(ctx *gin.Context) {
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	files, err := controller.listTrash.Execute(ctxWithToken)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponses(files))
+}
+
+func (controller *TrashRestController) RestoreFile(ctx *gin.Context) {
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	file, err := controller.restoreFile.Execute(ctxWithToken, restorefile.RestoreFileCommand{Id: id})
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+	ctx.JSON(200, dto.NewFileMetadataResponse(file))
+}
@@ -0,0 +1,116 @@
+package rest
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	restorefile "devconnectstorage/internal/application/usecase/restore_file"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type ListTrashUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *ListTrashUseCaseMock) Execute(ctx context.Context) ([]domain.File, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]domain.File), args.Error(1)
+}
+
+type RestoreFileUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RestoreFileUseCaseMock) Execute(ctx context.Context, command restorefile.RestoreFileCommand) (domain.File, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.File), args.Error(1)
+}
+
+func TestListTrash_ShouldReturn200WithDeletedFiles(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListTrashUseCaseMock)
+	controller := &TrashRestController{listTrash: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/trash", controller.ListTrash)
+
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 12, "key", domain.VisibilityPrivate, domain.StatusDeleted, time.Now(), domain.WithDeletedAt(time.Now()))
+	useCaseMock.On("Execute", mock.Anything).Return([]domain.File{file}, nil).Once()
+
+	req := httptest.NewRequest(http.MethodGet, "/files/trash", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "deleted_at")
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestListTrash_ShouldReturn401WithoutCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(ListTrashUseCaseMock)
+	controller := &TrashRestController{listTrash: useCaseMock}
+
+	router := newTestRouter()
+	router.GET("/files/trash", controller.ListTrash)
+
+	req := httptest.NewRequest(http.MethodGet, "/files/trash", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnauthorized, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything)
+}
+
+func TestRestoreFile_ShouldReturn200WhenRestored(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RestoreFileUseCaseMock)
+	controller := &TrashRestController{restoreFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/restore", controller.RestoreFile)
+
+	file, _ := domain.RehydrateFile("123", "owner-1", nil, "test.txt", "text/plain", 12, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	useCaseMock.On("Execute", mock.Anything, restorefile.RestoreFileCommand{Id: "123"}).Return(file, nil).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/restore", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), "AVAILABLE")
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestRestoreFile_ShouldReturn500WhenUseCaseFails(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(RestoreFileUseCaseMock)
+	controller := &TrashRestController{restoreFile: useCaseMock}
+
+	router := newTestRouter()
+	router.POST("/files/:id/restore", controller.RestoreFile)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).Return(domain.File{}, errors.New("restore error")).Once()
+
+	req := httptest.NewRequest(http.MethodPost, "/files/123/restore", nil)
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusInternalServerError, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
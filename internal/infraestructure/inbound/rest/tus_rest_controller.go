@@ -0,0 +1,189 @@
+package rest
+
+import (
+	"devconnectstorage/internal/apperror"
+	appendresumableupload "devconnectstorage/internal/application/usecase/append_resumable_upload"
+	createresumableupload "devconnectstorage/internal/application/usecase/create_resumable_upload"
+	getresumableupload "devconnectstorage/internal/application/usecase/get_resumable_upload"
+	terminateresumableupload "devconnectstorage/internal/application/usecase/terminate_resumable_upload"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/inbound/rest/dto"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const tusVersion = "1.0.0"
+
+type TusRestController struct {
+	createUpload    createresumableupload.ICreateResumableUploadUseCase
+	getUpload       getresumableupload.IGetResumableUploadUseCase
+	appendUpload    appendresumableupload.IAppendResumableUploadUseCase
+	terminateUpload terminateresumableupload.ITerminateResumableUploadUseCase
+	basePath        string
+}
+
+func NewTusRestController(createUploadUseCase createresumableupload.ICreateResumableUploadUseCase, getUploadUseCase getresumableupload.IGetResumableUploadUseCase, appendUploadUseCase appendresumableupload.IAppendResumableUploadUseCase, terminateUploadUseCase terminateresumableupload.ITerminateResumableUploadUseCase, basePath string) *TusRestController {
+	return &TusRestController{
+		createUpload:    createUploadUseCase,
+		getUpload:       getUploadUseCase,
+		appendUpload:    appendUploadUseCase,
+		terminateUpload: terminateUploadUseCase,
+		basePath:        basePath,
+	}
+}
+
+func (controller *TusRestController) Options(ctx *gin.Context) {
+	ctx.Header("Tus-Resumable", tusVersion)
+	ctx.Header("Tus-Version", tusVersion)
+	ctx.Header("Tus-Extension", "creation,expiration,termination")
+	ctx.Status(204)
+}
+
+func (controller *TusRestController) CreateUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	body, err := dto.NewTusCreateUploadRequest(ctx.GetHeader("Upload-Length"), ctx.GetHeader("Upload-Metadata"))
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	session, err := controller.createUpload.Execute(ctxWithToken, body.ToCommand())
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+
+	ctx.Header("Location", controller.basePath+"/"+session.ID())
+	ctx.Header("Upload-Expires", session.ExpiresAt().UTC().Format(http.TimeFormat))
+	ctx.Status(201)
+}
+
+func (controller *TusRestController) GetOffset(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	session, err := controller.getUpload.Execute(ctxWithToken, getresumableupload.GetResumableUploadQuery{Id: id})
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+
+	ctx.Header("Cache-Control", "no-store")
+	ctx.Header("Upload-Offset", strconv.FormatInt(session.Offset(), 10))
+	ctx.Header("Upload-Length", strconv.FormatInt(session.Length(), 10))
+	ctx.Header("Upload-Expires", session.ExpiresAt().UTC().Format(http.TimeFormat))
+	ctx.Status(200)
+}
+
+func (controller *TusRestController) AppendChunk(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	if ctx.ContentType() != "application/offset+octet-stream" {
+		writeProblem(ctx, 415, "content type must be application/offset+octet-stream")
+		return
+	}
+
+	offset, err := strconv.ParseInt(ctx.GetHeader("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "invalid Upload-Offset header"))
+		return
+	}
+
+	session, err := controller.appendUpload.Execute(ctxWithToken, appendresumableupload.AppendResumableUploadCommand{
+		Id:      id,
+		Offset:  offset,
+		Content: ctx.Request.Body,
+	})
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+
+	ctx.Header("Upload-Offset", strconv.FormatInt(session.Offset(), 10))
+	ctx.Header("Upload-Expires", session.ExpiresAt().UTC().Format(http.TimeFormat))
+	ctx.Status(204)
+}
+
+func (controller *TusRestController) TerminateUpload(ctx *gin.Context) {
+	if !checkTusResumable(ctx) {
+		return
+	}
+
+	id := ctx.Param("id")
+	if id == "" {
+		_ = ctx.Error(apperror.New(apperror.ErrValidation, "id cannot be empty"))
+		return
+	}
+
+	ctxWithToken, err := authenticatedContext(ctx)
+	if err != nil {
+		_ = ctx.Error(err)
+		return
+	}
+
+	err = controller.terminateUpload.Execute(ctxWithToken, terminateresumableupload.TerminateResumableUploadCommand{Id: id})
+	if err != nil {
+		writeTusError(ctx, err)
+		return
+	}
+	ctx.Status(204)
+}
+
+func checkTusResumable(ctx *gin.Context) bool {
+	ctx.Header("Tus-Resumable", tusVersion)
+	if ctx.GetHeader("Tus-Resumable") != tusVersion {
+		ctx.Header("Tus-Version", tusVersion)
+		writeProblem(ctx, 412, "unsupported tus version")
+		return false
+	}
+	return true
+}
+
+// writeTusError keeps 410 for expired uploads, which the tus protocol
+// distinguishes from uploads that never existed.
+func writeTusError(ctx *gin.Context, err error) {
+	if errors.Is(err, domain.ErrUploadSessionExpired) {
+		writeProblem(ctx, 410, apperror.MessageOf(err))
+		return
+	}
+	_ = ctx.Error(err)
+}
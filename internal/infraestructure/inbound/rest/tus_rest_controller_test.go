@@ -0,0 +1,267 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appendresumableupload "devconnectstorage/internal/application/usecase/append_resumable_upload"
+	createresumableupload "devconnectstorage/internal/application/usecase/create_resumable_upload"
+	getresumableupload "devconnectstorage/internal/application/usecase/get_resumable_upload"
+	terminateresumableupload "devconnectstorage/internal/application/usecase/terminate_resumable_upload"
+	"devconnectstorage/internal/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type CreateResumableUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *CreateResumableUploadUseCaseMock) Execute(ctx context.Context, command createresumableupload.CreateResumableUploadCommand) (domain.UploadSession, error) {
+	args := m.Called(ctx, command)
+	return args.Get(0).(domain.UploadSession), args.Error(1)
+}
+
+type GetResumableUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *GetResumableUploadUseCaseMock) Execute(ctx context.Context, query getresumableupload.GetResumableUploadQuery) (domain.UploadSession, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(domain.UploadSession), args.Error(1)
+}
+
+type AppendResumableUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *AppendResumableUploadUseCaseMock) Execute(ctx context.Context, command appendresumableupload.AppendResumableUploadCommand) (domain.UploadSession, error) {
+	content, _ := io.ReadAll(command.Content)
+	args := m.Called(ctx, command.Id, command.Offset, string(content))
+	return args.Get(0).(domain.UploadSession), args.Error(1)
+}
+
+type TerminateResumableUploadUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *TerminateResumableUploadUseCaseMock) Execute(ctx context.Context, command terminateresumableupload.TerminateResumableUploadCommand) error {
+	args := m.Called(ctx, command)
+	return args.Error(0)
+}
+
+func uploadSession(tailSize int64) domain.UploadSession {
+	session, _ := domain.RehydrateUploadSession("123", "1", "multipart-1", 10, nil, 1, tailSize, time.Now(), time.Now().Add(time.Hour))
+	return session
+}
+
+func tusRequest(method string, target string, body io.Reader) *http.Request {
+	req := httptest.NewRequest(method, target, body)
+	req.Header.Set("Tus-Resumable", "1.0.0")
+	req.AddCookie(&http.Cookie{Name: "jwt", Value: "faafdafs"})
+	return req
+}
+
+func TestTusOptions_ShouldAdvertiseExtensions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := &TusRestController{}
+	router := newTestRouter()
+	router.OPTIONS("/files/tus", controller.Options)
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, httptest.NewRequest(http.MethodOptions, "/files/tus", nil))
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "1.0.0", resp.Header().Get("Tus-Version"))
+	assert.Equal(t, "creation,expiration,termination", resp.Header().Get("Tus-Extension"))
+}
+
+func TestTusCreateUpload_ShouldReturn201WithLocation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CreateResumableUploadUseCaseMock)
+	controller := &TusRestController{createUpload: useCaseMock, basePath: "/files/tus"}
+
+	router := newTestRouter()
+	router.POST("/files/tus", controller.CreateUpload)
+
+	useCaseMock.On("Execute", mock.Anything, createresumableupload.CreateResumableUploadCommand{
+		FileName:   "demo.mp4",
+		MimeType:   "video/mp4",
+		Length:     10,
+		Visibility: "PRIVATE",
+	}).Return(uploadSession(0), nil).Once()
+
+	req := tusRequest(http.MethodPost, "/files/tus", nil)
+	req.Header.Set("Upload-Length", "10")
+	req.Header.Set("Upload-Metadata", "filename ZGVtby5tcDQ=,filetype dmlkZW8vbXA0,visibility UFJJVkFURQ==")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusCreated, resp.Code)
+	assert.Equal(t, "/files/tus/123", resp.Header().Get("Location"))
+	assert.Equal(t, "1.0.0", resp.Header().Get("Tus-Resumable"))
+	assert.NotEmpty(t, resp.Header().Get("Upload-Expires"))
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestTusCreateUpload_ShouldReturn400WithoutMetadata(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(CreateResumableUploadUseCaseMock)
+	controller := &TusRestController{createUpload: useCaseMock, basePath: "/files/tus"}
+
+	router := newTestRouter()
+	router.POST("/files/tus", controller.CreateUpload)
+
+	req := tusRequest(http.MethodPost, "/files/tus", nil)
+	req.Header.Set("Upload-Length", "10")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusBadRequest, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything)
+}
+
+func TestTusCreateUpload_ShouldReturn412ForUnsupportedVersion(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	controller := &TusRestController{basePath: "/files/tus"}
+	router := newTestRouter()
+	router.POST("/files/tus", controller.CreateUpload)
+
+	req := httptest.NewRequest(http.MethodPost, "/files/tus", nil)
+	req.Header.Set("Tus-Resumable", "0.2.2")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusPreconditionFailed, resp.Code)
+	assert.Equal(t, "1.0.0", resp.Header().Get("Tus-Version"))
+}
+
+func TestTusGetOffset_ShouldReturnOffsetHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetResumableUploadUseCaseMock)
+	controller := &TusRestController{getUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.HEAD("/files/tus/:id", controller.GetOffset)
+
+	useCaseMock.On("Execute", mock.Anything, getresumableupload.GetResumableUploadQuery{Id: "123"}).
+		Return(uploadSession(4), nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, tusRequest(http.MethodHead, "/files/tus/123", nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, "4", resp.Header().Get("Upload-Offset"))
+	assert.Equal(t, "10", resp.Header().Get("Upload-Length"))
+	assert.Equal(t, "no-store", resp.Header().Get("Cache-Control"))
+}
+
+func TestTusGetOffset_ShouldReturn404ForUnknownUpload(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(GetResumableUploadUseCaseMock)
+	controller := &TusRestController{getUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.HEAD("/files/tus/:id", controller.GetOffset)
+
+	useCaseMock.On("Execute", mock.Anything, mock.Anything).
+		Return(domain.UploadSession{}, domain.ErrUploadSessionNotFound).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, tusRequest(http.MethodHead, "/files/tus/123", nil))
+
+	assert.Equal(t, http.StatusNotFound, resp.Code)
+}
+
+func TestTusAppendChunk_ShouldReturn204WithNewOffset(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(AppendResumableUploadUseCaseMock)
+	controller := &TusRestController{appendUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.PATCH("/files/tus/:id", controller.AppendChunk)
+
+	useCaseMock.On("Execute", mock.Anything, "123", int64(0), "abcd").Return(uploadSession(4), nil).Once()
+
+	req := tusRequest(http.MethodPatch, "/files/tus/123", bytes.NewBufferString("abcd"))
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Upload-Offset", "0")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	assert.Equal(t, "4", resp.Header().Get("Upload-Offset"))
+	useCaseMock.AssertExpectations(t)
+}
+
+func TestTusAppendChunk_ShouldReturn409OnOffsetMismatch(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(AppendResumableUploadUseCaseMock)
+	controller := &TusRestController{appendUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.PATCH("/files/tus/:id", controller.AppendChunk)
+
+	useCaseMock.On("Execute", mock.Anything, "123", int64(2), "cd").
+		Return(domain.UploadSession{}, domain.ErrUploadOffsetMismatch).Once()
+
+	req := tusRequest(http.MethodPatch, "/files/tus/123", bytes.NewBufferString("cd"))
+	req.Header.Set("Content-Type", "application/offset+octet-stream")
+	req.Header.Set("Upload-Offset", "2")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusConflict, resp.Code)
+}
+
+func TestTusAppendChunk_ShouldReturn415ForWrongContentType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(AppendResumableUploadUseCaseMock)
+	controller := &TusRestController{appendUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.PATCH("/files/tus/:id", controller.AppendChunk)
+
+	req := tusRequest(http.MethodPatch, "/files/tus/123", bytes.NewBufferString("abcd"))
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Upload-Offset", "0")
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.Code)
+	useCaseMock.AssertNotCalled(t, "Execute", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTusTerminateUpload_ShouldReturn204(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	useCaseMock := new(TerminateResumableUploadUseCaseMock)
+	controller := &TusRestController{terminateUpload: useCaseMock}
+
+	router := newTestRouter()
+	router.DELETE("/files/tus/:id", controller.TerminateUpload)
+
+	useCaseMock.On("Execute", mock.Anything, terminateresumableupload.TerminateResumableUploadCommand{Id: "123"}).Return(nil).Once()
+
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, tusRequest(http.MethodDelete, "/files/tus/123", nil))
+
+	assert.Equal(t, http.StatusNoContent, resp.Code)
+	useCaseMock.AssertExpectations(t)
+}
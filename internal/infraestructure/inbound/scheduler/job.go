@@ -0,0 +1,45 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+type Task func(ctx context.Context) error
+
+type Job struct {
+	name     string
+	interval time.Duration
+	task     Task
+}
+
+func NewJob(name string, interval time.Duration, task Task) *Job {
+	return &Job{
+		name:     name,
+		interval: interval,
+		task:     task,
+	}
+}
+
+func (job *Job) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(job.interval)
+		defer ticker.Stop()
+
+		for {
+			job.run(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (job *Job) run(ctx context.Context) {
+	if err := job.task(ctx); err != nil {
+		log.Printf("job %s failed: %v", job.name, err)
+	}
+}
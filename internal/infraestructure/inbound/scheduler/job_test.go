@@ -0,0 +1,41 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJob_ShouldRunTaskPeriodically(t *testing.T) {
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	NewJob("test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return errors.New("failing runs must not stop the job")
+	}).Start(ctx)
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+}
+
+func TestJob_ShouldStopWhenContextIsCancelled(t *testing.T) {
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+
+	NewJob("test", 10*time.Millisecond, func(ctx context.Context) error {
+		runs.Add(1)
+		return nil
+	}).Start(ctx)
+
+	assert.Eventually(t, func() bool { return runs.Load() >= 1 }, time.Second, 5*time.Millisecond)
+	cancel()
+	time.Sleep(30 * time.Millisecond)
+	stoppedAt := runs.Load()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stoppedAt, runs.Load())
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"devconnectstorage/internal/apperror"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -47,17 +48,21 @@ func (ac *AuthClient) GetProfile(token string) (*int64, error) {
 func doAuthRequest(client *http.Client, req *http.Request) (*int64, error) {
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "authentication service unavailable")
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return nil, apperror.Wrap(apperror.ErrUnauthenticated, fmt.Errorf("unexpected status code: %d", resp.StatusCode), "invalid or expired session")
+	default:
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, fmt.Errorf("unexpected status code: %d", resp.StatusCode), "authentication service unavailable")
 	}
 
 	var authProfile ProfileMeResponse
 	if err := json.NewDecoder(resp.Body).Decode(&authProfile); err != nil {
-		return nil, err
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "authentication service unavailable")
 	}
 	return &authProfile.ID, nil
 }
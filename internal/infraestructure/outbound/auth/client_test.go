@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"devconnectstorage/internal/apperror"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -50,6 +51,24 @@ func TestAuthClient_GetProfile_UnexpectedStatusCode(t *testing.T) {
 	assert.Nil(t, id)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unexpected status code")
+	assert.ErrorIs(t, err, apperror.ErrUnauthenticated)
+}
+
+func TestAuthClient_GetProfile_ServerError(t *testing.T) {
+	// arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewAuthClient(server.URL)
+
+	// act
+	id, err := client.GetProfile("valid-token")
+
+	// assert
+	assert.Nil(t, id)
+	assert.ErrorIs(t, err, apperror.ErrUpstreamUnavailable)
 }
 
 func TestAuthClient_GetProfile_InvalidJSON(t *testing.T) {
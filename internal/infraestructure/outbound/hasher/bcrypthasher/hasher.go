@@ -0,0 +1,24 @@
+package bcrypthasher
+
+import (
+	"devconnectstorage/internal/apperror"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type BcryptHasher struct{}
+
+func (BcryptHasher) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (BcryptHasher) Compare(hash string, password string) error {
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+		return apperror.New(apperror.ErrForbidden, "invalid password")
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+package bcrypthasher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBcryptHasher_ShouldCompareHashedPassword(t *testing.T) {
+	hasher := BcryptHasher{}
+
+	hash, err := hasher.Hash("s3cret")
+	require.NoError(t, err)
+
+	assert.NotEqual(t, "s3cret", hash)
+	assert.NoError(t, hasher.Compare(hash, "s3cret"))
+	assert.EqualError(t, hasher.Compare(hash, "wrong"), "invalid password")
+}
@@ -0,0 +1,101 @@
+package project
+
+import (
+	"devconnectstorage/internal/apperror"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type IProjectMembershipClient interface {
+	IsMember(token string, projectID string, profileID int64) (bool, error)
+	ListProjects(token string, profileID int64) ([]string, error)
+}
+
+type projectResponse struct {
+	ID string `json:"id"`
+}
+
+type ProjectMembershipClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func NewProjectMembershipClient(baseURL string) *ProjectMembershipClient {
+	client := http.DefaultClient
+	return &ProjectMembershipClient{
+		baseURL:    baseURL,
+		httpClient: client,
+	}
+}
+
+func (pc *ProjectMembershipClient) IsMember(token string, projectID string, profileID int64) (bool, error) {
+	endpoint := fmt.Sprintf(
+		"%s/v1/projects/%s/members/%s",
+		pc.baseURL,
+		url.PathEscape(projectID),
+		strconv.FormatInt(profileID, 10),
+	)
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return false, err
+	}
+	req.AddCookie(&http.Cookie{
+		Name:  "jwt",
+		Value: token,
+	})
+
+	resp, err := pc.httpClient.Do(req)
+	if err != nil {
+		return false, apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "project service unavailable")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound, http.StatusForbidden:
+		return false, nil
+	default:
+		return false, apperror.Wrap(apperror.ErrUpstreamUnavailable, fmt.Errorf("unexpected status code: %d", resp.StatusCode), "project service unavailable")
+	}
+}
+
+// ListProjects returns the IDs of the projects profileID is a member of.
+func (pc *ProjectMembershipClient) ListProjects(token string, profileID int64) ([]string, error) {
+	endpoint := fmt.Sprintf(
+		"%s/v1/projects?member_id=%s",
+		pc.baseURL,
+		strconv.FormatInt(profileID, 10),
+	)
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.AddCookie(&http.Cookie{
+		Name:  "jwt",
+		Value: token,
+	})
+
+	resp, err := pc.httpClient.Do(req)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "project service unavailable")
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, fmt.Errorf("unexpected status code: %d", resp.StatusCode), "project service unavailable")
+	}
+
+	var projects []projectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
+		return nil, apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "project service unavailable")
+	}
+	ids := make([]string, 0, len(projects))
+	for _, project := range projects {
+		ids = append(ids, project.ID)
+	}
+	return ids, nil
+}
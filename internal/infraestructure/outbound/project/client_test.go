@@ -0,0 +1,99 @@
+package project
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectMembershipClient_IsMember_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects/project-1/members/123", r.URL.Path)
+
+		cookie, err := r.Cookie("jwt")
+		assert.NoError(t, err)
+		assert.Equal(t, "valid-token", cookie.Value)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewProjectMembershipClient(server.URL)
+
+	member, err := client.IsMember("valid-token", "project-1", 123)
+
+	assert.NoError(t, err)
+	assert.True(t, member)
+}
+
+func TestProjectMembershipClient_IsMember_NotMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewProjectMembershipClient(server.URL)
+
+	member, err := client.IsMember("valid-token", "project-1", 123)
+
+	assert.NoError(t, err)
+	assert.False(t, member)
+}
+
+func TestProjectMembershipClient_IsMember_UnexpectedStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewProjectMembershipClient(server.URL)
+
+	member, err := client.IsMember("valid-token", "project-1", 123)
+
+	assert.False(t, member)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code")
+}
+
+func TestProjectMembershipClient_IsMember_RequestError(t *testing.T) {
+	client := NewProjectMembershipClient("http://127.0.0.1:0")
+
+	member, err := client.IsMember("valid-token", "project-1", 123)
+
+	assert.False(t, member)
+	assert.Error(t, err)
+}
+
+func TestProjectMembershipClient_ListProjects_Success(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/projects", r.URL.Path)
+		assert.Equal(t, "123", r.URL.Query().Get("member_id"))
+		cookie, err := r.Cookie("jwt")
+		assert.NoError(t, err)
+		assert.Equal(t, "valid-token", cookie.Value)
+		_, _ = w.Write([]byte(`[{"id":"project-1"},{"id":"project-2"}]`))
+	}))
+	defer server.Close()
+
+	client := NewProjectMembershipClient(server.URL)
+	projects, err := client.ListProjects("valid-token", 123)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"project-1", "project-2"}, projects)
+}
+
+func TestProjectMembershipClient_ListProjects_UnexpectedStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewProjectMembershipClient(server.URL)
+	projects, err := client.ListProjects("valid-token", 123)
+
+	assert.Nil(t, projects)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected status code")
+}
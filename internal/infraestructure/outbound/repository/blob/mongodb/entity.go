@@ -0,0 +1,18 @@
+package mongodb
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type MongoBlobEntity struct {
+	Checksum   string    `bson:"_id"`
+	StorageKey string    `bson:"storage_key"`
+	Size       int64     `bson:"size"`
+	References int64     `bson:"references"`
+	CreatedAt  time.Time `bson:"created_at"`
+}
+
+func (m *MongoBlobEntity) ToDomain() (domain.Blob, error) {
+	return domain.RehydrateBlob(m.Checksum, m.StorageKey, m.Size, m.References, m.CreatedAt)
+}
@@ -0,0 +1,105 @@
+package mongodb
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoBlobRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+func NewMongoBlobRepository(
+	mongoUri string,
+	database string,
+	collection string,
+) (*MongoBlobRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		return &MongoBlobRepository{}, err
+	}
+
+	return &MongoBlobRepository{
+		client:     client,
+		database:   database,
+		collection: collection,
+	}, nil
+}
+
+func (repo MongoBlobRepository) GetBlob(ctx context.Context, checksum string) (domain.Blob, error) {
+	result := repo.blobs().FindOne(ctx, bson.M{"_id": checksum, "references": bson.M{"$gt": 0}})
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return domain.Blob{}, domain.ErrBlobNotFound
+	}
+	if result.Err() != nil {
+		return domain.Blob{}, mongoerror.Wrap(result.Err(), "blob not found")
+	}
+
+	var entity MongoBlobEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.Blob{}, mongoerror.Wrap(err, "blob not found")
+	}
+	return entity.ToDomain()
+}
+
+func (repo MongoBlobRepository) Acquire(ctx context.Context, blob domain.Blob) (domain.Blob, error) {
+	update := bson.M{
+		"$inc": bson.M{"references": 1},
+		"$setOnInsert": bson.M{
+			"storage_key": blob.StorageKey(),
+			"size":        blob.Size(),
+			"created_at":  blob.CreatedAt(),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	result := repo.blobs().FindOneAndUpdate(ctx, bson.M{"_id": blob.Checksum()}, update, opts)
+	if result.Err() != nil {
+		return domain.Blob{}, mongoerror.Wrap(result.Err(), "blob not found")
+	}
+
+	var entity MongoBlobEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.Blob{}, mongoerror.Wrap(err, "blob not found")
+	}
+	return entity.ToDomain()
+}
+
+func (repo MongoBlobRepository) Release(ctx context.Context, checksum string) (bool, error) {
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+	filter := bson.M{"_id": checksum, "references": bson.M{"$gt": 0}}
+	result := repo.blobs().FindOneAndUpdate(ctx, filter, bson.M{"$inc": bson.M{"references": -1}}, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return false, domain.ErrBlobNotFound
+	}
+	if result.Err() != nil {
+		return false, mongoerror.Wrap(result.Err(), "blob not found")
+	}
+
+	var entity MongoBlobEntity
+	if err := result.Decode(&entity); err != nil {
+		return false, mongoerror.Wrap(err, "blob not found")
+	}
+	if entity.References > 0 {
+		return false, nil
+	}
+
+	// Only the caller that removes the zero-count document owns the object;
+	// a concurrent Acquire in between revives the blob and keeps it.
+	deleted, err := repo.blobs().DeleteOne(ctx, bson.M{"_id": checksum, "references": 0})
+	if err != nil {
+		return false, mongoerror.Wrap(err, "blob not found")
+	}
+	return deleted.DeletedCount == 1, nil
+}
+
+func (repo MongoBlobRepository) blobs() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
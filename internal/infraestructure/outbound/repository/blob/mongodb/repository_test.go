@@ -0,0 +1,69 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/testcontainers/testcontainers-go/modules/mongodb"
+)
+
+const testChecksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+func newTestRepository(t *testing.T) *MongoBlobRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoBlobRepository(mongoURI, "test-db", "blobs")
+	require.NoError(t, err)
+	return repo
+}
+
+func TestMongoBlobRepository_ShouldCountReferences(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	_, err := repo.GetBlob(ctx, testChecksum)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+
+	blob, err := domain.NewBlob(testChecksum, "blobs/2c/"+testChecksum, 5)
+	require.NoError(t, err)
+
+	acquired, err := repo.Acquire(ctx, blob)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), acquired.References())
+
+	acquired, err = repo.Acquire(ctx, blob)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), acquired.References())
+
+	last, err := repo.Release(ctx, testChecksum)
+	require.NoError(t, err)
+	assert.False(t, last)
+
+	found, err := repo.GetBlob(ctx, testChecksum)
+	require.NoError(t, err)
+	assert.Equal(t, "blobs/2c/"+testChecksum, found.StorageKey())
+
+	last, err = repo.Release(ctx, testChecksum)
+	require.NoError(t, err)
+	assert.True(t, last)
+
+	_, err = repo.GetBlob(ctx, testChecksum)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+	_, err = repo.Release(ctx, testChecksum)
+	assert.ErrorIs(t, err, domain.ErrBlobNotFound)
+}
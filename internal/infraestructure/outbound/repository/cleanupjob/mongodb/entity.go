@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type MongoCleanupJobEntity struct {
+	ID           string     `bson:"_id"`
+	Scope        string     `bson:"scope"`
+	SubjectID    string     `bson:"subject_id"`
+	Status       string     `bson:"status"`
+	TrashedCount int64      `bson:"trashed_count"`
+	LastError    string     `bson:"last_error,omitempty"`
+	CreatedAt    time.Time  `bson:"created_at"`
+	UpdatedAt    time.Time  `bson:"updated_at"`
+	CompletedAt  *time.Time `bson:"completed_at,omitempty"`
+}
+
+func NewMongoCleanupJobEntity(job domain.CleanupJob) MongoCleanupJobEntity {
+	return MongoCleanupJobEntity{
+		ID:           job.ID(),
+		Scope:        string(job.Scope()),
+		SubjectID:    job.SubjectID(),
+		Status:       string(job.Status()),
+		TrashedCount: job.TrashedCount(),
+		LastError:    job.LastError(),
+		CreatedAt:    job.CreatedAt(),
+		UpdatedAt:    job.UpdatedAt(),
+		CompletedAt:  job.CompletedAt(),
+	}
+}
+
+func (m *MongoCleanupJobEntity) ToDomain() (domain.CleanupJob, error) {
+	return domain.RehydrateCleanupJob(
+		m.ID,
+		domain.CleanupScope(m.Scope),
+		m.SubjectID,
+		domain.CleanupStatus(m.Status),
+		m.TrashedCount,
+		m.LastError,
+		m.CreatedAt,
+		m.UpdatedAt,
+		m.CompletedAt,
+	)
+}
@@ -0,0 +1,108 @@
+package mongodb
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoCleanupJobRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+func NewMongoCleanupJobRepository(
+	mongoUri string,
+	database string,
+	collection string,
+) (*MongoCleanupJobRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		return &MongoCleanupJobRepository{}, err
+	}
+
+	return &MongoCleanupJobRepository{
+		client:     client,
+		database:   database,
+		collection: collection,
+	}, nil
+}
+
+func (repo MongoCleanupJobRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := repo.jobs().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "status", Value: 1}, {Key: "created_at", Value: 1}},
+	})
+	return mongoerror.Wrap(err, "cleanup job not found")
+}
+
+func (repo MongoCleanupJobRepository) Save(ctx context.Context, job domain.CleanupJob) error {
+	result, err := repo.jobs().InsertOne(ctx, NewMongoCleanupJobEntity(job))
+	if err != nil {
+		return mongoerror.Wrap(err, "cleanup job not found")
+	}
+	if result.InsertedID == nil {
+		return errors.New("failed to insert cleanup job")
+	}
+	return nil
+}
+
+func (repo MongoCleanupJobRepository) GetJob(ctx context.Context, id string) (domain.CleanupJob, error) {
+	result := repo.jobs().FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		return domain.CleanupJob{}, mongoerror.Wrap(result.Err(), "cleanup job not found")
+	}
+
+	var entity MongoCleanupJobEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.CleanupJob{}, mongoerror.Wrap(err, "cleanup job not found")
+	}
+	return entity.ToDomain()
+}
+
+// ListPending returns the oldest unfinished jobs first, so a backlog of
+// events is worked off in the order it arrived.
+func (repo MongoCleanupJobRepository) ListPending(ctx context.Context, limit int64) ([]domain.CleanupJob, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(limit)
+	cursor, err := repo.jobs().Find(ctx, bson.M{"status": string(domain.CleanupStatusPending)}, opts)
+	if err != nil {
+		return nil, mongoerror.Wrap(err, "cleanup job not found")
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var entities []MongoCleanupJobEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, mongoerror.Wrap(err, "cleanup job not found")
+	}
+
+	jobs := make([]domain.CleanupJob, 0, len(entities))
+	for _, entity := range entities {
+		job, domainError := entity.ToDomain()
+		if domainError != nil {
+			return nil, domainError
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
+
+func (repo MongoCleanupJobRepository) Update(ctx context.Context, job domain.CleanupJob) error {
+	result, err := repo.jobs().ReplaceOne(ctx, bson.M{"_id": job.ID()}, NewMongoCleanupJobEntity(job))
+	if err != nil {
+		return mongoerror.Wrap(err, "cleanup job not found")
+	}
+	if result.MatchedCount <= 0 {
+		return apperror.New(apperror.ErrNotFound, "cleanup job not found")
+	}
+	return nil
+}
+
+func (repo MongoCleanupJobRepository) jobs() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
@@ -0,0 +1,60 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/testcontainers/testcontainers-go/modules/mongodb"
+)
+
+func newTestRepository(t *testing.T) *MongoCleanupJobRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoCleanupJobRepository(mongoURI, "test-db", "cleanup_jobs")
+	require.NoError(t, err)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+	return repo
+}
+
+func TestMongoCleanupJobRepository_ShouldTrackPendingJobs(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	job, err := domain.NewCleanupJob("event-1", domain.CleanupScopeProject, "project-1")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, job))
+	assert.ErrorIs(t, repo.Save(ctx, job), apperror.ErrConflict)
+
+	pending, err := repo.ListPending(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+
+	require.NoError(t, job.RecordBatch(7, nil))
+	require.NoError(t, job.Complete())
+	require.NoError(t, repo.Update(ctx, job))
+
+	pending, err = repo.ListPending(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	persisted, err := repo.GetJob(ctx, "event-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), persisted.TrashedCount())
+	assert.Equal(t, domain.CleanupStatusCompleted, persisted.Status())
+}
@@ -6,34 +6,139 @@ import (
 )
 
 type MongoFileEntity struct {
-	ID         string    `bson:"_id"`
-	OwnerID    string    `bson:"owner_id"`
-	ProjectID  *string   `bson:"project_id,omitempty"`
-	FileName   string    `bson:"file_name"`
-	MimeType   string    `bson:"mime_type"`
-	Size       int64     `bson:"size"`
-	StorageKey string    `bson:"storage_key"`
-	Visibility string    `bson:"visibility"`
-	Status     string    `bson:"status"`
+	ID               string                   `bson:"_id"`
+	OwnerID          string                   `bson:"owner_id"`
+	ProjectID        *string                  `bson:"project_id,omitempty"`
+	FileName         string                   `bson:"file_name"`
+	MimeType         string                   `bson:"mime_type"`
+	DetectedMimeType string                   `bson:"detected_mime_type,omitempty"`
+	Size             int64                    `bson:"size"`
+	Checksum         string                   `bson:"checksum,omitempty"`
+	StorageKey       string                   `bson:"storage_key"`
+	Visibility       string                   `bson:"visibility"`
+	Status           string                   `bson:"status"`
+	CreatedAt        time.Time                `bson:"created_at"`
+	DeletedAt        *time.Time               `bson:"deleted_at,omitempty"`
+	Version          int                      `bson:"version,omitempty"`
+	Versions         []MongoFileVersionEntity `bson:"versions,omitempty"`
+	Shares           []MongoFileShareEntity   `bson:"shares,omitempty"`
+	Tags             []string                 `bson:"tags,omitempty"`
+	Attributes       map[string]string        `bson:"attributes,omitempty"`
+	Path             string                   `bson:"path,omitempty"`
+	Revision         int64                    `bson:"revision"`
+	UpdatedAt        time.Time                `bson:"updated_at,omitempty"`
+	ReservedVersion  int                      `bson:"reserved_version,omitempty"`
+}
+
+type MongoFileVersionEntity struct {
+	Number           int       `bson:"number"`
+	StorageKey       string    `bson:"storage_key"`
+	MimeType         string    `bson:"mime_type"`
+	Size             int64     `bson:"size"`
+	Checksum         string    `bson:"checksum,omitempty"`
+	CreatedAt        time.Time `bson:"created_at"`
+	ContentAddressed bool      `bson:"content_addressed,omitempty"`
+}
+
+type MongoFileShareEntity struct {
+	ProfileID  string    `bson:"profile_id"`
+	Permission string    `bson:"permission"`
 	CreatedAt  time.Time `bson:"created_at"`
 }
 
 func NewMongoFileEntity(file domain.File) MongoFileEntity {
+	versions := make([]MongoFileVersionEntity, 0, len(file.Versions()))
+	for _, version := range file.Versions() {
+		versions = append(versions, MongoFileVersionEntity{
+			Number:           version.Number(),
+			StorageKey:       version.StorageKey(),
+			MimeType:         version.MimeType(),
+			Size:             version.Size(),
+			Checksum:         version.Checksum(),
+			ContentAddressed: version.ContentAddressed(),
+			CreatedAt:        version.CreatedAt(),
+		})
+	}
+	shares := make([]MongoFileShareEntity, 0, len(file.Shares()))
+	for _, share := range file.Shares() {
+		shares = append(shares, MongoFileShareEntity{
+			ProfileID:  share.ProfileID(),
+			Permission: string(share.Permission()),
+			CreatedAt:  share.CreatedAt(),
+		})
+	}
 	return MongoFileEntity{
-		ID:         file.ID(),
-		OwnerID:    file.OwnerID(),
-		ProjectID:  file.ProjectID(),
-		FileName:   file.FileName(),
-		MimeType:   file.MimeType(),
-		Size:       file.Size(),
-		StorageKey: file.StorageKey(),
-		Visibility: string(file.Visibility()),
-		Status:     string(file.Status()),
-		CreatedAt:  file.CreatedAt(),
+		ID:               file.ID(),
+		OwnerID:          file.OwnerID(),
+		ProjectID:        file.ProjectID(),
+		FileName:         file.FileName(),
+		MimeType:         file.MimeType(),
+		DetectedMimeType: file.DetectedMimeType(),
+		Size:             file.Size(),
+		Checksum:         file.Checksum(),
+		StorageKey:       file.StorageKey(),
+		Visibility:       string(file.Visibility()),
+		Status:           string(file.Status()),
+		CreatedAt:        file.CreatedAt(),
+		DeletedAt:        file.DeletedAt(),
+		Version:          file.Version(),
+		Versions:         versions,
+		Shares:           shares,
+		Tags:             file.Tags(),
+		Attributes:       file.Attributes(),
+		Path:             file.Path(),
+		Revision:         file.Revision(),
+		UpdatedAt:        file.UpdatedAt(),
+		ReservedVersion:  file.ReservedVersion(),
 	}
 }
 
 func (m *MongoFileEntity) ToDomain() (domain.File, error) {
+	var options []domain.RehydrateOption
+	if m.Checksum != "" {
+		options = append(options, domain.WithChecksum(m.Checksum))
+	}
+	if m.DetectedMimeType != "" {
+		options = append(options, domain.WithDetectedMimeType(m.DetectedMimeType))
+	}
+	if m.DeletedAt != nil {
+		options = append(options, domain.WithDeletedAt(*m.DeletedAt))
+	}
+	if len(m.Versions) > 0 {
+		versions := make([]domain.FileVersion, 0, len(m.Versions))
+		for _, entity := range m.Versions {
+			version, err := domain.RehydrateFileVersion(entity.Number, entity.StorageKey, entity.MimeType, entity.Size, entity.Checksum, entity.ContentAddressed, entity.CreatedAt)
+			if err != nil {
+				return domain.File{}, err
+			}
+			versions = append(versions, version)
+		}
+		options = append(options, domain.WithVersions(m.Version, versions))
+	}
+	if len(m.Shares) > 0 {
+		shares := make([]domain.FileShare, 0, len(m.Shares))
+		for _, entity := range m.Shares {
+			share, err := domain.RehydrateFileShare(entity.ProfileID, domain.Permission(entity.Permission), entity.CreatedAt)
+			if err != nil {
+				return domain.File{}, err
+			}
+			shares = append(shares, share)
+		}
+		options = append(options, domain.WithShares(shares))
+	}
+	if len(m.Tags) > 0 {
+		options = append(options, domain.WithTags(m.Tags))
+	}
+	if len(m.Attributes) > 0 {
+		options = append(options, domain.WithAttributes(m.Attributes))
+	}
+	if m.Path != "" {
+		options = append(options, domain.WithPath(m.Path))
+	}
+	if m.ReservedVersion > 0 {
+		options = append(options, domain.WithReservedVersion(m.ReservedVersion))
+	}
+	options = append(options, domain.WithRevision(m.Revision, m.UpdatedAt))
 	return domain.RehydrateFile(
 		m.ID,
 		m.OwnerID,
@@ -45,5 +150,6 @@ func (m *MongoFileEntity) ToDomain() (domain.File, error) {
 		domain.Visibility(m.Visibility),
 		domain.Status(m.Status),
 		m.CreatedAt,
+		options...,
 	)
 }
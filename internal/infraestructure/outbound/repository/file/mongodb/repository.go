@@ -2,14 +2,24 @@ package mongodb
 
 import (
 	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
 	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
 	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
+// maxPathRunes bounds $substrCP lengths; stored paths are never longer.
+const maxPathRunes = 1024
+
 type MongoFileRepository struct {
 	client     *mongo.Client
 	database   string
@@ -35,10 +45,41 @@ func NewMongoFileRepository(
 	}, nil
 }
 
+func (repo MongoFileRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := repo.client.Database(repo.database).Collection(repo.collection).Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "shares.profile_id", Value: 1}, {Key: "status", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "status", Value: 1}, {Key: "size", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "status", Value: 1}, {Key: "file_name", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "status", Value: 1}, {Key: "created_at", Value: 1}, {Key: "_id", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "tags", Value: 1}, {Key: "status", Value: 1}}},
+		{
+			// Trashed and failed files give up their path so it can be reused.
+			Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "project_id", Value: 1}, {Key: "path", Value: 1}},
+			Options: options.Index().SetUnique(true).SetPartialFilterExpression(bson.M{
+				"path":   bson.M{"$exists": true},
+				"status": bson.M{"$in": bson.A{string(domain.StatusPending), string(domain.StatusAvailable)}},
+			}),
+		},
+		{Keys: bson.D{{Key: "project_id", Value: 1}, {Key: "path", Value: 1}}},
+		{Keys: bson.D{{Key: "owner_id", Value: 1}, {Key: "versions.checksum", Value: 1}}},
+		{
+			// Language "none" skips stemming and stop words, which suit file
+			// names poorly; text indexes ignore case and diacritics regardless.
+			Keys: bson.D{{Key: "file_name", Value: "text"}, {Key: "tags", Value: "text"}, {Key: "attributes.description", Value: "text"}},
+			Options: options.Index().
+				SetName("file_search").
+				SetDefaultLanguage("none").
+				SetWeights(bson.D{{Key: "file_name", Value: 10}, {Key: "tags", Value: 5}, {Key: "attributes.description", Value: 1}}),
+		},
+	})
+	return mongoerror.Wrap(err, "file not found")
+}
+
 func (repo MongoFileRepository) Save(ctx context.Context, file domain.File) (domain.File, error) {
 	result, err := repo.client.Database(repo.database).Collection(repo.collection).InsertOne(ctx, NewMongoFileEntity(file))
 	if err != nil {
-		return domain.File{}, err
+		return domain.File{}, mongoerror.Wrap(err, "file not found")
 	}
 
 	if result.InsertedID == nil {
@@ -51,13 +92,13 @@ func (repo MongoFileRepository) GetFile(ctx context.Context, id string) (domain.
 	filter := bson.M{"_id": id}
 	result := repo.client.Database(repo.database).Collection(repo.collection).FindOne(ctx, filter)
 	if result.Err() != nil {
-		return domain.File{}, result.Err()
+		return domain.File{}, mongoerror.Wrap(result.Err(), "file not found")
 	}
 
 	var mongoFile MongoFileEntity
 	err := result.Decode(&mongoFile)
 	if err != nil {
-		return domain.File{}, err
+		return domain.File{}, mongoerror.Wrap(err, "file not found")
 	}
 
 	metadata, domainError := mongoFile.ToDomain()
@@ -71,10 +112,421 @@ func (repo *MongoFileRepository) DeleteFile(ctx context.Context, id string) erro
 	filter := bson.M{"_id": id}
 	result, err := repo.client.Database(repo.database).Collection(repo.collection).DeleteOne(ctx, filter)
 	if err != nil {
-		return err
+		return mongoerror.Wrap(err, "file not found")
 	}
 	if result.DeletedCount <= 0 {
-		return errors.New("could not delete. please try again")
+		return apperror.New(apperror.ErrNotFound, "file not found")
 	}
 	return nil
 }
+
+// Update replaces the stored file only if it is still at the revision file
+// was read at, and stores it as the next revision. A file changed in between
+// fails with a conflict, so the caller can reload and retry.
+func (repo MongoFileRepository) Update(ctx context.Context, file domain.File) error {
+	filter := bson.M{"_id": file.ID(), "revision": file.Revision()}
+	if file.Revision() == 0 {
+		// Files stored before revisions were tracked have no revision field.
+		filter["revision"] = bson.M{"$in": bson.A{0, nil}}
+	}
+	entity := NewMongoFileEntity(file)
+	entity.Revision++
+	entity.UpdatedAt = time.Now()
+
+	result, err := repo.files().ReplaceOne(ctx, filter, entity)
+	if err != nil {
+		return mongoerror.Wrap(err, "file not found")
+	}
+	if result.MatchedCount > 0 {
+		return nil
+	}
+
+	count, err := repo.files().CountDocuments(ctx, bson.M{"_id": file.ID()})
+	if err != nil {
+		return mongoerror.Wrap(err, "file not found")
+	}
+	if count == 0 {
+		return apperror.New(apperror.ErrNotFound, "file not found")
+	}
+	return apperror.New(apperror.ErrConflict, "file was modified concurrently, retry the request")
+}
+
+func (repo MongoFileRepository) ListDeletedByOwner(ctx context.Context, ownerID string) ([]domain.File, error) {
+	filter := bson.M{"owner_id": ownerID, "status": string(domain.StatusDeleted)}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: -1}})
+	return repo.find(ctx, filter, opts)
+}
+
+func (repo MongoFileRepository) ListDeletedBefore(ctx context.Context, deletedBefore time.Time, limit int64) ([]domain.File, error) {
+	filter := bson.M{
+		"status":     string(domain.StatusDeleted),
+		"deleted_at": bson.M{"$lt": deletedBefore},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "deleted_at", Value: 1}}).SetLimit(limit)
+	return repo.find(ctx, filter, opts)
+}
+
+func (repo MongoFileRepository) ListStaleUploads(ctx context.Context, startedBefore time.Time, limit int64) ([]domain.File, error) {
+	filter := bson.M{
+		"status":     bson.M{"$in": bson.A{string(domain.StatusPending), string(domain.StatusFailed)}},
+		"created_at": bson.M{"$lt": startedBefore},
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}).SetLimit(limit)
+	return repo.find(ctx, filter, opts)
+}
+
+// HasContent reports whether ownerID keeps a file, in the trash or not, with a
+// version whose content hashes to checksum.
+func (repo MongoFileRepository) HasContent(ctx context.Context, ownerID string, checksum string) (bool, error) {
+	filter := bson.M{
+		"owner_id":          ownerID,
+		"versions.checksum": checksum,
+		"status":            bson.M{"$in": bson.A{string(domain.StatusAvailable), string(domain.StatusDeleted)}},
+	}
+	count, err := repo.files().CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, mongoerror.Wrap(err, "file not found")
+	}
+	return count > 0, nil
+}
+
+func (repo MongoFileRepository) ListSharedWith(ctx context.Context, profileID string) ([]domain.File, error) {
+	filter := bson.M{"shares.profile_id": profileID, "status": string(domain.StatusAvailable)}
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	return repo.find(ctx, filter, opts)
+}
+
+func (repo MongoFileRepository) ListFiles(ctx context.Context, listing aggregate.FileListing) ([]domain.File, error) {
+	field := sortField(listing.Sort)
+	direction := 1
+	if listing.Descending {
+		direction = -1
+	}
+
+	conditions := listFilter(listing.Filter)
+	if listing.After != nil {
+		conditions = append(conditions, afterCursor(field, direction, *listing.After))
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: field, Value: direction}, {Key: "_id", Value: direction}}).
+		SetLimit(listing.Limit)
+	query := bson.M{}
+	if len(conditions) > 0 {
+		query["$and"] = conditions
+	}
+	return repo.find(ctx, query, opts)
+}
+
+func (repo MongoFileRepository) SearchFiles(ctx context.Context, search aggregate.FileSearch) ([]domain.File, error) {
+	filter := bson.M{
+		"$text":  bson.M{"$search": search.Text},
+		"status": string(domain.StatusAvailable),
+		"$or":    accessibleTo(search.Reader),
+	}
+	if search.ProjectID != nil {
+		filter["project_id"] = *search.ProjectID
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "score", Value: bson.M{"$meta": "textScore"}}, {Key: "_id", Value: 1}}).
+		SetLimit(search.Limit)
+	return repo.find(ctx, filter, opts)
+}
+
+// ListFolder lists a page of the folders and files directly below the listed
+// prefix, fetching one entry more of each than the limit to tell whether the
+// listing goes on.
+func (repo MongoFileRepository) ListFolder(ctx context.Context, listing aggregate.FolderListing) (aggregate.Folder, error) {
+	base := bson.M{
+		"project_id": listing.ProjectID,
+		"status":     string(domain.StatusAvailable),
+		"$or":        accessibleTo(listing.Reader),
+	}
+	prefix := "^"
+	if listing.Prefix != "" {
+		prefix += regexp.QuoteMeta(listing.Prefix + "/")
+	}
+
+	filesPath := bson.M{"$regex": prefix + "[^/]+$"}
+	if listing.After != "" {
+		filesPath["$gt"] = folderEntryPath(listing.Prefix, listing.After)
+	}
+	filesFilter := bson.M{"path": filesPath}
+	for key, value := range base {
+		filesFilter[key] = value
+	}
+	filesOptions := options.Find().SetSort(bson.D{{Key: "path", Value: 1}, {Key: "_id", Value: 1}})
+	if listing.Limit > 0 {
+		filesOptions.SetLimit(listing.Limit + 1)
+	}
+	files, err := repo.find(ctx, filesFilter, filesOptions)
+	if err != nil {
+		return aggregate.Folder{}, err
+	}
+
+	foldersFilter := bson.M{"path": bson.M{"$regex": prefix + "[^/]+/"}}
+	for key, value := range base {
+		foldersFilter[key] = value
+	}
+	offset := 0
+	if listing.Prefix != "" {
+		offset = utf8.RuneCountInString(listing.Prefix) + 1
+	}
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: foldersFilter}},
+		{{Key: "$project", Value: bson.M{"rest": bson.M{"$substrCP": bson.A{"$path", offset, maxPathRunes}}}}},
+		{{Key: "$group", Value: bson.M{"_id": bson.M{"$arrayElemAt": bson.A{bson.M{"$split": bson.A{"$rest", "/"}}, 0}}}}},
+	}
+	if listing.After != "" {
+		pipeline = append(pipeline, bson.D{{Key: "$match", Value: bson.M{"_id": bson.M{"$gt": listing.After}}}})
+	}
+	pipeline = append(pipeline, bson.D{{Key: "$sort", Value: bson.M{"_id": 1}}})
+	if listing.Limit > 0 {
+		pipeline = append(pipeline, bson.D{{Key: "$limit", Value: listing.Limit + 1}})
+	}
+	cursor, err := repo.files().Aggregate(ctx, pipeline)
+	if err != nil {
+		return aggregate.Folder{}, mongoerror.Wrap(err, "folder not found")
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var groups []struct {
+		Name string `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &groups); err != nil {
+		return aggregate.Folder{}, mongoerror.Wrap(err, "folder not found")
+	}
+	folders := make([]string, 0, len(groups))
+	for _, group := range groups {
+		folders = append(folders, group.Name)
+	}
+	folder := aggregate.Folder{Prefix: listing.Prefix, Folders: folders, Files: files}
+	if listing.Limit > 0 {
+		folder = pageFolder(folder, listing.Limit)
+	}
+	return folder, nil
+}
+
+// pageFolder trims folders and files, each fetched with one entry more than
+// limit, to the names up to where either list was cut, so the next page can
+// start after that name without skipping entries of the other list.
+func pageFolder(folder aggregate.Folder, limit int64) aggregate.Folder {
+	cursor := ""
+	if int64(len(folder.Folders)) > limit {
+		cursor = folder.Folders[limit-1]
+	}
+	if int64(len(folder.Files)) > limit {
+		name := folderEntryName(folder.Prefix, folder.Files[limit-1])
+		if cursor == "" || name < cursor {
+			cursor = name
+		}
+	}
+	if cursor == "" {
+		return folder
+	}
+
+	folders := folder.Folders[:0:0]
+	for _, name := range folder.Folders {
+		if name <= cursor {
+			folders = append(folders, name)
+		}
+	}
+	files := folder.Files[:0:0]
+	for _, file := range folder.Files {
+		if folderEntryName(folder.Prefix, file) <= cursor {
+			files = append(files, file)
+		}
+	}
+	folder.Folders, folder.Files, folder.NextCursor = folders, files, cursor
+	return folder
+}
+
+func folderEntryPath(prefix string, name string) string {
+	if prefix == "" {
+		return name
+	}
+	return prefix + "/" + name
+}
+
+func folderEntryName(prefix string, file domain.File) string {
+	if prefix == "" {
+		return file.Path()
+	}
+	return strings.TrimPrefix(file.Path(), prefix+"/")
+}
+
+func (repo MongoFileRepository) FindByPath(ctx context.Context, projectID string, path string) ([]domain.File, error) {
+	filter := bson.M{"project_id": projectID, "path": path, "status": string(domain.StatusAvailable)}
+	return repo.find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+}
+
+// FolderExists reports whether ownerID keeps a file at path or below it.
+func (repo MongoFileRepository) FolderExists(ctx context.Context, ownerID string, projectID string, path string) (bool, error) {
+	filter := bson.M{
+		"owner_id":   ownerID,
+		"project_id": projectID,
+		"status":     bson.M{"$in": bson.A{string(domain.StatusPending), string(domain.StatusAvailable)}},
+		"$or": bson.A{
+			bson.M{"path": path},
+			bson.M{"path": bson.M{"$regex": "^" + regexp.QuoteMeta(path+"/")}},
+		},
+	}
+	count, err := repo.files().CountDocuments(ctx, filter, options.Count().SetLimit(1))
+	if err != nil {
+		return false, mongoerror.Wrap(err, "folder not found")
+	}
+	return count > 0, nil
+}
+
+// MoveFolder rewrites the path of every live file ownerID keeps below from,
+// returning how many files moved. The destination is checked before the write;
+// a file that lands on it in between is refused by the unique path index, so
+// the move reports a conflict rather than overwriting it. Moved files are
+// stored as their next revision.
+func (repo MongoFileRepository) MoveFolder(ctx context.Context, ownerID string, projectID string, from string, to string) (int64, error) {
+	exists, err := repo.FolderExists(ctx, ownerID, projectID, to)
+	if err != nil {
+		return 0, err
+	}
+	if exists {
+		return 0, apperror.New(apperror.ErrConflict, "%s already exists", to)
+	}
+
+	filter := bson.M{
+		"owner_id":   ownerID,
+		"project_id": projectID,
+		"status":     bson.M{"$in": bson.A{string(domain.StatusPending), string(domain.StatusAvailable)}},
+		"path":       bson.M{"$regex": "^" + regexp.QuoteMeta(from+"/")},
+	}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"path": bson.M{"$concat": bson.A{
+				// Paths may start with $, which a pipeline would read as a field.
+				bson.M{"$literal": to + "/"},
+				bson.M{"$substrCP": bson.A{"$path", utf8.RuneCountInString(from) + 1, maxPathRunes}},
+			}},
+			"revision":   bson.M{"$add": bson.A{bson.M{"$ifNull": bson.A{"$revision", 0}}, 1}},
+			"updated_at": time.Now(),
+		}}},
+	}
+	result, err := repo.files().UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, mongoerror.Wrap(err, "folder not found")
+	}
+	return result.ModifiedCount, nil
+}
+
+func (repo MongoFileRepository) files() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
+
+func listFilter(filter aggregate.FileFilter) bson.A {
+	conditions := bson.A{}
+	if filter.OwnerID != "" {
+		conditions = append(conditions, bson.M{"owner_id": filter.OwnerID})
+	}
+	if filter.ProjectID != nil {
+		conditions = append(conditions, bson.M{"project_id": *filter.ProjectID})
+	}
+	if filter.Visibility != "" {
+		conditions = append(conditions, bson.M{"visibility": string(filter.Visibility)})
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, bson.M{"status": string(filter.Status)})
+	}
+	if filter.MimePrefix != "" {
+		conditions = append(conditions, bson.M{"mime_type": bson.M{"$regex": "^" + regexp.QuoteMeta(filter.MimePrefix)}})
+	}
+	if len(filter.Tags) > 0 {
+		conditions = append(conditions, bson.M{"tags": bson.M{"$all": filter.Tags}})
+	}
+	for key, value := range filter.Attributes {
+		conditions = append(conditions, bson.M{"attributes." + key: value})
+	}
+	if !filter.CreatedFrom.IsZero() {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$gte": filter.CreatedFrom}})
+	}
+	if !filter.CreatedTo.IsZero() {
+		conditions = append(conditions, bson.M{"created_at": bson.M{"$lt": filter.CreatedTo}})
+	}
+	if filter.ReadableBy != nil {
+		conditions = append(conditions, bson.M{"$or": readableBy(*filter.ReadableBy)})
+	}
+	return conditions
+}
+
+// accessibleTo matches the files reader owns or may read.
+func accessibleTo(reader aggregate.Reader) bson.A {
+	return append(bson.A{bson.M{"owner_id": reader.ProfileID}}, readableBy(reader)...)
+}
+
+// readableBy matches the files reader may read without owning them, mirroring
+// the rules applied when a single file is fetched.
+func readableBy(reader aggregate.Reader) bson.A {
+	readable := bson.A{
+		bson.M{"visibility": string(domain.VisibilityPublic)},
+		bson.M{"shares.profile_id": reader.ProfileID},
+	}
+	if len(reader.MemberOf) > 0 {
+		readable = append(readable, bson.M{
+			"visibility": string(domain.VisibilityProject),
+			"project_id": bson.M{"$in": reader.MemberOf},
+		})
+	}
+	return readable
+}
+
+// afterCursor matches files past cursor in sort order, breaking ties on the
+// sort field by ID.
+func afterCursor(field string, direction int, cursor aggregate.FileCursor) bson.M {
+	operator := "$gt"
+	if direction < 0 {
+		operator = "$lt"
+	}
+	var value any
+	switch cursor.Sort {
+	case aggregate.SortBySize:
+		value = cursor.Size
+	case aggregate.SortByName:
+		value = cursor.Name
+	default:
+		value = cursor.CreatedAt
+	}
+	return bson.M{"$or": bson.A{
+		bson.M{field: bson.M{operator: value}},
+		bson.M{field: value, "_id": bson.M{operator: cursor.ID}},
+	}}
+}
+
+func sortField(sort aggregate.FileSort) string {
+	switch sort {
+	case aggregate.SortBySize:
+		return "size"
+	case aggregate.SortByName:
+		return "file_name"
+	default:
+		return "created_at"
+	}
+}
+
+func (repo MongoFileRepository) find(ctx context.Context, filter bson.M, opts *options.FindOptions) ([]domain.File, error) {
+	cursor, err := repo.client.Database(repo.database).Collection(repo.collection).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, mongoerror.Wrap(err, "file not found")
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var entities []MongoFileEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, mongoerror.Wrap(err, "file not found")
+	}
+
+	files := make([]domain.File, 0, len(entities))
+	for _, entity := range entities {
+		file, domainError := entity.ToDomain()
+		if domainError != nil {
+			return nil, domainError
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -2,9 +2,12 @@ package mongodb
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
 	"devconnectstorage/internal/domain"
 
 	"github.com/stretchr/testify/assert"
@@ -151,3 +154,447 @@ func TestMongoFileRepository_Delete_ShouldReturnErrorWhenHaveDatabaseError(t *te
 	err = repo.DeleteFile(ctx, "123")
 	assert.Error(t, err)
 }
+
+func newTestRepository(t *testing.T) *MongoFileRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoFileRepository(
+		mongoURI,
+		"",
+		"",
+		"test-db",
+		"files",
+	)
+	require.NoError(t, err)
+	return repo
+}
+
+func TestMongoFileRepository_Update_ShouldMoveFileToTrash(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	file, err := domain.RehydrateFile(
+		"file-123",
+		"owner-123",
+		nil,
+		"test.txt",
+		"text/plain",
+		42,
+		"storage/key",
+		domain.VisibilityPrivate,
+		domain.StatusAvailable,
+		time.Now(),
+	)
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, file)
+	require.NoError(t, err)
+
+	require.NoError(t, file.MarkAsDeleted())
+	require.NoError(t, repo.Update(ctx, file))
+
+	trash, err := repo.ListDeletedByOwner(ctx, "owner-123")
+	require.NoError(t, err)
+	require.Len(t, trash, 1)
+	assert.Equal(t, domain.StatusDeleted, trash[0].Status())
+	assert.NotNil(t, trash[0].DeletedAt())
+
+	expired, err := repo.ListDeletedBefore(ctx, time.Now().Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Len(t, expired, 1)
+
+	notExpired, err := repo.ListDeletedBefore(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, notExpired)
+}
+
+func TestMongoFileRepository_Update_ShouldRejectStaleRevision(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	file, err := domain.RehydrateFile("file-123", "owner-123", nil, "test.txt", "text/plain", 42, "storage/key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, file)
+	require.NoError(t, err)
+
+	first, err := repo.GetFile(ctx, "file-123")
+	require.NoError(t, err)
+	second, err := repo.GetFile(ctx, "file-123")
+	require.NoError(t, err)
+
+	require.NoError(t, first.Rename("owner-123", "first.txt"))
+	require.NoError(t, repo.Update(ctx, first))
+	require.NoError(t, second.Rename("owner-123", "second.txt"))
+	assert.ErrorIs(t, repo.Update(ctx, second), apperror.ErrConflict)
+
+	stored, err := repo.GetFile(ctx, "file-123")
+	require.NoError(t, err)
+	assert.Equal(t, "first.txt", stored.FileName())
+	assert.Equal(t, int64(1), stored.Revision())
+}
+
+func TestMongoFileRepository_Update_ShouldReturnErrorWhenIdNotExists(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	file, err := domain.NewFile("123", "owner-123", nil, "test.txt", "text/plain", 42, domain.VisibilityPrivate)
+	require.NoError(t, err)
+
+	err = repo.Update(ctx, file)
+	assert.Error(t, err)
+	assert.Equal(t, err.Error(), "could not update. please try again")
+}
+
+func TestMongoFileRepository_ListStaleUploads_ShouldReturnPendingAndFailedFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	pending, err := domain.NewFile("pending", "owner-123", nil, "a.txt", "text/plain", 1, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	failed, err := domain.NewFile("failed", "owner-123", nil, "b.txt", "text/plain", 1, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	require.NoError(t, failed.MarkAsFailed())
+	available, err := domain.NewFile("available", "owner-123", nil, "c.txt", "text/plain", 1, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	require.NoError(t, available.MarkAsAvailable("key"))
+
+	for _, file := range []domain.File{pending, failed, available} {
+		_, err := repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	stale, err := repo.ListStaleUploads(ctx, time.Now().Add(time.Minute), 10)
+	require.NoError(t, err)
+	assert.Len(t, stale, 2)
+
+	fresh, err := repo.ListStaleUploads(ctx, time.Now().Add(-time.Hour), 10)
+	require.NoError(t, err)
+	assert.Empty(t, fresh)
+}
+
+func TestMongoFileRepository_HasContent_ShouldOnlyMatchTheOwnersFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	file, err := domain.NewFile("file-123", "owner-123", nil, "hello.txt", "text/plain", 5, domain.VisibilityPrivate)
+	require.NoError(t, err)
+	require.NoError(t, file.RecordChecksum(checksum))
+	require.NoError(t, file.MarkAsAvailable("key"))
+	_, err = repo.Save(ctx, file)
+	require.NoError(t, err)
+
+	owned, err := repo.HasContent(ctx, "owner-123", checksum)
+	require.NoError(t, err)
+	assert.True(t, owned)
+
+	owned, err = repo.HasContent(ctx, "someone-else", checksum)
+	require.NoError(t, err)
+	assert.False(t, owned)
+}
+
+func TestMongoFileRepository_ListSharedWith_ShouldReturnSharedFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	shared, err := domain.RehydrateFile("shared", "owner-123", nil, "a.txt", "text/plain", 1, "key-a", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, shared.ShareWith("owner-123", "reader-1", domain.PermissionRead))
+	notShared, err := domain.RehydrateFile("private", "owner-123", nil, "b.txt", "text/plain", 1, "key-b", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+
+	for _, file := range []domain.File{shared, notShared} {
+		_, err := repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	files, err := repo.ListSharedWith(ctx, "reader-1")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	permission, found := files[0].PermissionFor("reader-1")
+	assert.True(t, found)
+	assert.Equal(t, domain.PermissionRead, permission)
+}
+
+func TestMongoFileRepository_ListFiles_ShouldPageThroughFilteredFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	createdAt := time.Now().UTC().Truncate(time.Millisecond)
+	for i, mimeType := range []string{"image/png", "image/jpeg", "text/plain", "image/gif"} {
+		file, err := domain.RehydrateFile(fmt.Sprintf("file-%d", i), "owner-123", nil, fmt.Sprintf("f%d", i), mimeType, int64(10-i), "key", domain.VisibilityPrivate, domain.StatusAvailable, createdAt.Add(time.Duration(i)*time.Second))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+	listing := aggregate.FileListing{
+		Filter: aggregate.FileFilter{OwnerID: "owner-123", Status: domain.StatusAvailable, MimePrefix: "image/"},
+		Sort:   aggregate.SortBySize,
+		Limit:  2,
+	}
+
+	first, err := repo.ListFiles(ctx, listing)
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "file-3", first[0].ID())
+	assert.Equal(t, "file-1", first[1].ID())
+
+	after := aggregate.NewFileCursor(first[1], aggregate.SortBySize, false)
+	listing.After = &after
+	second, err := repo.ListFiles(ctx, listing)
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "file-0", second[0].ID())
+}
+
+func TestMongoFileRepository_ListFiles_ShouldOnlyReturnReadableFiles(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	public, err := domain.RehydrateFile("public", "owner-123", nil, "a.txt", "text/plain", 1, "key-a", domain.VisibilityPublic, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+	private, err := domain.RehydrateFile("private", "owner-123", nil, "b.txt", "text/plain", 1, "key-b", domain.VisibilityPrivate, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+	for _, file := range []domain.File{public, private} {
+		_, err := repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	files, err := repo.ListFiles(ctx, aggregate.FileListing{
+		Filter: aggregate.FileFilter{OwnerID: "owner-123", ReadableBy: &aggregate.Reader{ProfileID: "reader-1"}},
+		Sort:   aggregate.SortByCreatedAt,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "public", files[0].ID())
+}
+
+func TestMongoFileRepository_SearchFiles_ShouldRankReadableMatches(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	files := []struct {
+		id         string
+		owner      string
+		name       string
+		visibility domain.Visibility
+	}{
+		{"mine", "reader-1", "Architecture Diagram.png", domain.VisibilityPrivate},
+		{"public", "owner-123", "architecture-notes.md", domain.VisibilityPublic},
+		{"accented", "owner-123", "diagrámme.svg", domain.VisibilityPublic},
+		{"private", "owner-123", "architecture.pdf", domain.VisibilityPrivate},
+	}
+	for _, spec := range files {
+		file, err := domain.RehydrateFile(spec.id, spec.owner, nil, spec.name, "text/plain", 1, "key", spec.visibility, domain.StatusAvailable, time.Now())
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	found, err := repo.SearchFiles(ctx, aggregate.FileSearch{Text: "ARCHITECTURE", Reader: aggregate.Reader{ProfileID: "reader-1"}, Limit: 10})
+	require.NoError(t, err)
+	ids := make([]string, 0, len(found))
+	for _, file := range found {
+		ids = append(ids, file.ID())
+	}
+	assert.ElementsMatch(t, []string{"mine", "public"}, ids)
+
+	found, err = repo.SearchFiles(ctx, aggregate.FileSearch{Text: "diagramme", Reader: aggregate.Reader{ProfileID: "reader-1"}, Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+	assert.Equal(t, "accented", found[0].ID())
+}
+
+func TestMongoFileRepository_ListFiles_ShouldFilterByTagsAndAttributes(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	tagged, err := domain.RehydrateFile("tagged", "owner-123", nil, "a.png", "image/png", 1, "key-a", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(),
+		domain.WithTags([]string{"avatar", "profile"}), domain.WithAttributes(map[string]string{"kind": "avatar"}))
+	require.NoError(t, err)
+	untagged, err := domain.RehydrateFile("untagged", "owner-123", nil, "b.png", "image/png", 1, "key-b", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(),
+		domain.WithTags([]string{"avatar"}))
+	require.NoError(t, err)
+	for _, file := range []domain.File{tagged, untagged} {
+		_, err := repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	files, err := repo.ListFiles(ctx, aggregate.FileListing{
+		Filter: aggregate.FileFilter{OwnerID: "owner-123", Tags: []string{"avatar"}, Attributes: map[string]string{"kind": "avatar"}},
+		Sort:   aggregate.SortByCreatedAt,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, []string{"avatar", "profile"}, files[0].Tags())
+	assert.Equal(t, "avatar", files[0].Attributes()["kind"])
+}
+
+func TestMongoFileRepository_FolderTree_ShouldListAndMoveFolders(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	projectID := "project-1"
+	for id, path := range map[string]string{
+		"readme": "README.md",
+		"arch":   "docs/img/arch.png",
+		"guide":  "docs/guide.md",
+	} {
+		file, err := domain.RehydrateFile(id, "owner-123", &projectID, id, "text/plain", 1, "key-"+id, domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithPath(path))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+
+	duplicate, err := domain.RehydrateFile("duplicate", "owner-123", &projectID, "dup", "text/plain", 1, "key-dup", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithPath("README.md"))
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, duplicate)
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+
+	reader := aggregate.Reader{ProfileID: "owner-123"}
+	root, err := repo.ListFolder(ctx, aggregate.FolderListing{ProjectID: projectID, Reader: reader})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs"}, root.Folders)
+	require.Len(t, root.Files, 1)
+	assert.Equal(t, "readme", root.Files[0].ID())
+
+	hidden, err := repo.ListFolder(ctx, aggregate.FolderListing{ProjectID: projectID, Reader: aggregate.Reader{ProfileID: "stranger"}})
+	require.NoError(t, err)
+	assert.Empty(t, hidden.Folders)
+	assert.Empty(t, hidden.Files)
+
+	trashed, err := domain.RehydrateFile("trashed", "owner-123", &projectID, "old", "text/plain", 1, "key-old", domain.VisibilityPrivate, domain.StatusDeleted, time.Now(), domain.WithPath("docs/old.md"))
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, trashed)
+	require.NoError(t, err)
+
+	_, err = repo.MoveFolder(ctx, "owner-123", projectID, "docs/img", "docs")
+	assert.ErrorIs(t, err, apperror.ErrConflict)
+
+	moved, err := repo.MoveFolder(ctx, "owner-123", projectID, "docs", "guides/v1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), moved)
+
+	guide, err := repo.GetFile(ctx, "guide")
+	require.NoError(t, err)
+	assert.Equal(t, "guides/v1/guide.md", guide.Path())
+	assert.Equal(t, int64(1), guide.Revision())
+	stillTrashed, err := repo.GetFile(ctx, "trashed")
+	require.NoError(t, err)
+	assert.Equal(t, "docs/old.md", stillTrashed.Path())
+
+	exists, err := repo.FolderExists(ctx, "owner-123", projectID, "docs")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	files, err := repo.FindByPath(ctx, projectID, "guides/v1/img/arch.png")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, "arch", files[0].ID())
+
+	_, err = repo.MoveFolder(ctx, "owner-123", projectID, "guides", "$archive")
+	require.NoError(t, err)
+	guide, err = repo.GetFile(ctx, "guide")
+	require.NoError(t, err)
+	assert.Equal(t, "$archive/v1/guide.md", guide.Path())
+}
+
+func TestMongoFileRepository_ListFolder_ShouldPageThroughChildren(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	projectID := "project-1"
+	for id, path := range map[string]string{
+		"a": "docs/a.md",
+		"b": "docs/b/one.md",
+		"c": "docs/c.md",
+		"d": "docs/d/two.md",
+	} {
+		file, err := domain.RehydrateFile(id, "owner-123", &projectID, id, "text/plain", 1, "key-"+id, domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithPath(path))
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+	listing := aggregate.FolderListing{ProjectID: projectID, Prefix: "docs", Reader: aggregate.Reader{ProfileID: "owner-123"}, Limit: 1}
+
+	first, err := repo.ListFolder(ctx, listing)
+	require.NoError(t, err)
+	assert.Empty(t, first.Folders)
+	require.Len(t, first.Files, 1)
+	assert.Equal(t, "a.md", first.NextCursor)
+
+	listing.After = first.NextCursor
+	second, err := repo.ListFolder(ctx, listing)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b"}, second.Folders)
+	assert.Empty(t, second.Files)
+	assert.Equal(t, "b", second.NextCursor)
+}
+
+func TestPageFolder_ShouldCutWhereEitherListEnds(t *testing.T) {
+	projectID := "project-1"
+	file := func(path string) domain.File {
+		file, err := domain.RehydrateFile(path, "owner-123", &projectID, path, "text/plain", 1, "key", domain.VisibilityPrivate, domain.StatusAvailable, time.Now(), domain.WithPath("docs/"+path))
+		require.NoError(t, err)
+		return file
+	}
+
+	page := pageFolder(aggregate.Folder{
+		Prefix:  "docs",
+		Folders: []string{"a", "c", "e"},
+		Files:   []domain.File{file("b.md"), file("d.md")},
+	}, 2)
+
+	assert.Equal(t, []string{"a", "c"}, page.Folders)
+	require.Len(t, page.Files, 1)
+	assert.Equal(t, "docs/b.md", page.Files[0].Path())
+	assert.Equal(t, "c", page.NextCursor)
+
+	last := pageFolder(aggregate.Folder{Prefix: "docs", Folders: []string{"a"}, Files: []domain.File{file("b.md")}}, 2)
+	assert.Empty(t, last.NextCursor)
+	assert.Len(t, last.Files, 1)
+}
+
+func TestMongoFileRepository_ListFiles_ShouldListProjectFilesOfEveryOwner(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+
+	projectID := "project-1"
+	otherProjectID := "project-2"
+	for id, owner := range map[string]string{"first": "owner-1", "second": "owner-2"} {
+		file, err := domain.RehydrateFile(id, owner, &projectID, id+".txt", "text/plain", 1, "key-"+id, domain.VisibilityProject, domain.StatusAvailable, time.Now())
+		require.NoError(t, err)
+		_, err = repo.Save(ctx, file)
+		require.NoError(t, err)
+	}
+	elsewhere, err := domain.RehydrateFile("elsewhere", "owner-1", &otherProjectID, "c.txt", "text/plain", 1, "key-c", domain.VisibilityProject, domain.StatusAvailable, time.Now())
+	require.NoError(t, err)
+	_, err = repo.Save(ctx, elsewhere)
+	require.NoError(t, err)
+
+	files, err := repo.ListFiles(ctx, aggregate.FileListing{
+		Filter: aggregate.FileFilter{ProjectID: &projectID, Status: domain.StatusAvailable},
+		Sort:   aggregate.SortByCreatedAt,
+		Limit:  10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, files, 2)
+}
@@ -0,0 +1,26 @@
+package mongoerror
+
+import (
+	"devconnectstorage/internal/apperror"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/x/mongo/driver/topology"
+)
+
+// Wrap classifies driver failures: a missing document is not found, duplicate
+// keys are conflicts and anything network related means Mongo is unreachable.
+func Wrap(err error, notFoundMessage string) error {
+	switch {
+	case err == nil:
+		return nil
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return apperror.Wrap(apperror.ErrNotFound, err, notFoundMessage)
+	case mongo.IsDuplicateKeyError(err):
+		return apperror.Wrap(apperror.ErrConflict, err, "resource already exists")
+	case mongo.IsNetworkError(err), mongo.IsTimeout(err), errors.Is(err, mongo.ErrClientDisconnected), errors.As(err, &topology.ServerSelectionError{}):
+		return apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "database unavailable")
+	default:
+		return err
+	}
+}
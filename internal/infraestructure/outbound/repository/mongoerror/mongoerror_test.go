@@ -0,0 +1,28 @@
+package mongoerror
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+func TestWrap_ShouldClassifyDriverErrors(t *testing.T) {
+	notFound := Wrap(mongo.ErrNoDocuments, "file not found")
+	assert.ErrorIs(t, notFound, apperror.ErrNotFound)
+	assert.ErrorIs(t, notFound, mongo.ErrNoDocuments)
+	assert.Equal(t, "file not found", apperror.MessageOf(notFound))
+
+	duplicate := Wrap(mongo.WriteException{WriteErrors: []mongo.WriteError{{Code: 11000}}}, "file not found")
+	assert.ErrorIs(t, duplicate, apperror.ErrConflict)
+
+	timeout := Wrap(context.DeadlineExceeded, "file not found")
+	assert.ErrorIs(t, timeout, apperror.ErrUpstreamUnavailable)
+
+	other := errors.New("decode failed")
+	assert.Equal(t, other, Wrap(other, "file not found"))
+	assert.NoError(t, Wrap(nil, "file not found"))
+}
@@ -0,0 +1,46 @@
+package mongodb
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type MongoShareLinkEntity struct {
+	ID            string     `bson:"_id"`
+	FileID        string     `bson:"file_id"`
+	OwnerID       string     `bson:"owner_id"`
+	PasswordHash  string     `bson:"password_hash,omitempty"`
+	MaxDownloads  int64      `bson:"max_downloads"`
+	DownloadCount int64      `bson:"download_count"`
+	ExpiresAt     time.Time  `bson:"expires_at"`
+	CreatedAt     time.Time  `bson:"created_at"`
+	RevokedAt     *time.Time `bson:"revoked_at,omitempty"`
+}
+
+func NewMongoShareLinkEntity(link domain.ShareLink) MongoShareLinkEntity {
+	return MongoShareLinkEntity{
+		ID:            link.ID(),
+		FileID:        link.FileID(),
+		OwnerID:       link.OwnerID(),
+		PasswordHash:  link.PasswordHash(),
+		MaxDownloads:  link.MaxDownloads(),
+		DownloadCount: link.DownloadCount(),
+		ExpiresAt:     link.ExpiresAt(),
+		CreatedAt:     link.CreatedAt(),
+		RevokedAt:     link.RevokedAt(),
+	}
+}
+
+func (m *MongoShareLinkEntity) ToDomain() (domain.ShareLink, error) {
+	return domain.RehydrateShareLink(
+		m.ID,
+		m.FileID,
+		m.OwnerID,
+		m.ExpiresAt,
+		m.MaxDownloads,
+		m.DownloadCount,
+		m.PasswordHash,
+		m.CreatedAt,
+		m.RevokedAt,
+	)
+}
@@ -0,0 +1,127 @@
+package mongodb
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
+	"errors"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoShareLinkRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+func NewMongoShareLinkRepository(
+	mongoUri string,
+	database string,
+	collection string,
+) (*MongoShareLinkRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		return &MongoShareLinkRepository{}, err
+	}
+
+	return &MongoShareLinkRepository{
+		client:     client,
+		database:   database,
+		collection: collection,
+	}, nil
+}
+
+func (repo MongoShareLinkRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := repo.links().Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "file_id", Value: 1}, {Key: "created_at", Value: -1}},
+	})
+	return mongoerror.Wrap(err, "share link not found")
+}
+
+func (repo MongoShareLinkRepository) Save(ctx context.Context, link domain.ShareLink) error {
+	result, err := repo.links().InsertOne(ctx, NewMongoShareLinkEntity(link))
+	if err != nil {
+		return mongoerror.Wrap(err, "share link not found")
+	}
+	if result.InsertedID == nil {
+		return errors.New("failed to insert share link")
+	}
+	return nil
+}
+
+func (repo MongoShareLinkRepository) GetShareLink(ctx context.Context, id string) (domain.ShareLink, error) {
+	result := repo.links().FindOne(ctx, bson.M{"_id": id})
+	if result.Err() != nil {
+		return domain.ShareLink{}, mongoerror.Wrap(result.Err(), "share link not found")
+	}
+
+	var entity MongoShareLinkEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.ShareLink{}, mongoerror.Wrap(err, "share link not found")
+	}
+	return entity.ToDomain()
+}
+
+func (repo MongoShareLinkRepository) ListByFile(ctx context.Context, fileID string) ([]domain.ShareLink, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	cursor, err := repo.links().Find(ctx, bson.M{"file_id": fileID}, opts)
+	if err != nil {
+		return nil, mongoerror.Wrap(err, "share link not found")
+	}
+	defer func() { _ = cursor.Close(ctx) }()
+
+	var entities []MongoShareLinkEntity
+	if err := cursor.All(ctx, &entities); err != nil {
+		return nil, mongoerror.Wrap(err, "share link not found")
+	}
+
+	links := make([]domain.ShareLink, 0, len(entities))
+	for _, entity := range entities {
+		link, domainError := entity.ToDomain()
+		if domainError != nil {
+			return nil, domainError
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+func (repo MongoShareLinkRepository) Update(ctx context.Context, link domain.ShareLink) error {
+	result, err := repo.links().ReplaceOne(ctx, bson.M{"_id": link.ID()}, NewMongoShareLinkEntity(link))
+	if err != nil {
+		return mongoerror.Wrap(err, "share link not found")
+	}
+	if result.MatchedCount <= 0 {
+		return apperror.New(apperror.ErrNotFound, "share link not found")
+	}
+	return nil
+}
+
+func (repo MongoShareLinkRepository) RegisterDownload(ctx context.Context, id string, now time.Time) error {
+	filter := bson.M{
+		"_id":        id,
+		"revoked_at": bson.M{"$exists": false},
+		"expires_at": bson.M{"$gt": now},
+		"$or": bson.A{
+			bson.M{"max_downloads": 0},
+			bson.M{"$expr": bson.M{"$lt": bson.A{"$download_count", "$max_downloads"}}},
+		},
+	}
+	result, err := repo.links().UpdateOne(ctx, filter, bson.M{"$inc": bson.M{"download_count": 1}})
+	if err != nil {
+		return mongoerror.Wrap(err, "share link not found")
+	}
+	if result.MatchedCount <= 0 {
+		return apperror.New(apperror.ErrNotFound, "share link is no longer available")
+	}
+	return nil
+}
+
+func (repo MongoShareLinkRepository) links() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
@@ -0,0 +1,72 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/testcontainers/testcontainers-go/modules/mongodb"
+)
+
+func newTestRepository(t *testing.T) *MongoShareLinkRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoShareLinkRepository(mongoURI, "test-db", "share_links")
+	require.NoError(t, err)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+	return repo
+}
+
+func TestMongoShareLinkRepository_ShouldPersistAndRevokeLinks(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	link, err := domain.NewShareLink("link-1", "file-1", "owner-1", time.Now().Add(time.Hour), 0, "hash")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, link))
+
+	links, err := repo.ListByFile(ctx, "file-1")
+	require.NoError(t, err)
+	require.Len(t, links, 1)
+	assert.True(t, links[0].RequiresPassword())
+
+	require.NoError(t, link.Revoke())
+	require.NoError(t, repo.Update(ctx, link))
+
+	persisted, err := repo.GetShareLink(ctx, "link-1")
+	require.NoError(t, err)
+	assert.NotNil(t, persisted.RevokedAt())
+	assert.Error(t, repo.RegisterDownload(ctx, "link-1", time.Now()))
+}
+
+func TestMongoShareLinkRepository_RegisterDownload_ShouldStopAtLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	link, err := domain.NewShareLink("link-1", "file-1", "owner-1", time.Now().Add(time.Hour), 2, "")
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, link))
+
+	require.NoError(t, repo.RegisterDownload(ctx, "link-1", time.Now()))
+	require.NoError(t, repo.RegisterDownload(ctx, "link-1", time.Now()))
+	assert.EqualError(t, repo.RegisterDownload(ctx, "link-1", time.Now()), "share link is no longer available")
+
+	persisted, err := repo.GetShareLink(ctx, "link-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), persisted.DownloadCount())
+}
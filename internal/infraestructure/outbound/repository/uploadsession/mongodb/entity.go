@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"devconnectstorage/internal/domain"
+	"time"
+)
+
+type MongoUploadPartEntity struct {
+	Number int    `bson:"number"`
+	ETag   string `bson:"etag"`
+	Size   int64  `bson:"size"`
+}
+
+type MongoUploadSessionEntity struct {
+	ID          string                  `bson:"_id"`
+	OwnerID     string                  `bson:"owner_id"`
+	MultipartID string                  `bson:"multipart_id"`
+	Length      int64                   `bson:"length"`
+	Offset      int64                   `bson:"offset"`
+	Parts       []MongoUploadPartEntity `bson:"parts"`
+	TailNumber  int                     `bson:"tail_number"`
+	TailSize    int64                   `bson:"tail_size"`
+	CreatedAt   time.Time               `bson:"created_at"`
+	ExpiresAt   time.Time               `bson:"expires_at"`
+}
+
+func NewMongoUploadSessionEntity(session domain.UploadSession) MongoUploadSessionEntity {
+	parts := make([]MongoUploadPartEntity, 0, len(session.Parts()))
+	for _, part := range session.Parts() {
+		parts = append(parts, MongoUploadPartEntity{
+			Number: part.Number(),
+			ETag:   part.ETag(),
+			Size:   part.Size(),
+		})
+	}
+	return MongoUploadSessionEntity{
+		ID:          session.ID(),
+		OwnerID:     session.OwnerID(),
+		MultipartID: session.MultipartID(),
+		Length:      session.Length(),
+		Offset:      session.Offset(),
+		Parts:       parts,
+		TailNumber:  session.TailNumber(),
+		TailSize:    session.TailSize(),
+		CreatedAt:   session.CreatedAt(),
+		ExpiresAt:   session.ExpiresAt(),
+	}
+}
+
+func (m *MongoUploadSessionEntity) ToDomain() (domain.UploadSession, error) {
+	parts := make([]domain.UploadPart, 0, len(m.Parts))
+	for _, entity := range m.Parts {
+		part, err := domain.RehydrateUploadPart(entity.Number, entity.ETag, entity.Size)
+		if err != nil {
+			return domain.UploadSession{}, err
+		}
+		parts = append(parts, part)
+	}
+	return domain.RehydrateUploadSession(
+		m.ID,
+		m.OwnerID,
+		m.MultipartID,
+		m.Length,
+		parts,
+		m.TailNumber,
+		m.TailSize,
+		m.CreatedAt,
+		m.ExpiresAt,
+	)
+}
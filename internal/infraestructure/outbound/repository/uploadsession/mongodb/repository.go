@@ -0,0 +1,134 @@
+package mongodb
+
+import (
+	"context"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	namespaceNotFoundCode = 26
+	indexNotFoundCode     = 27
+)
+
+type MongoUploadSessionRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+func NewMongoUploadSessionRepository(
+	mongoUri string,
+	database string,
+	collection string,
+) (*MongoUploadSessionRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		return &MongoUploadSessionRepository{}, err
+	}
+
+	return &MongoUploadSessionRepository{
+		client:     client,
+		database:   database,
+		collection: collection,
+	}, nil
+}
+
+// EnsureIndexes drops the TTL index earlier releases created on expires_at.
+// Expired sessions still hold a quota reservation, so they must be removed by
+// the stale upload sweep, which releases it, rather than by Mongo.
+func (repo MongoUploadSessionRepository) EnsureIndexes(ctx context.Context) error {
+	_, err := repo.sessions().Indexes().DropOne(ctx, "expires_at_1")
+	var serverErr mongo.ServerError
+	if errors.As(err, &serverErr) && (serverErr.HasErrorCode(indexNotFoundCode) || serverErr.HasErrorCode(namespaceNotFoundCode)) {
+		return nil
+	}
+	return mongoerror.Wrap(err, "upload session not found")
+}
+
+func (repo MongoUploadSessionRepository) Save(ctx context.Context, session domain.UploadSession) error {
+	result, err := repo.sessions().InsertOne(ctx, NewMongoUploadSessionEntity(session))
+	if err != nil {
+		return mongoerror.Wrap(err, "upload session not found")
+	}
+	if result.InsertedID == nil {
+		return errors.New("failed to insert upload session")
+	}
+	return nil
+}
+
+func (repo MongoUploadSessionRepository) GetUploadSession(ctx context.Context, id string) (domain.UploadSession, error) {
+	result := repo.sessions().FindOne(ctx, bson.M{"_id": id})
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return domain.UploadSession{}, domain.ErrUploadSessionNotFound
+	}
+	if result.Err() != nil {
+		return domain.UploadSession{}, mongoerror.Wrap(result.Err(), "upload session not found")
+	}
+
+	var entity MongoUploadSessionEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.UploadSession{}, mongoerror.Wrap(err, "upload session not found")
+	}
+	return entity.ToDomain()
+}
+
+// Update records progress made from expectedOffset. Only the progress fields
+// are written so the part counter kept by ReservePartNumber is never reset.
+func (repo MongoUploadSessionRepository) Update(ctx context.Context, session domain.UploadSession, expectedOffset int64) error {
+	entity := NewMongoUploadSessionEntity(session)
+	filter := bson.M{"_id": session.ID(), "offset": expectedOffset}
+	update := bson.M{"$set": bson.M{
+		"offset":      entity.Offset,
+		"parts":       entity.Parts,
+		"tail_number": entity.TailNumber,
+		"tail_size":   entity.TailSize,
+	}}
+	result, err := repo.sessions().UpdateOne(ctx, filter, update)
+	if err != nil {
+		return mongoerror.Wrap(err, "upload session not found")
+	}
+	if result.MatchedCount <= 0 {
+		return domain.ErrUploadOffsetMismatch
+	}
+	return nil
+}
+
+// ReservePartNumber hands out a number no other write to the session has used
+// or will use, above every part already recorded.
+func (repo MongoUploadSessionRepository) ReservePartNumber(ctx context.Context, id string) (int, error) {
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{"next_part": bson.M{"$add": bson.A{
+			bson.M{"$max": bson.A{bson.M{"$ifNull": bson.A{"$next_part", 0}}, bson.M{"$max": "$parts.number"}, "$tail_number"}},
+			1,
+		}}}}},
+	}
+	opts := options.FindOneAndUpdate().
+		SetReturnDocument(options.After).
+		SetProjection(bson.M{"next_part": 1})
+	result := repo.sessions().FindOneAndUpdate(ctx, bson.M{"_id": id}, update, opts)
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return 0, domain.ErrUploadSessionNotFound
+	}
+	var reserved struct {
+		NextPart int `bson:"next_part"`
+	}
+	if err := result.Decode(&reserved); err != nil {
+		return 0, mongoerror.Wrap(err, "upload session not found")
+	}
+	return reserved.NextPart, nil
+}
+
+func (repo MongoUploadSessionRepository) Delete(ctx context.Context, id string) error {
+	_, err := repo.sessions().DeleteOne(ctx, bson.M{"_id": id})
+	return mongoerror.Wrap(err, "upload session not found")
+}
+
+func (repo MongoUploadSessionRepository) sessions() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
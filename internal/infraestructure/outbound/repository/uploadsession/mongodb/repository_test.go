@@ -0,0 +1,70 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"devconnectstorage/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/testcontainers/testcontainers-go/modules/mongodb"
+)
+
+func newTestRepository(t *testing.T) *MongoUploadSessionRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoUploadSessionRepository(mongoURI, "test-db", "upload_sessions")
+	require.NoError(t, err)
+	require.NoError(t, repo.EnsureIndexes(ctx))
+	return repo
+}
+
+func TestMongoUploadSessionRepository_ShouldPersistProgress(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	session, err := domain.NewUploadSession("file-1", "owner-1", "multipart-1", 10, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, session))
+
+	first, err := repo.ReservePartNumber(ctx, "file-1")
+	require.NoError(t, err)
+	second, err := repo.ReservePartNumber(ctx, "file-1")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, []int{first, second})
+
+	require.NoError(t, session.AddPart(first, "etag-1", 6))
+	require.NoError(t, session.SetTail(second, 2))
+	require.NoError(t, repo.Update(ctx, session, 0))
+
+	third, err := repo.ReservePartNumber(ctx, "file-1")
+	require.NoError(t, err)
+	assert.Equal(t, 3, third)
+
+	persisted, err := repo.GetUploadSession(ctx, "file-1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), persisted.Offset())
+	assert.Equal(t, int64(2), persisted.TailSize())
+	assert.Equal(t, second, persisted.TailNumber())
+	require.Len(t, persisted.Parts(), 1)
+	assert.Equal(t, "etag-1", persisted.Parts()[0].ETag())
+
+	assert.ErrorIs(t, repo.Update(ctx, session, 0), domain.ErrUploadOffsetMismatch)
+
+	require.NoError(t, repo.Delete(ctx, "file-1"))
+	_, err = repo.GetUploadSession(ctx, "file-1")
+	assert.ErrorIs(t, err, domain.ErrUploadSessionNotFound)
+}
@@ -0,0 +1,13 @@
+package mongodb
+
+import "devconnectstorage/internal/domain"
+
+type MongoStorageUsageEntity struct {
+	OwnerID   string `bson:"_id"`
+	UsedBytes int64  `bson:"used_bytes"`
+	FileCount int64  `bson:"file_count"`
+}
+
+func (m *MongoStorageUsageEntity) ToDomain() (domain.StorageUsage, error) {
+	return domain.RehydrateStorageUsage(m.OwnerID, m.UsedBytes, m.FileCount)
+}
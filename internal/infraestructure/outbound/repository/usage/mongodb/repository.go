@@ -0,0 +1,111 @@
+package mongodb
+
+import (
+	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/outbound/repository/mongoerror"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type MongoStorageUsageRepository struct {
+	client     *mongo.Client
+	database   string
+	collection string
+}
+
+func NewMongoStorageUsageRepository(
+	mongoUri string,
+	database string,
+	collection string,
+) (*MongoStorageUsageRepository, error) {
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI(mongoUri))
+	if err != nil {
+		return &MongoStorageUsageRepository{}, err
+	}
+
+	return &MongoStorageUsageRepository{
+		client:     client,
+		database:   database,
+		collection: collection,
+	}, nil
+}
+
+func (repo MongoStorageUsageRepository) GetUsage(ctx context.Context, ownerID string) (domain.StorageUsage, error) {
+	result := repo.usages().FindOne(ctx, bson.M{"_id": ownerID})
+	if errors.Is(result.Err(), mongo.ErrNoDocuments) {
+		return domain.NewStorageUsage(ownerID)
+	}
+	if result.Err() != nil {
+		return domain.StorageUsage{}, mongoerror.Wrap(result.Err(), "storage usage not found")
+	}
+
+	var entity MongoStorageUsageEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.StorageUsage{}, mongoerror.Wrap(err, "storage usage not found")
+	}
+	return entity.ToDomain()
+}
+
+// Reserve adds bytes and files to the owner's usage unless that would exceed
+// limit. The check and the increment happen in a single update, so concurrent
+// uploads cannot overshoot the quota together.
+func (repo MongoStorageUsageRepository) Reserve(ctx context.Context, ownerID string, bytes int64, files int64, limit int64) (domain.StorageUsage, error) {
+	filter := bson.M{"_id": ownerID}
+	if limit > 0 {
+		if bytes > limit {
+			return domain.StorageUsage{}, repo.quotaExceeded(ctx, ownerID, bytes, limit)
+		}
+		filter["used_bytes"] = bson.M{"$lte": limit - bytes}
+	}
+
+	update := bson.M{"$inc": bson.M{"used_bytes": bytes, "file_count": files}}
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	result := repo.usages().FindOneAndUpdate(ctx, filter, update, opts)
+	if mongo.IsDuplicateKeyError(result.Err()) {
+		// The owner's document exists but failed the limit filter, so the
+		// upsert tried to insert it a second time.
+		return domain.StorageUsage{}, repo.quotaExceeded(ctx, ownerID, bytes, limit)
+	}
+	if result.Err() != nil {
+		return domain.StorageUsage{}, mongoerror.Wrap(result.Err(), "storage usage not found")
+	}
+
+	var entity MongoStorageUsageEntity
+	if err := result.Decode(&entity); err != nil {
+		return domain.StorageUsage{}, mongoerror.Wrap(err, "storage usage not found")
+	}
+	return entity.ToDomain()
+}
+
+// Release gives bytes and files back, never letting the counters drop below
+// zero for content stored before usage was tracked.
+func (repo MongoStorageUsageRepository) Release(ctx context.Context, ownerID string, bytes int64, files int64) error {
+	update := bson.A{bson.M{"$set": bson.M{
+		"used_bytes": bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$used_bytes", bytes}}}},
+		"file_count": bson.M{"$max": bson.A{0, bson.M{"$subtract": bson.A{"$file_count", files}}}},
+	}}}
+	if _, err := repo.usages().UpdateOne(ctx, bson.M{"_id": ownerID}, update); err != nil {
+		return mongoerror.Wrap(err, "storage usage not found")
+	}
+	return nil
+}
+
+func (repo MongoStorageUsageRepository) quotaExceeded(ctx context.Context, ownerID string, bytes int64, limit int64) error {
+	usage, err := repo.GetUsage(ctx, ownerID)
+	if err == nil {
+		err = usage.CheckCapacity(bytes, limit)
+	}
+	if err != nil {
+		return err
+	}
+	return apperror.New(apperror.ErrInsufficientStorage, "storage quota exceeded")
+}
+
+func (repo MongoStorageUsageRepository) usages() *mongo.Collection {
+	return repo.client.Database(repo.database).Collection(repo.collection)
+}
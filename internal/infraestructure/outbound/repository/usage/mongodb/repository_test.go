@@ -0,0 +1,59 @@
+package mongodb
+
+import (
+	"context"
+	"testing"
+
+	"devconnectstorage/internal/apperror"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	db "github.com/testcontainers/testcontainers-go/modules/mongodb"
+)
+
+func newTestRepository(t *testing.T) *MongoStorageUsageRepository {
+	ctx := context.Background()
+	mongoContainer, err := db.Run(
+		ctx,
+		"mongo:8.2",
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = mongoContainer.Terminate(ctx)
+	})
+
+	mongoURI, err := mongoContainer.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	repo, err := NewMongoStorageUsageRepository(mongoURI, "test-db", "storage_usage")
+	require.NoError(t, err)
+	return repo
+}
+
+func TestMongoStorageUsageRepository_ShouldEnforceLimit(t *testing.T) {
+	ctx := context.Background()
+	repo := newTestRepository(t)
+
+	usage, err := repo.GetUsage(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), usage.UsedBytes())
+
+	usage, err = repo.Reserve(ctx, "123", 60, 1, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), usage.UsedBytes())
+	assert.Equal(t, int64(1), usage.FileCount())
+
+	_, err = repo.Reserve(ctx, "123", 50, 1, 100)
+	assert.ErrorIs(t, err, apperror.ErrInsufficientStorage)
+	assert.Equal(t, "upload of 50 bytes exceeds the storage quota: 40 of 100 bytes remaining", apperror.MessageOf(err))
+
+	usage, err = repo.Reserve(ctx, "123", 40, 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), usage.UsedBytes())
+
+	require.NoError(t, repo.Release(ctx, "123", 500, 2))
+	usage, err = repo.GetUsage(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), usage.UsedBytes())
+	assert.Equal(t, int64(0), usage.FileCount())
+}
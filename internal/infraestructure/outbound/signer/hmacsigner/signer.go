@@ -0,0 +1,63 @@
+package hmacsigner
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"devconnectstorage/internal/apperror"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type HMACSigner struct {
+	secret []byte
+}
+
+func NewHMACSigner(secret []byte) (*HMACSigner, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret cannot be empty")
+	}
+	return &HMACSigner{secret: secret}, nil
+}
+
+func (signer *HMACSigner) Sign(linkID string, expiresAt time.Time) string {
+	payload := linkID + ":" + strconv.FormatInt(expiresAt.Unix(), 10)
+	encodedPayload := base64.RawURLEncoding.EncodeToString([]byte(payload))
+	return encodedPayload + "." + base64.RawURLEncoding.EncodeToString(signer.mac(encodedPayload))
+}
+
+func (signer *HMACSigner) Verify(token string, now time.Time) (string, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", apperror.New(apperror.ErrNotFound, "invalid share token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, signer.mac(encodedPayload)) {
+		return "", apperror.New(apperror.ErrNotFound, "invalid share token")
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", apperror.New(apperror.ErrNotFound, "invalid share token")
+	}
+
+	separator := strings.LastIndex(string(payload), ":")
+	if separator <= 0 {
+		return "", apperror.New(apperror.ErrNotFound, "invalid share token")
+	}
+	expiresAt, err := strconv.ParseInt(string(payload[separator+1:]), 10, 64)
+	if err != nil {
+		return "", apperror.New(apperror.ErrNotFound, "invalid share token")
+	}
+	if !now.Before(time.Unix(expiresAt, 0)) {
+		return "", apperror.New(apperror.ErrNotFound, "share link expired")
+	}
+	return string(payload[:separator]), nil
+}
+
+func (signer *HMACSigner) mac(payload string) []byte {
+	mac := hmac.New(sha256.New, signer.secret)
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
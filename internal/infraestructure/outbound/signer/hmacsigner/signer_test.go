@@ -0,0 +1,50 @@
+package hmacsigner
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHMACSigner_ShouldVerifySignedToken(t *testing.T) {
+	signer, err := NewHMACSigner([]byte("secret"))
+	require.NoError(t, err)
+
+	token := signer.Sign("link-1", time.Now().Add(time.Hour))
+	linkID, err := signer.Verify(token, time.Now())
+
+	require.NoError(t, err)
+	assert.Equal(t, "link-1", linkID)
+}
+
+func TestHMACSigner_ShouldRejectTamperedToken(t *testing.T) {
+	signer, err := NewHMACSigner([]byte("secret"))
+	require.NoError(t, err)
+	other, err := NewHMACSigner([]byte("other-secret"))
+	require.NoError(t, err)
+
+	token := other.Sign("link-1", time.Now().Add(time.Hour))
+
+	_, err = signer.Verify(token, time.Now())
+	assert.EqualError(t, err, "invalid share token")
+	_, err = signer.Verify("not-a-token", time.Now())
+	assert.EqualError(t, err, "invalid share token")
+}
+
+func TestHMACSigner_ShouldRejectExpiredToken(t *testing.T) {
+	signer, err := NewHMACSigner([]byte("secret"))
+	require.NoError(t, err)
+
+	expiresAt := time.Now().Add(time.Minute)
+	token := signer.Sign("link-1", expiresAt)
+
+	_, err = signer.Verify(token, expiresAt.Add(time.Second))
+	assert.EqualError(t, err, "share link expired")
+}
+
+func TestNewHMACSigner_ShouldRequireSecret(t *testing.T) {
+	_, err := NewHMACSigner(nil)
+	assert.Error(t, err)
+}
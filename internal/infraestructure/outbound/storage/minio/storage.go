@@ -2,18 +2,31 @@ package minioStorage
 
 import (
 	"context"
+	"devconnectstorage/internal/apperror"
+	"devconnectstorage/internal/application/aggregate"
 	"devconnectstorage/internal/domain"
+	"devconnectstorage/internal/infraestructure/httpheader"
 	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
 
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
 )
 
+// Streams of unknown length are buffered one part at a time; minio-go would
+// otherwise size parts for its 5 TiB maximum and allocate ~600 MiB per upload.
+const unknownSizePartSize = 16 * 1024 * 1024
+
 type MinIOStorage struct {
-	client *minio.Client
-	bucket string
+	client        *minio.Client
+	presignClient *minio.Client
+	bucket        string
 }
 
 func NewMinIOStorage(
@@ -33,16 +46,64 @@ func NewMinIOStorage(
 	}
 
 	return &MinIOStorage{
-		client: client,
-		bucket: bucket,
+		client:        client,
+		presignClient: client,
+		bucket:        bucket,
 	}, nil
 }
 
+func (storage *MinIOStorage) UsePublicEndpoint(endpoint string, accessKey string, secretKey string, useSSL bool, region string) error {
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+		Region: region,
+	})
+	if err != nil {
+		return err
+	}
+	storage.presignClient = client
+	return nil
+}
+
 func (storage *MinIOStorage) SaveFile(ctx context.Context, fileBytes io.Reader, file domain.File) (string, error) {
 	objectName := buildObjectKey(file)
-	info, err := storage.client.PutObject(ctx, storage.bucket, objectName, fileBytes, file.Size(), minio.PutObjectOptions{})
+	options := minio.PutObjectOptions{}
+	if file.Size() == domain.UnknownSize {
+		options.PartSize = unknownSizePartSize
+	}
+	info, err := storage.client.PutObject(ctx, storage.bucket, objectName, fileBytes, file.Size(), options)
 	if err != nil {
-		return "", err
+		return "", storageError(err, "file content not found")
+	}
+	return info.Key, nil
+}
+
+func (storage *MinIOStorage) SaveFileVersion(ctx context.Context, fileBytes io.Reader, file domain.File, version int, size int64) (string, error) {
+	objectName := buildVersionObjectKey(file, version)
+	info, err := storage.client.PutObject(ctx, storage.bucket, objectName, fileBytes, size, minio.PutObjectOptions{})
+	if err != nil {
+		return "", storageError(err, "file content not found")
+	}
+	return info.Key, nil
+}
+
+func (storage *MinIOStorage) CopyObject(ctx context.Context, sourceKey string, file domain.File, version int) (string, error) {
+	if sourceKey == "" {
+		return "", errors.New("storage key cannot be null")
+	}
+
+	objectName := buildVersionObjectKey(file, version)
+	if objectName == sourceKey {
+		return sourceKey, nil
+	}
+
+	info, err := storage.client.CopyObject(
+		ctx,
+		minio.CopyDestOptions{Bucket: storage.bucket, Object: objectName},
+		minio.CopySrcOptions{Bucket: storage.bucket, Object: sourceKey},
+	)
+	if err != nil {
+		return "", storageError(err, "file content not found")
 	}
 	return info.Key, nil
 }
@@ -52,37 +113,266 @@ func (storage *MinIOStorage) DeleteFile(ctx context.Context, file domain.File) e
 		return errors.New("storage key nil on delete")
 	}
 
-	return storage.client.RemoveObject(
+	var keys []string
+	if !file.ContentAddressed() {
+		keys = append(keys, file.StorageKey())
+	}
+	for _, version := range file.Versions() {
+		if version.ContentAddressed() {
+			continue
+		}
+		if !slices.Contains(keys, version.StorageKey()) {
+			keys = append(keys, version.StorageKey())
+		}
+	}
+
+	var removeErrors []error
+	for _, key := range keys {
+		if err := storage.DeleteObject(ctx, key); err != nil {
+			removeErrors = append(removeErrors, err)
+		}
+	}
+	return errors.Join(removeErrors...)
+}
+
+func (storage *MinIOStorage) PromoteBlob(ctx context.Context, sourceKey string, checksum string) (string, error) {
+	if sourceKey == "" {
+		return "", errors.New("storage key cannot be null")
+	}
+
+	info, err := storage.client.CopyObject(
+		ctx,
+		minio.CopyDestOptions{Bucket: storage.bucket, Object: buildBlobKey(checksum)},
+		minio.CopySrcOptions{Bucket: storage.bucket, Object: sourceKey},
+	)
+	if err != nil {
+		return "", storageError(err, "file content not found")
+	}
+	return info.Key, nil
+}
+
+func (storage *MinIOStorage) DeleteObject(ctx context.Context, storageKey string) error {
+	if storageKey == "" {
+		return errors.New("storage key nil on delete")
+	}
+
+	err := storage.client.RemoveObject(
 		ctx,
 		storage.bucket,
-		file.StorageKey(),
+		storageKey,
 		minio.RemoveObjectOptions{},
 	)
+	if err != nil {
+		return storageError(err, "file content not found")
+	}
+	return nil
+}
+
+func (storage *MinIOStorage) DiscardUpload(ctx context.Context, file domain.File) error {
+	if err := storage.client.RemoveIncompleteUpload(ctx, storage.bucket, buildStagingKey(file)); err != nil {
+		return err
+	}
+	if err := storage.DeleteUploadTail(ctx, file); err != nil {
+		return err
+	}
+	if err := storage.DeleteUpload(ctx, file); err != nil {
+		return err
+	}
+	return storage.DeleteObject(ctx, buildObjectKey(file))
+}
+
+func (storage *MinIOStorage) CreateMultipartUpload(ctx context.Context, file domain.File) (string, error) {
+	core := minio.Core{Client: storage.client}
+	return core.NewMultipartUpload(ctx, storage.bucket, buildStagingKey(file), minio.PutObjectOptions{
+		ContentType: file.MimeType(),
+	})
+}
+
+func (storage *MinIOStorage) UploadPart(ctx context.Context, file domain.File, uploadID string, number int, content io.Reader, size int64) (string, error) {
+	core := minio.Core{Client: storage.client}
+	part, err := core.PutObjectPart(ctx, storage.bucket, buildStagingKey(file), uploadID, number, content, size, minio.PutObjectPartOptions{})
+	if err != nil {
+		return "", storageError(err, "upload not found")
+	}
+	return part.ETag, nil
+}
+
+func (storage *MinIOStorage) CompleteMultipartUpload(ctx context.Context, file domain.File, uploadID string, parts []domain.UploadPart) (string, error) {
+	completeParts := make([]minio.CompletePart, 0, len(parts))
+	for _, part := range parts {
+		completeParts = append(completeParts, minio.CompletePart{PartNumber: part.Number(), ETag: part.ETag()})
+	}
+
+	core := minio.Core{Client: storage.client}
+	info, err := core.CompleteMultipartUpload(ctx, storage.bucket, buildStagingKey(file), uploadID, completeParts, minio.PutObjectOptions{})
+	if err != nil {
+		return "", storageError(err, "upload not found")
+	}
+	return info.Key, nil
+}
+
+func (storage *MinIOStorage) AbortMultipartUpload(ctx context.Context, file domain.File, uploadID string) error {
+	core := minio.Core{Client: storage.client}
+	return core.AbortMultipartUpload(ctx, storage.bucket, buildStagingKey(file), uploadID)
+}
+
+func (storage *MinIOStorage) SaveUploadTail(ctx context.Context, file domain.File, number int, content io.Reader, size int64) error {
+	_, err := storage.client.PutObject(ctx, storage.bucket, buildUploadTailKey(file, number), content, size, minio.PutObjectOptions{})
+	return err
+}
+
+func (storage *MinIOStorage) GetUploadTail(ctx context.Context, file domain.File, number int) (io.ReadCloser, error) {
+	return storage.GetFile(ctx, buildUploadTailKey(file, number), 0, -1)
+}
+
+// DeleteUploadTail removes every tail stored for file, including those left
+// by writes that lost the race to advance the upload.
+func (storage *MinIOStorage) DeleteUploadTail(ctx context.Context, file domain.File) error {
+	objects := storage.client.ListObjects(ctx, storage.bucket, minio.ListObjectsOptions{Prefix: buildUploadTailPrefix(file), Recursive: true})
+	for result := range storage.client.RemoveObjects(ctx, storage.bucket, objects, minio.RemoveObjectsOptions{}) {
+		if result.Err != nil {
+			return storageError(result.Err, "upload not found")
+		}
+	}
+	return nil
+}
+
+func (storage *MinIOStorage) PresignUpload(ctx context.Context, file domain.File, expiry time.Duration) (string, error) {
+	headers := http.Header{}
+	headers.Set("Content-Type", file.MimeType())
+
+	uploadURL, err := storage.presignClient.PresignHeader(
+		ctx,
+		http.MethodPut,
+		storage.bucket,
+		buildStagingKey(file),
+		expiry,
+		nil,
+		headers,
+	)
+	if err != nil {
+		return "", err
+	}
+	return uploadURL.String(), nil
+}
+
+// PresignDownload signs a GET that makes storage answer with the file's own
+// type and an attachment disposition, since blob keys are shared and carry
+// neither.
+func (storage *MinIOStorage) PresignDownload(ctx context.Context, storageKey string, fileName string, mimeType string, expiry time.Duration) (string, error) {
+	if storageKey == "" {
+		return "", errors.New("storage key cannot be null")
+	}
+
+	params := url.Values{}
+	params.Set("response-content-disposition", httpheader.AttachmentDisposition(fileName))
+	if mimeType != "" {
+		params.Set("response-content-type", mimeType)
+	}
+
+	downloadURL, err := storage.presignClient.PresignedGetObject(ctx, storage.bucket, storageKey, expiry, params)
+	if err != nil {
+		return "", err
+	}
+	return downloadURL.String(), nil
+}
+
+// StatUpload describes content a client PUT through a presigned URL. It sits
+// under a staging key until completion copies it to the file's own key, so
+// the URL cannot replace content once the file is available.
+func (storage *MinIOStorage) StatUpload(ctx context.Context, file domain.File) (aggregate.StoredObject, error) {
+	info, err := storage.client.StatObject(ctx, storage.bucket, buildStagingKey(file), minio.StatObjectOptions{})
+	if err != nil {
+		return aggregate.StoredObject{}, storageError(err, "uploaded content not found")
+	}
+	return aggregate.StoredObject{
+		Key:         info.Key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ETag:        info.ETag,
+	}, nil
+}
+
+func (storage *MinIOStorage) OpenUpload(ctx context.Context, file domain.File) (io.ReadCloser, error) {
+	content, err := storage.GetFile(ctx, buildStagingKey(file), 0, -1)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, apperror.Wrap(apperror.ErrNotFound, err, "uploaded content not found")
+	}
+	return content, err
+}
+
+func (storage *MinIOStorage) DeleteUpload(ctx context.Context, file domain.File) error {
+	return storage.DeleteObject(ctx, buildStagingKey(file))
+}
+
+func (storage *MinIOStorage) StatFile(ctx context.Context, storageKey string) (aggregate.StoredObject, error) {
+	if storageKey == "" {
+		return aggregate.StoredObject{}, errors.New("storage key cannot be null")
+	}
+	info, err := storage.client.StatObject(ctx, storage.bucket, storageKey, minio.StatObjectOptions{})
+	if err != nil {
+		return aggregate.StoredObject{}, storageError(err, "file content not found")
+	}
+	return aggregate.StoredObject{
+		Key:         info.Key,
+		Size:        info.Size,
+		ContentType: info.ContentType,
+		ETag:        info.ETag,
+	}, nil
 }
 
-func (storage *MinIOStorage) GetFile(ctx context.Context, storageKey string) (io.ReadCloser, error) {
+// GetFile reads length bytes starting at offset; a negative length reads to
+// the end of the object.
+func (storage *MinIOStorage) GetFile(ctx context.Context, storageKey string, offset int64, length int64) (io.ReadCloser, error) {
 
 	if storageKey == "" {
 		return nil, errors.New("storage key cannot be null")
 	}
 
+	options := minio.GetObjectOptions{}
+	if offset > 0 || length >= 0 {
+		end := int64(0)
+		if length >= 0 {
+			end = offset + length - 1
+		}
+		if err := options.SetRange(offset, end); err != nil {
+			return nil, err
+		}
+	}
+
 	obj, err := storage.client.GetObject(
 		ctx,
 		storage.bucket,
 		storageKey,
-		minio.GetObjectOptions{},
+		options,
 	)
 	if err != nil {
-		return nil, err
+		return nil, storageError(err, "file content not found")
 	}
 
 	_, err = obj.Stat()
 	if err != nil {
-		return nil, err
+		return nil, storageError(err, "file content not found")
 	}
 	return obj, nil
 }
 
+// storageError classifies MinIO failures: missing keys and uploads become
+// not found, and requests that never got an S3 response mean MinIO is down.
+func storageError(err error, notFoundMessage string) error {
+	response := minio.ToErrorResponse(err)
+	switch {
+	case apperror.KindOf(err) != nil:
+		return err
+	case response.Code == "NoSuchKey" || response.Code == "NoSuchUpload":
+		return apperror.Wrap(apperror.ErrNotFound, err, notFoundMessage)
+	case response.StatusCode == 0 && !errors.Is(err, context.Canceled):
+		return apperror.Wrap(apperror.ErrUpstreamUnavailable, err, "object storage unavailable")
+	default:
+		return err
+	}
+}
+
 func buildObjectKey(file domain.File) string {
 	if file.ProjectID() != nil {
 		return fmt.Sprintf(
@@ -100,3 +390,42 @@ func buildObjectKey(file domain.File) string {
 		file.FileName(),
 	)
 }
+
+func buildStagingKey(file domain.File) string {
+	return "uploads/" + buildObjectKey(file)
+}
+
+func buildUploadTailPrefix(file domain.File) string {
+	return buildStagingKey(file) + ".tail/"
+}
+
+func buildUploadTailKey(file domain.File, number int) string {
+	return buildUploadTailPrefix(file) + strconv.Itoa(number)
+}
+
+func buildBlobKey(checksum string) string {
+	return fmt.Sprintf("blobs/%s/%s", checksum[:2], checksum)
+}
+
+func buildVersionObjectKey(file domain.File, version int) string {
+	if version <= 1 {
+		return buildObjectKey(file)
+	}
+	if file.ProjectID() != nil {
+		return fmt.Sprintf(
+			"%s/%s/%s/v%d/%s",
+			file.OwnerID(),
+			file.ID(),
+			*file.ProjectID(),
+			version,
+			file.FileName(),
+		)
+	}
+	return fmt.Sprintf(
+		"%s/%s/v%d/%s",
+		file.OwnerID(),
+		file.ID(),
+		version,
+		file.FileName(),
+	)
+}
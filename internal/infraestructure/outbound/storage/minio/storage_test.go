@@ -3,11 +3,15 @@ package minioStorage
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
+	"devconnectstorage/internal/apperror"
 	"devconnectstorage/internal/domain"
 
 	"github.com/minio/minio-go/v7"
@@ -104,7 +108,7 @@ func TestMinIOStorage_ShoulReturnSucessWithStorageKey(t *testing.T) {
 	require.NoError(t, err)
 	assert.NotEmpty(t, key)
 
-	returnedFile, err := client.GetFile(ctx, key)
+	returnedFile, err := client.GetFile(ctx, key, 0, -1)
 	require.NotEmpty(t, returnedFile)
 	require.NoError(t, err)
 
@@ -114,11 +118,74 @@ func TestMinIOStorage_ShoulReturnSucessWithStorageKey(t *testing.T) {
 	_ = returnedFile.Close()
 }
 
+func TestMinIOStorage_ShouldSaveStreamOfUnknownSize(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+	content := []byte("streamed content")
+
+	file, err := domain.NewFile("1", "owner-123", nil, "stream.txt", "text/plain", domain.UnknownSize, domain.VisibilityPublic)
+	require.NoError(t, err)
+
+	key, err := client.SaveFile(ctx, io.NopCloser(bytes.NewReader(content)), file)
+	require.NoError(t, err)
+
+	returnedFile, err := client.GetFile(ctx, key, 0, -1)
+	require.NoError(t, err)
+	fileBytes, err := io.ReadAll(returnedFile)
+	require.NoError(t, err)
+	assert.Equal(t, content, fileBytes)
+	_ = returnedFile.Close()
+}
+
+func TestMinIOStorage_ShouldReadByteRange(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+	content := []byte("0123456789")
+
+	file, err := domain.NewFile("1", "owner-123", nil, "digits.txt", "text/plain", int64(len(content)), domain.VisibilityPublic)
+	require.NoError(t, err)
+	key, err := client.SaveFile(ctx, bytes.NewReader(content), file)
+	require.NoError(t, err)
+
+	middle, err := client.GetFile(ctx, key, 2, 3)
+	require.NoError(t, err)
+	data, err := io.ReadAll(middle)
+	require.NoError(t, err)
+	assert.Equal(t, "234", string(data))
+	_ = middle.Close()
+
+	tail, err := client.GetFile(ctx, key, 7, -1)
+	require.NoError(t, err)
+	data, err = io.ReadAll(tail)
+	require.NoError(t, err)
+	assert.Equal(t, "789", string(data))
+	_ = tail.Close()
+
+	object, err := client.StatFile(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(content)), object.Size)
+	assert.NotEmpty(t, object.ETag)
+}
+
 func TestMinIOStorage_ShoulReturnErrorOnGetFileWithoutStorageKey(t *testing.T) {
 	ctx := context.Background()
 	client, err := NewMinIOStorage("localhost:9000", "minioadmin", "minioadmin", false, "test")
 	require.NoError(t, err)
-	_, err = client.GetFile(ctx, "")
+	_, err = client.GetFile(ctx, "", 0, -1)
 	assert.Equal(t, "storage key cannot be null", err.Error())
 }
 
@@ -126,10 +193,22 @@ func TestMinIOStorage_ShoulReturnErrorOnGetFileWithoutFileInStorage(t *testing.T
 	ctx := context.Background()
 	client, err := NewMinIOStorage("localhost:9000", "minioadmin", "minioadmin", false, "test")
 	require.NoError(t, err)
-	_, err = client.GetFile(ctx, "some-key")
+	_, err = client.GetFile(ctx, "some-key", 0, -1)
 	assert.Error(t, err)
 }
 
+func TestStorageError_ShouldClassifyMinIOFailures(t *testing.T) {
+	notFound := storageError(minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound}, "file content not found")
+	assert.ErrorIs(t, notFound, apperror.ErrNotFound)
+	assert.Equal(t, "file content not found", apperror.MessageOf(notFound))
+
+	unreachable := storageError(errors.New("dial tcp 127.0.0.1:9000: connect: connection refused"), "file content not found")
+	assert.ErrorIs(t, unreachable, apperror.ErrUpstreamUnavailable)
+
+	denied := minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}
+	assert.Equal(t, error(denied), storageError(denied, "file content not found"))
+}
+
 func TestMinIOStorage_ShoulReturnErrorOnDeleteWithoutStorageKey(t *testing.T) {
 	ctx := context.Background()
 	content := []byte("file content")
@@ -140,3 +219,271 @@ func TestMinIOStorage_ShoulReturnErrorOnDeleteWithoutStorageKey(t *testing.T) {
 	err = client.DeleteFile(ctx, file)
 	assert.Error(t, err)
 }
+
+func TestMinIOStorage_ShouldSaveVersionsAndDeleteThemAll(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	firstKey, err := client.SaveFile(ctx, bytes.NewReader([]byte("v1")), file)
+	require.NoError(t, err)
+	require.NoError(t, file.MarkAsAvailable(firstKey))
+
+	secondKey, err := client.SaveFileVersion(ctx, bytes.NewReader([]byte("v2!")), file, file.NextVersionNumber(), 3)
+	require.NoError(t, err)
+	assert.NotEqual(t, firstKey, secondKey)
+	require.NoError(t, file.AddVersion(secondKey, "text/plain", 3, ""))
+
+	err = client.DeleteFile(ctx, file)
+	require.NoError(t, err)
+
+	_, err = client.GetFile(ctx, firstKey, 0, -1)
+	assert.Error(t, err)
+	_, err = client.GetFile(ctx, secondKey, 0, -1)
+	assert.Error(t, err)
+}
+
+func TestMinIOStorage_ShouldPromoteBlobAndKeepItOnFileDelete(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+	const checksum = "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	file, err := domain.NewFile("1", "owner-123", nil, "hello.txt", "text/plain", 5, domain.VisibilityPublic)
+	require.NoError(t, err)
+	uploadKey, err := client.SaveFile(ctx, bytes.NewReader([]byte("hello")), file)
+	require.NoError(t, err)
+
+	blobKey, err := client.PromoteBlob(ctx, uploadKey, checksum)
+	require.NoError(t, err)
+	assert.Equal(t, "blobs/2c/"+checksum, blobKey)
+	require.NoError(t, client.DeleteObject(ctx, uploadKey))
+
+	blob, err := domain.NewBlob(checksum, blobKey, 5)
+	require.NoError(t, err)
+	require.NoError(t, file.MarkAsAvailableFromBlob(blob))
+	require.NoError(t, client.DeleteFile(ctx, file))
+
+	content, err := client.GetFile(ctx, blobKey, 0, -1)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(data))
+	_ = content.Close()
+}
+
+func TestBuildBlobKey(t *testing.T) {
+	assert.Equal(t, "blobs/ab/abcdef", buildBlobKey("abcdef"))
+}
+
+func TestBuildVersionObjectKey(t *testing.T) {
+	projectID := "project-1"
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	projectFile, err := domain.NewFile("1", "owner-123", &projectID, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+
+	assert.Equal(t, buildObjectKey(file), buildVersionObjectKey(file, 1))
+	assert.Equal(t, "owner-123/1/v2/test.txt", buildVersionObjectKey(file, 2))
+	assert.Equal(t, "owner-123/1/project-1/v3/test.txt", buildVersionObjectKey(projectFile, 3))
+}
+
+func TestMinIOStorage_ShouldCopyObjectToRenamedKey(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	sourceKey, err := client.SaveFile(ctx, bytes.NewReader([]byte("v1")), file)
+	require.NoError(t, err)
+	require.NoError(t, file.MarkAsAvailable(sourceKey))
+	require.NoError(t, file.Rename("owner-123", "renamed.txt"))
+
+	targetKey, err := client.CopyObject(ctx, sourceKey, file, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "owner-123/1/renamed.txt", targetKey)
+
+	copied, err := client.GetFile(ctx, targetKey, 0, -1)
+	require.NoError(t, err)
+	content, err := io.ReadAll(copied)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", string(content))
+	_ = copied.Close()
+}
+
+func TestMinIOStorage_ShouldSkipCopyWhenKeyIsUnchanged(t *testing.T) {
+	ctx := context.Background()
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	client, err := NewMinIOStorage("localhost:9000", "minioadmin", "minioadmin", false, "test")
+	require.NoError(t, err)
+
+	key, err := client.CopyObject(ctx, "owner-123/1/test.txt", file, 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "owner-123/1/test.txt", key)
+}
+
+func TestMinIOStorage_ShouldPresignUploadOnPublicEndpoint(t *testing.T) {
+	ctx := context.Background()
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	client, err := NewMinIOStorage("minio:9000", "minioadmin", "minioadmin", false, "test")
+	require.NoError(t, err)
+	require.NoError(t, client.UsePublicEndpoint("storage.example.com", "minioadmin", "minioadmin", true, "us-east-1"))
+
+	uploadURL, err := client.PresignUpload(ctx, file, 5*time.Minute)
+
+	require.NoError(t, err)
+	parsed, err := url.Parse(uploadURL)
+	require.NoError(t, err)
+	assert.Equal(t, "https", parsed.Scheme)
+	assert.Equal(t, "storage.example.com", parsed.Host)
+	assert.Equal(t, "/test/uploads/owner-123/1/test.txt", parsed.Path)
+	assert.Equal(t, "300", parsed.Query().Get("X-Amz-Expires"))
+	assert.Contains(t, parsed.Query().Get("X-Amz-SignedHeaders"), "content-type")
+}
+
+func TestMinIOStorage_ShouldPresignDownloadWithContentDisposition(t *testing.T) {
+	ctx := context.Background()
+	client, err := NewMinIOStorage("minio:9000", "minioadmin", "minioadmin", false, "test")
+	require.NoError(t, err)
+	require.NoError(t, client.UsePublicEndpoint("storage.example.com", "minioadmin", "minioadmin", true, "us-east-1"))
+
+	downloadURL, err := client.PresignDownload(ctx, "owner-123/1/test.txt", "test.txt", "text/plain", time.Minute)
+
+	require.NoError(t, err)
+	parsed, err := url.Parse(downloadURL)
+	require.NoError(t, err)
+	assert.Equal(t, "storage.example.com", parsed.Host)
+	assert.Equal(t, "/test/owner-123/1/test.txt", parsed.Path)
+	assert.Equal(t, `attachment; filename=test.txt`, parsed.Query().Get("response-content-disposition"))
+	assert.Equal(t, "text/plain", parsed.Query().Get("response-content-type"))
+	assert.Equal(t, "60", parsed.Query().Get("X-Amz-Expires"))
+}
+
+func TestMinIOStorage_ShouldNotPresignDownloadWithoutStorageKey(t *testing.T) {
+	client, err := NewMinIOStorage("minio:9000", "minioadmin", "minioadmin", false, "test")
+	require.NoError(t, err)
+
+	_, err = client.PresignDownload(context.Background(), "", "test.txt", "text/plain", time.Minute)
+
+	assert.EqualError(t, err, "storage key cannot be null")
+}
+
+func TestMinIOStorage_ShouldStatPresignedUpload(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+
+	file, err := domain.NewFile("1", "owner-123", nil, "test.txt", "text/plain", 2, domain.VisibilityPublic)
+	require.NoError(t, err)
+	uploadURL, err := client.PresignUpload(ctx, file, time.Minute)
+	require.NoError(t, err)
+
+	request, err := http.NewRequest(http.MethodPut, uploadURL, bytes.NewReader([]byte("v1")))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "text/plain")
+	response, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	_ = response.Body.Close()
+	require.Equal(t, http.StatusOK, response.StatusCode)
+
+	object, err := client.StatUpload(ctx, file)
+	require.NoError(t, err)
+	assert.Equal(t, "uploads/owner-123/1/test.txt", object.Key)
+	assert.Equal(t, int64(2), object.Size)
+	assert.Equal(t, "text/plain", object.ContentType)
+
+	content, err := client.OpenUpload(ctx, file)
+	require.NoError(t, err)
+	data, err := io.ReadAll(content)
+	require.NoError(t, err)
+	_ = content.Close()
+	assert.Equal(t, "v1", string(data))
+	_, err = client.StatFile(ctx, buildObjectKey(file))
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+
+	require.NoError(t, client.DeleteUpload(ctx, file))
+	_, err = client.StatUpload(ctx, file)
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+}
+
+func TestMinIOStorage_ShouldAssembleMultipartUploadWithTail(t *testing.T) {
+	endpoint, terminate := startMinioContainer(t)
+	defer terminate()
+
+	bucket := "test-bucket"
+
+	client, err := NewMinIOStorage(endpoint, "minioadmin", "minioadmin", false, bucket)
+	require.NoError(t, err)
+	createBucketForTest(t, client, bucket)
+	ctx := context.Background()
+
+	firstPart := bytes.Repeat([]byte("a"), 5*1024*1024)
+	file, err := domain.NewFile("1", "owner-123", nil, "archive.zip", "application/zip", int64(len(firstPart)+4), domain.VisibilityPublic)
+	require.NoError(t, err)
+
+	uploadID, err := client.CreateMultipartUpload(ctx, file)
+	require.NoError(t, err)
+
+	firstETag, err := client.UploadPart(ctx, file, uploadID, 1, bytes.NewReader(firstPart), int64(len(firstPart)))
+	require.NoError(t, err)
+
+	require.NoError(t, client.SaveUploadTail(ctx, file, 2, bytes.NewReader([]byte("tail")), 4))
+	tail, err := client.GetUploadTail(ctx, file, 2)
+	require.NoError(t, err)
+	tailContent, err := io.ReadAll(tail)
+	require.NoError(t, err)
+	_ = tail.Close()
+	assert.Equal(t, "tail", string(tailContent))
+
+	secondETag, err := client.UploadPart(ctx, file, uploadID, 2, bytes.NewReader(tailContent), 4)
+	require.NoError(t, err)
+
+	first, err := domain.RehydrateUploadPart(1, firstETag, int64(len(firstPart)))
+	require.NoError(t, err)
+	second, err := domain.RehydrateUploadPart(2, secondETag, 4)
+	require.NoError(t, err)
+	key, err := client.CompleteMultipartUpload(ctx, file, uploadID, []domain.UploadPart{first, second})
+	require.NoError(t, err)
+	assert.Equal(t, "uploads/owner-123/1/archive.zip", key)
+
+	object, err := client.StatFile(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, file.Size(), object.Size)
+	assert.Equal(t, "application/zip", object.ContentType)
+
+	require.NoError(t, client.DiscardUpload(ctx, file))
+	_, err = client.GetUploadTail(ctx, file, 2)
+	assert.Error(t, err)
+	_, err = client.StatUpload(ctx, file)
+	assert.ErrorIs(t, err, apperror.ErrNotFound)
+}